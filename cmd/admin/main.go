@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tsel-ticketmaster/tm-order/config"
+	adminapp_admin "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	adminapp_customer "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/customer"
+	adminapp_event "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/event"
+	adminapp_order "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+	adminapp_ticket "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/pkg/applogger"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/postgresql"
+	"github.com/tsel-ticketmaster/tm-order/pkg/redis"
+	"github.com/tsel-ticketmaster/tm-order/pkg/validator"
+)
+
+const usage = `Usage:
+  admin create -name name -email email -password password [-role SUPER_ADMIN|EVENT_MANAGER|SUPPORT]
+  admin import-events -file path [-format yaml|csv] [-dry-run]
+  admin password-report
+  admin reset-two-factor -email email
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	os.Exit(run(os.Args[1], os.Args[2:]))
+}
+
+func run(command string, args []string) int {
+	c := config.Get()
+	logger := applogger.GetLogrus()
+	validate := validator.Get()
+
+	psqldb := postgresql.GetDatabase()
+	defer psqldb.Close()
+
+	rc := redis.GetClient()
+	defer rc.Close()
+
+	// The cli never signs in, so the json web token is not needed. The session is ended when the two-factor of an
+	// admin is reset.
+	adminUseCase := adminapp_admin.NewAdminUseCase(adminapp_admin.AdminUseCaseProperty{
+		Logger:          logger,
+		Timeout:         c.Application.Timeout,
+		CryptoSecret:    c.Crypto.Secret,
+		Session:         session.NewRedisSessionStore(logger, rc),
+		AdminRepository: adminapp_admin.NewAdminRepository(logger, psqldb),
+	})
+
+	ctx := context.Background()
+
+	var (
+		result interface{}
+		failed bool
+		err    error
+	)
+
+	switch command {
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		req := adminapp_admin.CreateAdminRequest{}
+		fs.StringVar(&req.Name, "name", "", "name of the admin")
+		fs.StringVar(&req.Email, "email", "", "email of the admin")
+		fs.StringVar(&req.Password, "password", "", "password of the admin")
+		fs.StringVar(&req.Role, "role", adminapp_admin.RoleSuperAdmin, "role of the admin")
+		fs.Parse(args)
+
+		if err = validate.StructCtx(ctx, req); err == nil {
+			result, err = adminUseCase.CreateAdmin(ctx, req)
+		}
+	case "import-events":
+		fs := flag.NewFlagSet("import-events", flag.ExitOnError)
+		var path string
+		req := adminapp_event.ImportEventRequest{}
+		fs.StringVar(&path, "file", "", "path of the yaml or csv file")
+		fs.StringVar(&req.Format, "format", "", "format of the file, it is taken from the file extension by default")
+		fs.BoolVar(&req.DryRun, "dry-run", false, "only validate the file")
+		fs.Parse(args)
+
+		if req.Format == "" {
+			req.Format = importFormat(path)
+		}
+
+		file, openErr := os.Open(path)
+		if openErr != nil {
+			fmt.Fprintln(os.Stderr, openErr)
+			return 1
+		}
+		defer file.Close()
+		req.File = file
+
+		eventUseCase := adminapp_event.NewEventUseCase(adminapp_event.EventUseCaseProperty{
+			Logger:                       logger,
+			Location:                     c.Application.Timezone,
+			Timeout:                      c.Application.Timeout,
+			Validate:                     validate,
+			EventRepository:              adminapp_event.NewEventRepository(logger, psqldb),
+			ArtistRepository:             adminapp_event.NewArtistRepository(logger, psqldb),
+			PromotorRepository:           adminapp_event.NewPromotorRepository(logger, psqldb),
+			ShowRepository:               adminapp_event.NewShowRepository(logger, psqldb),
+			LocationRepository:           adminapp_event.NewLocationRepository(logger, psqldb),
+			OrderRuleDayRepository:       adminapp_order.NewOrderRuleDayRepository(logger, psqldb),
+			OrderRuleRangeDateRepository: adminapp_order.NewOrderRuleRangeDateRepository(logger, psqldb),
+			TicketStockRepository:        adminapp_ticket.NewTicketStockRepository(logger, psqldb),
+		})
+
+		if err = validate.StructCtx(ctx, req); err == nil {
+			var resp adminapp_event.ImportEventResponse
+			resp, err = eventUseCase.ImportEvents(ctx, req)
+			result, failed = resp, len(resp.Errors) > 0
+		}
+	case "reset-two-factor":
+		fs := flag.NewFlagSet("reset-two-factor", flag.ExitOnError)
+		req := adminapp_admin.ResetTwoFactorRequest{}
+		fs.StringVar(&req.Email, "email", "", "email of the admin that has lost the authenticator and the recovery codes")
+		fs.Parse(args)
+
+		if err = validate.StructCtx(ctx, req); err == nil {
+			err = adminUseCase.ResetTwoFactor(ctx, req)
+			result = map[string]string{"message": "the admin is signed out and enrolls a new authenticator on the next sign in"}
+		}
+	case "password-report":
+		// the accounts that are still on the legacy hash are upgraded on their next sign in.
+		customerUseCase := adminapp_customer.NewCustomerUseCase(adminapp_customer.CustomerUseCaseProperty{
+			Logger:             logger,
+			Timeout:            c.Application.Timeout,
+			CustomerRepository: adminapp_customer.NewCustomerRepository(logger, psqldb),
+		})
+
+		var adminReport adminapp_admin.PasswordHashReportResponse
+		var customerReport adminapp_customer.PasswordHashReportResponse
+		if adminReport, err = adminUseCase.GetPasswordHashReport(ctx); err == nil {
+			customerReport, err = customerUseCase.GetPasswordHashReport(ctx)
+		}
+		result = map[string]interface{}{
+			"admin":    adminReport,
+			"customer": customerReport,
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errors.Destruct(err).Message)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(result)
+
+	if failed {
+		return 1
+	}
+
+	return 0
+}
+
+// importFormat returns the import format of the file extension.
+func importFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return adminapp_event.ImportFormatYAML
+	default:
+		return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+}
@@ -11,14 +11,26 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 	"github.com/tsel-ticketmaster/tm-order/config"
+	adminapp_admin "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	adminapp_customer "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/customer"
+	adminapp_dlq "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/dlq"
+	adminapp_event "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/event"
+	adminapp_export "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/export"
+	adminapp_order "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+	adminapp_ticket "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	customerapp_customer "github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/customer"
 	customerapp_event "github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/midtrans"
 	customerapp_order "github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/order"
 	customerapp_ticket "github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/ticket"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/jwt"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
 	internalMiddleare "github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/outbox"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
 	"github.com/tsel-ticketmaster/tm-order/pkg/applogger"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gcstorage"
 	"github.com/tsel-ticketmaster/tm-order/pkg/gctasks"
 	"github.com/tsel-ticketmaster/tm-order/pkg/kafka"
 	"github.com/tsel-ticketmaster/tm-order/pkg/middleware"
@@ -68,7 +80,46 @@ func main() {
 		logger.WithContext(ctx).WithError(err).Error()
 	}
 
-	publisher := pubsub.PublisherFromConfluentKafkaProducer(logger, kafka.NewProducer())
+	var schemaRegistry pubsub.SchemaRegistry
+	if c.Kafka.SchemaRegistryFile != "" {
+		sr, err := pubsub.SchemaRegistryFromFile(c.Kafka.SchemaRegistryFile)
+		if err != nil {
+			logger.WithContext(ctx).WithError(err).Fatal()
+		}
+		schemaRegistry = sr
+	}
+
+	var serializer pubsub.Serializer = pubsub.JSONSerializer{Registry: schemaRegistry}
+	if c.Kafka.Serializer == pubsub.SchemaFormatProtobuf {
+		serializer = pubsub.ProtobufSerializer{Registry: schemaRegistry}
+	}
+
+	var (
+		basePublisher pubsub.Publisher
+		dlqReader     pubsub.DeadLetterQueueReader
+	)
+	if c.PubSub.Driver == "memory" {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Logger: logger})
+		basePublisher = broker.Publisher()
+		dlqReader = broker.DeadLetterQueueReader(c.Kafka.DLQTopic)
+	} else {
+		basePublisher = pubsub.PublisherFromConfluentKafkaProducer(logger, kafka.NewProducer())
+		dlqReader = pubsub.DeadLetterQueueReaderFromConfluentKafkaConsumer(pubsub.ConfluentKafkaDLQReaderProperty{
+			Logger:   logger,
+			Topic:    c.Kafka.DLQTopic,
+			Consumer: kafka.NewConsumer(fmt.Sprintf("%s-dlq-reader", c.Application.Name), false),
+		})
+	}
+
+	publisher := pubsub.PublisherWithSerializer(basePublisher, serializer)
+
+	eventOutbox := outbox.NewOutbox(outbox.OutboxProperty{
+		Logger:     logger,
+		Publisher:  basePublisher,
+		Serializer: serializer,
+		Repository: outbox.NewRepository(logger, psqldb),
+	})
+	go eventOutbox.Run(ctx, c.Outbox.RelayInterval)
 
 	rc := redis.GetClient()
 	if err := rc.Ping(context.Background()).Err(); err != nil {
@@ -76,11 +127,16 @@ func main() {
 	}
 
 	cloudTask := gctasks.NewGCTasks(logger, c.GCP.ProjectID, c.GCP.ServiceAccount)
+	exportStorage := gcstorage.NewGCStorage(logger, c.Export.Bucket, c.GCP.ServiceAccount)
 
 	session := session.NewRedisSessionStore(logger, rc)
 
+	adminSessionMiddleware := internalMiddleare.NewAdminSessionMiddleware(jsonWebToken, session)
 	customerSessionMiddleware := internalMiddleare.NewCustomerSessionMiddleware(jsonWebToken, session)
 
+	customerSignInEmailGuard := lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByEmail)
+	customerSignInIPGuard := lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByIP)
+
 	router := mux.NewRouter()
 	router.Use(
 		otelmux.Middleware(c.Application.Name),
@@ -89,6 +145,111 @@ func main() {
 	)
 
 	// admin's app
+	adminappAdminRepo := adminapp_admin.NewAdminRepository(logger, psqldb)
+	adminappAdminUseCase := adminapp_admin.NewAdminUseCase(adminapp_admin.AdminUseCaseProperty{
+		Logger:           logger,
+		Timeout:          c.Application.Timeout,
+		CryptoSecret:     c.Crypto.Secret,
+		JSONWebToken:     jsonWebToken,
+		Session:          session,
+		Cache:            rc,
+		AdminRepository:  adminappAdminRepo,
+		SignInEmailGuard: lockout.NewRedisGuard(logger, rc, lockout.AdminSignInByEmail),
+		SignInIPGuard:    lockout.NewRedisGuard(logger, rc, lockout.AdminSignInByIP),
+	})
+	adminapp_admin.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappAdminUseCase, c.Application.TrustedProxyHops)
+
+	orderRuleRegistry := orderrule.NewDefaultRegistry()
+
+	adminappEventRepo := adminapp_event.NewEventRepository(logger, psqldb)
+	adminappArtistRepo := adminapp_event.NewArtistRepository(logger, psqldb)
+	adminappPromotorRepo := adminapp_event.NewPromotorRepository(logger, psqldb)
+	adminappShowRepo := adminapp_event.NewShowRepository(logger, psqldb)
+	adminappLocationRepo := adminapp_event.NewLocationRepository(logger, psqldb)
+	adminappOrderRuleDayRepo := adminapp_order.NewOrderRuleDayRepository(logger, psqldb)
+	adminappOrderRuleRangeDateRepo := adminapp_order.NewOrderRuleRangeDateRepository(logger, psqldb)
+	adminappOrderRuleRepo := adminapp_order.NewOrderRuleRepository(logger, psqldb)
+	adminappTicketStockRepo := adminapp_ticket.NewTicketStockRepository(logger, psqldb)
+	adminappSalesRepo := adminapp_event.NewSalesRepository(logger, psqldb)
+	adminappEventUseCase := adminapp_event.NewEventUseCase(adminapp_event.EventUseCaseProperty{
+		Logger:                       logger,
+		Location:                     c.Application.Timezone,
+		Timeout:                      c.Application.Timeout,
+		Validate:                     validate,
+		EventRepository:              adminappEventRepo,
+		ArtistRepository:             adminappArtistRepo,
+		PromotorRepository:           adminappPromotorRepo,
+		ShowRepository:               adminappShowRepo,
+		LocationRepository:           adminappLocationRepo,
+		OrderRuleDayRepository:       adminappOrderRuleDayRepo,
+		OrderRuleRangeDateRepository: adminappOrderRuleRangeDateRepo,
+		OrderRuleRepository:          adminappOrderRuleRepo,
+		OrderRuleRegistry:            orderRuleRegistry,
+		TicketStockRepository:        adminappTicketStockRepo,
+		SalesRepository:              adminappSalesRepo,
+	})
+	adminapp_event.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappEventUseCase)
+
+	adminappTicketStockJournalRepo := adminapp_ticket.NewTicketStockJournalRepository(logger, psqldb)
+	adminappTicketUseCase := adminapp_ticket.NewTicketUseCase(adminapp_ticket.TicketUseCaseProperty{
+		Logger:                       logger,
+		Timeout:                      c.Application.Timeout,
+		TicketStockRepository:        adminappTicketStockRepo,
+		TicketStockJournalRepository: adminappTicketStockJournalRepo,
+	})
+	adminapp_ticket.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappTicketUseCase)
+
+	adminappOrderRepo := adminapp_order.NewOrderRepository(logger, psqldb)
+	adminappItemRepo := adminapp_order.NewItemRepository(logger, psqldb)
+	adminappOrderAuditRepo := adminapp_order.NewOrderAuditRepository(logger, psqldb)
+	adminappOrderUseCase := adminapp_order.NewOrderUseCase(adminapp_order.OrderUseCaseProperty{
+		AppName:              c.Application.Name,
+		Logger:               logger,
+		Timeout:              c.Application.Timeout,
+		OrderRepository:      adminappOrderRepo,
+		ItemRepository:       adminappItemRepo,
+		OrderAuditRepository: adminappOrderAuditRepo,
+		Outbox:               eventOutbox,
+	})
+	adminapp_order.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappOrderUseCase)
+
+	adminappExportRepo := adminapp_export.NewExportRepository(logger, psqldb)
+	adminappExportJobRepo := adminapp_export.NewJobRepository(logger, rc)
+	adminappExportUseCase := adminapp_export.NewExportUseCase(adminapp_export.ExportUseCaseProperty{
+		Logger:           logger,
+		Timeout:          c.Application.Timeout,
+		StreamTimeout:    c.Export.StreamTimeout,
+		JobTimeout:       c.Export.JobTimeout,
+		JobRetention:     c.Export.JobRetention,
+		SyncMaxRows:      c.Export.SyncMaxRows,
+		BaseURL:          c.Application.TMOrder.BaseURL,
+		ExportRepository: adminappExportRepo,
+		JobRepository:    adminappExportJobRepo,
+		Storage:          exportStorage,
+		CloudTask:        cloudTask,
+	})
+	adminapp_export.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappExportUseCase)
+
+	adminappDLQReplayRepo := adminapp_dlq.NewReplayRepository(logger, psqldb)
+	adminappDLQUseCase := adminapp_dlq.NewDLQUseCase(adminapp_dlq.DLQUseCaseProperty{
+		Logger:           logger,
+		Timeout:          c.Application.Timeout,
+		ReadTimeout:      c.Kafka.DLQReadTimeout,
+		Topic:            c.Kafka.DLQTopic,
+		Reader:           dlqReader,
+		Publisher:        publisher,
+		ReplayRepository: adminappDLQReplayRepo,
+	})
+	adminapp_dlq.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappDLQUseCase)
+
+	adminappCustomerUseCase := adminapp_customer.NewCustomerUseCase(adminapp_customer.CustomerUseCaseProperty{
+		Logger:             logger,
+		Timeout:            c.Application.Timeout,
+		SignInEmailGuard:   customerSignInEmailGuard,
+		SignInIPGuard:      customerSignInIPGuard,
+		CustomerRepository: adminapp_customer.NewCustomerRepository(logger, psqldb),
+	})
+	adminapp_customer.InitHTTPHandler(router, adminSessionMiddleware, validate, adminappCustomerUseCase)
 
 	// customer's app
 	customerappEventRepo := customerapp_event.NewEventRepository(logger, psqldb)
@@ -97,10 +258,35 @@ func main() {
 	customerappOrderItemRepo := customerapp_order.NewItemRepository(logger, psqldb)
 	customerappOrderRuleRangeDateRepo := customerapp_order.NewOrderRuleRangeDateRepository(logger, psqldb)
 	customerappOrderRuleDayRepo := customerapp_order.NewOrderRuleDayRepository(logger, psqldb)
+	customerappOrderRuleRepo := customerapp_order.NewOrderRuleRepository(logger, psqldb)
+	customerappCustomerRepo := customerapp_customer.NewCustomerRepository(logger, psqldb)
 	customerappTicketRepo := customerapp_ticket.NewTicketStockRepository(logger, psqldb)
 	customerappAcquiredTicketRepo := customerapp_ticket.NewAcquiredTicketRepository(logger, psqldb)
+	customerappCustomerUseCase := customerapp_customer.NewCustomerUseCase(customerapp_customer.CustomerUseCaseProperty{
+		AppName:                  c.Application.Name,
+		Logger:                   logger,
+		Timeout:                  c.Application.Timeout,
+		TMUserBaseURL:            c.Application.TMUser.BaseURL,
+		BaseURL:                  c.Application.TMOrder.BaseURL,
+		VerificationExpiration:   c.Customer.VerificationExpiration,
+		DeletionGracePeriod:      c.Customer.DeletionGracePeriod,
+		CryptoSecret:             c.Crypto.Secret,
+		JSONWebToken:             jsonWebToken,
+		Session:                  session,
+		Cache:                    rc,
+		Publisher:                publisher,
+		CloudTask:                cloudTask,
+		CustomerRepository:       customerappCustomerRepo,
+		OrderRepository:          customerapp_customer.NewOrderRepository(logger, psqldb),
+		AcquiredTicketRepository: customerapp_customer.NewAcquiredTicketRepository(logger, psqldb),
+		SignInEmailGuard:         customerSignInEmailGuard,
+		SignInIPGuard:            customerSignInIPGuard,
+	})
+	customerapp_customer.InitHTTPHandler(router, customerSessionMiddleware, validate, customerappCustomerUseCase, c.Application.TrustedProxyHops)
+
 	midtransRepo := midtrans.NewMidtransRepository(c.Midtrans.BaseURL, c.Midtrans.BasicAuthKey, logger, hc)
 	customerappOrderUseCase := customerapp_order.NewOrderUseCase(customerapp_order.OrderUseCaseProperty{
+		AppName:                      c.Application.Name,
 		Logger:                       logger,
 		Timeout:                      c.Application.Timeout,
 		BaseURL:                      c.Application.TMOrder.BaseURL,
@@ -112,15 +298,34 @@ func main() {
 		TicketStockRepository:        customerappTicketRepo,
 		OrderRuleRangeDateRepository: customerappOrderRuleRangeDateRepo,
 		OrderRuleDay:                 customerappOrderRuleDayRepo,
+		OrderRuleRepository:          customerappOrderRuleRepo,
+		OrderRuleRegistry:            orderRuleRegistry,
+		CustomerRepository:           customerappCustomerRepo,
 		OrderRepository:              customerappOrderRepo,
 		ItemRepository:               customerappOrderItemRepo,
 		Publisher:                    publisher,
+		Outbox:                       eventOutbox,
 		MidtransRepository:           midtransRepo,
 		CloudTask:                    cloudTask,
 		AcquiredTicketRepository:     customerappAcquiredTicketRepo,
 	})
 	customerapp_order.InitHTTPHandler(router, customerSessionMiddleware, validate, customerappOrderUseCase)
 
+	customerappEventUseCase := customerapp_event.NewEventUseCase(customerapp_event.EventUseCaseProperty{
+		Logger:                       logger,
+		Timeout:                      c.Application.Timeout,
+		EventRepository:              customerappEventRepo,
+		ArtistRepository:             customerapp_event.NewArtistRepository(logger, psqldb),
+		ShowRepository:               customerappShowRepo,
+		LocationRepository:           customerapp_event.NewLocationRepository(logger, psqldb),
+		TicketStockRepository:        customerappTicketRepo,
+		OrderRuleRangeDateRepository: adminappOrderRuleRangeDateRepo,
+		OrderRuleDayRepository:       adminappOrderRuleDayRepo,
+		OrderRuleRepository:          adminappOrderRuleRepo,
+		OrderRuleRegistry:            orderRuleRegistry,
+	})
+	customerapp_event.InitHTTPHandler(router, validate, customerappEventUseCase)
+
 	handler := middleware.SetChain(
 		router,
 		cors.New(cors.Options{
@@ -150,7 +355,9 @@ func main() {
 	<-sigterm
 
 	srv.Shutdown(ctx)
+	cancel()
 	publisher.Close()
+	dlqReader.Close()
 	psqldb.Close()
 	rc.Close()
 	mon.Stop(ctx)
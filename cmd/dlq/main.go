@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/tsel-ticketmaster/tm-order/config"
+	adminapp_dlq "github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/dlq"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/pkg/applogger"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/kafka"
+	"github.com/tsel-ticketmaster/tm-order/pkg/postgresql"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/validator"
+)
+
+const usage = `Usage:
+  dlq list   [-consumer name] [-channel topic] [-from RFC3339] [-to RFC3339]
+  dlq replay -id message-id[,message-id...] -reason text [-force]
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	os.Exit(run(os.Args[1], os.Args[2:]))
+}
+
+func run(command string, args []string) int {
+	c := config.Get()
+	logger := applogger.GetLogrus()
+	validate := validator.Get()
+
+	psqldb := postgresql.GetDatabase()
+	defer psqldb.Close()
+
+	var (
+		publisher pubsub.Publisher
+		reader    pubsub.DeadLetterQueueReader
+	)
+	// the in-memory broker lives in this process only, so the queue is empty locally, it still lets the cli run
+	// without kafka.
+	if c.PubSub.Driver == "memory" {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Logger: logger})
+		publisher = broker.Publisher()
+		reader = broker.DeadLetterQueueReader(c.Kafka.DLQTopic)
+	} else {
+		publisher = pubsub.PublisherFromConfluentKafkaProducer(logger, kafka.NewProducer())
+		reader = pubsub.DeadLetterQueueReaderFromConfluentKafkaConsumer(pubsub.ConfluentKafkaDLQReaderProperty{
+			Logger:   logger,
+			Topic:    c.Kafka.DLQTopic,
+			Consumer: kafka.NewConsumer(fmt.Sprintf("%s-dlq-cli", c.Application.Name), false),
+		})
+	}
+	defer publisher.Close()
+	defer reader.Close()
+
+	dlqUseCase := adminapp_dlq.NewDLQUseCase(adminapp_dlq.DLQUseCaseProperty{
+		Logger:           logger,
+		Timeout:          c.Application.Timeout,
+		ReadTimeout:      c.Kafka.DLQReadTimeout,
+		Topic:            c.Kafka.DLQTopic,
+		Reader:           reader,
+		Publisher:        publisher,
+		ReplayRepository: adminapp_dlq.NewReplayRepository(logger, psqldb),
+	})
+
+	ctx := context.Background()
+
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch command {
+	case "list":
+		fs := flag.NewFlagSet("list", flag.ExitOnError)
+		req := adminapp_dlq.GetManyMessageRequest{}
+		fs.StringVar(&req.Consumer, "consumer", "", "filter by consumer name")
+		fs.StringVar(&req.Channel, "channel", "", "filter by original topic")
+		fs.StringVar(&req.From, "from", "", "filter by failed consume date (RFC3339) from")
+		fs.StringVar(&req.To, "to", "", "filter by failed consume date (RFC3339) to")
+		fs.Parse(args)
+
+		if err = validate.StructCtx(ctx, req); err == nil {
+			result, err = dlqUseCase.GetManyMessage(ctx, req)
+		}
+	case "replay":
+		fs := flag.NewFlagSet("replay", flag.ExitOnError)
+		ids := fs.String("id", "", "comma separated message ids to be replayed")
+		req := adminapp_dlq.ReplayMessageRequest{}
+		fs.StringVar(&req.Reason, "reason", "", "reason of the replay")
+		fs.BoolVar(&req.Force, "force", false, "publish the messages that have already been replayed again")
+		fs.Parse(args)
+
+		if *ids != "" {
+			req.MessageIDs = strings.Split(*ids, ",")
+		}
+
+		actor := "cli"
+		if u, uerr := user.Current(); uerr == nil {
+			actor = fmt.Sprintf("cli:%s", u.Username)
+		}
+		ctx = context.WithValue(ctx, session.AccountContextKey{}, session.Account{Name: actor})
+
+		if err = validate.StructCtx(ctx, req); err == nil {
+			result, err = dlqUseCase.ReplayMessage(ctx, req)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errors.Destruct(err).Message)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(result)
+
+	return 0
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/tsel-ticketmaster/tm-order/pkg/applogger"
 )
 
 var (
@@ -24,7 +25,10 @@ type Config struct {
 		Debug       bool
 		Timeout     time.Duration
 		Timezone    *time.Location
-		TMUser      struct {
+		// Number of the proxies in front of the service that append to X-Forwarded-For, the load balancer of google
+		// cloud is one. The client ip is taken from the connection when it is 0.
+		TrustedProxyHops int
+		TMUser           struct {
 			BaseURL string
 		}
 		TMOrder struct {
@@ -67,6 +71,10 @@ type Config struct {
 		Password string
 		DB       int
 	}
+	PubSub struct {
+		// Driver of the pubsub, either kafka or memory. The memory driver lets the service run without kafka.
+		Driver string
+	}
 	Kafka struct {
 		Hosts            string
 		SecurityProtocol string
@@ -74,20 +82,52 @@ type Config struct {
 		SASLUsername     string
 		SASLPassword     string
 		SessionTimeout   int
+		// Producer delivery guarantees, see enable.idempotence and acks of librdkafka.
+		ProducerIdempotence bool
+		ProducerAcks        string
+		DLQTopic            string
+		DLQReadTimeout      time.Duration
+		// Serializer of the published events, either json or protobuf.
+		Serializer         string
+		SchemaRegistryFile string
 	}
 	GCP struct {
 		ProjectID      string
 		ServiceAccount []byte
 	}
+	Customer struct {
+		// Lifetime of the verification link that is sent on sign up and on resend.
+		VerificationExpiration time.Duration
+		// Time between the request to delete an account and its anonymization, the customer can still cancel it by
+		// signing in. It is at most 30 days, the longest that a task of the delete-customer queue can be scheduled.
+		DeletionGracePeriod time.Duration
+	}
 	Order struct {
 		Expiration              time.Duration
 		TaxChargePercentage     float64
 		ServiceChargePercentage float64
 	}
+	Outbox struct {
+		// Interval of the relay that publishes the outbox messages that could not be published right after the commit.
+		RelayInterval time.Duration
+	}
 	Midtrans struct {
 		BaseURL      string
 		BasicAuthKey string
 	}
+	Export struct {
+		// Google cloud storage bucket of the files that are written by the export jobs. The bucket should have a
+		// lifecycle rule that deletes the objects under exports/ once the jobs are no longer kept.
+		Bucket string
+		// Exports with more rows than this are written by a job instead of being streamed.
+		SyncMaxRows int64
+		// Time a streamed export may take.
+		StreamTimeout time.Duration
+		// Time a job may take, the dispatch deadline of the export-job queue must not be shorter.
+		JobTimeout time.Duration
+		// Time a job and its file can be downloaded for after it has been created.
+		JobRetention time.Duration
+	}
 }
 
 func (cfg *Config) application() {
@@ -99,7 +139,17 @@ func (cfg *Config) application() {
 	timeoutInSec, _ := strconv.Atoi(os.Getenv("APP_TIMEOUT"))
 	cfg.Application.Timeout = time.Duration(timeoutInSec) * time.Second
 
-	cfg.Application.Timezone, _ = time.LoadLocation(os.Getenv("APP_TIMEZONE"))
+	// the timezone is given to the events that are created without their own timezone, it must be an IANA name.
+	timezone, err := time.LoadLocation(os.Getenv("APP_TIMEZONE"))
+	if err != nil || timezone == time.Local {
+		timezone = time.UTC
+	}
+	cfg.Application.Timezone = timezone
+
+	cfg.Application.TrustedProxyHops, _ = strconv.Atoi(os.Getenv("APP_TRUSTED_PROXY_HOPS"))
+	if cfg.Application.TrustedProxyHops < 0 {
+		cfg.Application.TrustedProxyHops = 0
+	}
 
 	cfg.Application.TMUser.BaseURL = os.Getenv("APP_TMUSER_BASE_URL")
 	cfg.Application.TMOrder.BaseURL = os.Getenv("APP_TMORDER_BASE_URL")
@@ -110,6 +160,34 @@ func (cfg *Config) midtrans() {
 	cfg.Midtrans.BasicAuthKey = os.Getenv("MIDTRANS_BASIC_AUTH_KEY")
 }
 
+func (cfg *Config) export() {
+	cfg.Export.Bucket = os.Getenv("EXPORT_BUCKET")
+
+	cfg.Export.SyncMaxRows, _ = strconv.ParseInt(os.Getenv("EXPORT_SYNC_MAX_ROWS"), 10, 64)
+	if cfg.Export.SyncMaxRows <= 0 {
+		cfg.Export.SyncMaxRows = 10000
+	}
+
+	streamTimeoutInSec, _ := strconv.Atoi(os.Getenv("EXPORT_STREAM_TIMEOUT"))
+	if streamTimeoutInSec <= 0 {
+		streamTimeoutInSec = 60
+	}
+	cfg.Export.StreamTimeout = time.Duration(streamTimeoutInSec) * time.Second
+
+	// cloud tasks gives an http task at most 30 minutes to answer.
+	jobTimeoutInSec, _ := strconv.Atoi(os.Getenv("EXPORT_JOB_TIMEOUT"))
+	if jobTimeoutInSec <= 0 || jobTimeoutInSec > 1800 {
+		jobTimeoutInSec = 1800
+	}
+	cfg.Export.JobTimeout = time.Duration(jobTimeoutInSec) * time.Second
+
+	jobRetention, _ := strconv.Atoi(os.Getenv("EXPORT_JOB_RETENTION"))
+	if jobRetention <= 0 {
+		jobRetention = 1440
+	}
+	cfg.Export.JobRetention = time.Duration(jobRetention) * time.Minute
+}
+
 func (cfg *Config) order() {
 	expiration, _ := strconv.Atoi(os.Getenv("ORDER_EXPIRATION"))
 	cfg.Order.Expiration = time.Duration(expiration) * time.Minute
@@ -118,6 +196,34 @@ func (cfg *Config) order() {
 	cfg.Order.ServiceChargePercentage, _ = strconv.ParseFloat(os.Getenv("ORDER_SERVICE_CHARGE"), 64)
 }
 
+func (cfg *Config) outbox() {
+	relayIntervalInSec, _ := strconv.Atoi(os.Getenv("OUTBOX_RELAY_INTERVAL"))
+	if relayIntervalInSec <= 0 {
+		relayIntervalInSec = 5
+	}
+	cfg.Outbox.RelayInterval = time.Duration(relayIntervalInSec) * time.Second
+}
+
+func (cfg *Config) customer() {
+	verificationExpiration, _ := strconv.Atoi(os.Getenv("CUSTOMER_VERIFICATION_EXPIRATION"))
+	if verificationExpiration <= 0 {
+		verificationExpiration = 5
+	}
+	cfg.Customer.VerificationExpiration = time.Duration(verificationExpiration) * time.Minute
+
+	deletionGracePeriod, _ := strconv.Atoi(os.Getenv("CUSTOMER_DELETION_GRACE_PERIOD"))
+	if deletionGracePeriod <= 0 {
+		deletionGracePeriod = 30
+	}
+	// the deletion is a task of the delete-customer queue that is scheduled at the end of the grace period, cloud tasks
+	// does not schedule a task more than 30 days ahead.
+	if deletionGracePeriod > 30 {
+		applogger.GetLogrus().Warnf("CUSTOMER_DELETION_GRACE_PERIOD must be at most 30 days, got %d, 30 is used instead", deletionGracePeriod)
+		deletionGracePeriod = 30
+	}
+	cfg.Customer.DeletionGracePeriod = time.Duration(deletionGracePeriod) * 24 * time.Hour
+}
+
 func (cfg *Config) crypto() {
 	cfg.Crypto.Secret = os.Getenv("CRYPTO_SECRET")
 }
@@ -167,6 +273,13 @@ func (cfg *Config) redis() {
 	cfg.Redis.DB, _ = strconv.Atoi(os.Getenv("REDIS_DB"))
 }
 
+func (cfg *Config) pubsub() {
+	cfg.PubSub.Driver = os.Getenv("PUBSUB_DRIVER")
+	if cfg.PubSub.Driver == "" {
+		cfg.PubSub.Driver = "kafka"
+	}
+}
+
 func (cfg *Config) kafka() {
 	cfg.Kafka.Hosts = os.Getenv("KAFKA_HOSTS")
 	cfg.Kafka.SecurityProtocol = os.Getenv("KAFKA_SECURITY_PROTOCOL")
@@ -174,6 +287,18 @@ func (cfg *Config) kafka() {
 	cfg.Kafka.SASLUsername = os.Getenv("KAFKA_SASL_USERNAME")
 	cfg.Kafka.SASLPassword = os.Getenv("KAFKA_SASL_PASSWORD")
 	cfg.Kafka.SessionTimeout, _ = strconv.Atoi(os.Getenv("KAFKA_SESSION_TIMEOUT_MS"))
+	cfg.Kafka.ProducerIdempotence, _ = strconv.ParseBool(os.Getenv("KAFKA_PRODUCER_ENABLE_IDEMPOTENCE"))
+	cfg.Kafka.ProducerAcks = os.Getenv("KAFKA_PRODUCER_ACKS")
+	cfg.Kafka.DLQTopic = os.Getenv("KAFKA_DLQ_TOPIC")
+
+	dlqReadTimeoutInSec, _ := strconv.Atoi(os.Getenv("KAFKA_DLQ_READ_TIMEOUT"))
+	if dlqReadTimeoutInSec <= 0 {
+		dlqReadTimeoutInSec = 10
+	}
+	cfg.Kafka.DLQReadTimeout = time.Duration(dlqReadTimeoutInSec) * time.Second
+
+	cfg.Kafka.Serializer = os.Getenv("KAFKA_SERIALIZER")
+	cfg.Kafka.SchemaRegistryFile = os.Getenv("KAFKA_SCHEMA_REGISTRY_FILE")
 }
 
 func (cfg *Config) gcp() {
@@ -184,16 +309,20 @@ func (cfg *Config) gcp() {
 func load() *Config {
 	cfg := new(Config)
 	cfg.application()
+	cfg.customer()
 	cfg.order()
+	cfg.outbox()
 	cfg.crypto()
 	cfg.openTelemetry()
 	cfg.jwt()
 	cfg.postgresql()
 	cfg.cors()
 	cfg.redis()
+	cfg.pubsub()
 	cfg.kafka()
 	cfg.gcp()
 	cfg.midtrans()
+	cfg.export()
 	return cfg
 }
 
@@ -0,0 +1,53 @@
+package admin
+
+import "time"
+
+const (
+	RoleSuperAdmin   = "SUPER_ADMIN"
+	RoleEventManager = "EVENT_MANAGER"
+	RoleSupport      = "SUPPORT"
+
+	StatusActive   = "ACTIVE"
+	StatusInactive = "INACTIVE"
+
+	twoFactorChallengeKeyPrefix = "admin:two_factor_challenge:token:%s"
+	twoFactorAttemptKeyPrefix   = "admin:two_factor_attempt:id:%d"
+	twoFactorUsedCodeKeyPrefix  = "admin:two_factor_used_code:id:%d:%d"
+
+	// twoFactorIssuer is the name of the account in the authenticator of the admin.
+	twoFactorIssuer = "ticket-master-admin"
+	// twoFactorChallengeExpiresIn is the time that the admin has to complete the sign in with a code.
+	twoFactorChallengeExpiresIn = 5 * time.Minute
+	// twoFactorAttemptLimit is the number of codes that an admin can try within twoFactorAttemptWindow.
+	twoFactorAttemptLimit  = 10
+	twoFactorAttemptWindow = 15 * time.Minute
+)
+
+type Admin struct {
+	ID           int64
+	Name         string
+	Email        string
+	Password     string
+	PasswordSalt string
+	Role         string
+	Status       string
+	// TwoFactorSecret is sealed by twofactor.Seal, TwoFactorRecoveryCodes are hashed by twofactor.HashRecoveryCode.
+	TwoFactorSecret        string
+	TwoFactorEnabled       bool
+	TwoFactorRecoveryCodes []string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// PasswordHashCount counts the accounts by the scheme of their password hash.
+type PasswordHashCount struct {
+	Total  int64
+	Legacy int64
+}
+
+// TwoFactorChallenge is the sign in that waits for the code of the admin. Secret is only set, sealed, while the admin
+// has not enrolled yet, the first code then proves that the authenticator has it.
+type TwoFactorChallenge struct {
+	AdminID int64  `json:"admin_id"`
+	Secret  string `json:"secret,omitempty"`
+}
@@ -0,0 +1,264 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	AdminUseCase      AdminUseCase
+	// TrustedProxyHops is the number of proxies in front of the service that append to X-Forwarded-For.
+	TrustedProxyHops int
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, adminUseCase AdminUseCase, trustedProxyHops int) {
+	handler := &HTTPHandler{
+		Validate:         validate,
+		AdminUseCase:     adminUseCase,
+		TrustedProxyHops: trustedProxyHops,
+	}
+
+	router.HandleFunc("/tm-order/v1/adminapp/admins/signin", publicMiddleware.SetRouteChain(handler.SignIn)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/admins/signin/two-factor", publicMiddleware.SetRouteChain(handler.VerifyTwoFactor)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/admins/two-factor/recovery-codes", publicMiddleware.SetRouteChain(handler.RegenerateRecoveryCode, adminSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/admins/signout", publicMiddleware.SetRouteChain(handler.SignOut, adminSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/admins/profile", publicMiddleware.SetRouteChain(handler.GetProfile, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/admins", publicMiddleware.SetRouteChain(handler.CreateAdmin, adminSession.Verify, adminSession.RequireRole(RoleSuperAdmin))).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+func (handler HTTPHandler) SignIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := SignInRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	req.IPAddress = util.ClientIP(r, handler.TrustedProxyHops)
+
+	resp, err := handler.AdminUseCase.SignIn(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "a two-factor code is required to complete the sign in",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := VerifyTwoFactorRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.AdminUseCase.VerifyTwoFactor(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "admin has been successfully signed in",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) RegenerateRecoveryCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := RegenerateRecoveryCodeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.AdminUseCase.RegenerateRecoveryCode(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "admin's recovery codes have been successfully regenerated, the previous ones are no longer valid",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) SignOut(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	err := handler.AdminUseCase.SignOut(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "admin has been successfully signed out",
+	})
+}
+
+func (handler HTTPHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.AdminUseCase.GetProfile(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "admin profile",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) CreateAdmin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := CreateAdminRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.AdminUseCase.CreateAdmin(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.RESTEnvelope{
+		Status:  status.CREATED,
+		Message: "admin has been successfully created",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type AdminRepository interface {
+	Save(ctx context.Context, a Admin, tx *sql.Tx) (int64, error)
+	FindByID(ctx context.Context, ID int64, tx *sql.Tx) (Admin, error)
+	FindByEmail(ctx context.Context, email string, tx *sql.Tx) (Admin, error)
+	Update(ctx context.Context, ID int64, update Admin, tx *sql.Tx) error
+	CountPasswordHash(ctx context.Context, tx *sql.Tx) (PasswordHashCount, error)
+}
+
+type sqlCommand interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type adminRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewAdminRepository(logger *logrus.Logger, db *sql.DB) AdminRepository {
+	return &adminRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// CountPasswordHash implements AdminRepository.
+func (r *adminRepository) CountPasswordHash(ctx context.Context, tx *sql.Tx) (PasswordHashCount, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			COUNT(*), COUNT(*) FILTER (WHERE password NOT LIKE $1)
+		FROM admin
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PasswordHashCount{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting admin's password hashes")
+	}
+	defer stmt.Close()
+
+	var data PasswordHashCount
+
+	err = stmt.QueryRowContext(ctx, password.Prefix+"%").Scan(&data.Total, &data.Legacy)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PasswordHashCount{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting admin's password hashes")
+	}
+
+	return data, nil
+}
+
+// FindByEmail implements AdminRepository.
+func (r *adminRepository) FindByEmail(ctx context.Context, email string, tx *sql.Tx) (Admin, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			id, name, email, password, password_salt, role, status, two_factor_secret, two_factor_enabled, two_factor_recovery_codes, created_at, updated_at
+		FROM admin
+		WHERE
+			email = $1
+		LIMIT 1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Admin{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting admin's prorperties")
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, email)
+
+	var data Admin
+
+	err = row.Scan(
+		&data.ID, &data.Name, &data.Email, &data.Password, &data.PasswordSalt, &data.Role, &data.Status, &data.TwoFactorSecret, &data.TwoFactorEnabled, pq.Array(&data.TwoFactorRecoveryCodes), &data.CreatedAt, &data.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Admin{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("admin's properties with email '%s' is not found", email))
+		}
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Admin{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting admin's prorperties")
+	}
+
+	return data, nil
+}
+
+// FindByID implements AdminRepository.
+func (r *adminRepository) FindByID(ctx context.Context, ID int64, tx *sql.Tx) (Admin, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			id, name, email, password, password_salt, role, status, two_factor_secret, two_factor_enabled, two_factor_recovery_codes, created_at, updated_at
+		FROM admin
+		WHERE
+			id = $1
+		LIMIT 1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Admin{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting admin's prorperties")
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, ID)
+
+	var data Admin
+
+	err = row.Scan(
+		&data.ID, &data.Name, &data.Email, &data.Password, &data.PasswordSalt, &data.Role, &data.Status, &data.TwoFactorSecret, &data.TwoFactorEnabled, pq.Array(&data.TwoFactorRecoveryCodes), &data.CreatedAt, &data.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Admin{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("admin's properties with id '%d' is not found", ID))
+		}
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Admin{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting admin's prorperties")
+	}
+
+	return data, nil
+}
+
+// Save implements AdminRepository.
+func (r *adminRepository) Save(ctx context.Context, a Admin, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		INSERT INTO admin
+		(
+			name, email, password, password_salt, role, status, created_at, updated_at
+		)
+		VALUES
+		(
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)
+		RETURNING id
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving admin's prorperties")
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, a.Name, a.Email, a.Password, a.PasswordSalt, a.Role, a.Status, a.CreatedAt, a.UpdatedAt)
+
+	var ID int64
+
+	err = row.Scan(&ID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving admin's prorperties")
+	}
+
+	return ID, nil
+}
+
+// Update implements AdminRepository.
+func (r *adminRepository) Update(ctx context.Context, ID int64, a Admin, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE admin
+		SET
+			name = $1,
+			email = $2,
+			password = $3,
+			password_salt = $4,
+			role = $5,
+			status = $6,
+			two_factor_secret = $7,
+			two_factor_enabled = $8,
+			two_factor_recovery_codes = $9,
+			updated_at = $10
+		WHERE
+			id = $11
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating admin's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, a.Name, a.Email, a.Password, a.PasswordSalt, a.Role, a.Status, a.TwoFactorSecret, a.TwoFactorEnabled, pq.Array(a.TwoFactorRecoveryCodes), a.UpdatedAt, ID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating admin's prorperties")
+	}
+
+	return nil
+}
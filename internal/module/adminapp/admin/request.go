@@ -0,0 +1,28 @@
+package admin
+
+type SignInRequest struct {
+	Email     string `json:"email" validate:"required"`
+	Password  string `json:"password" validate:"required"`
+	IPAddress string `json:"-"`
+}
+
+type CreateAdminRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Email    string `json:"email" validate:"email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Role     string `json:"role" validate:"oneof=SUPER_ADMIN EVENT_MANAGER SUPPORT"`
+}
+
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Code is either the code of the authenticator or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
+
+type RegenerateRecoveryCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type ResetTwoFactorRequest struct {
+	Email string `json:"email" validate:"email"`
+}
@@ -0,0 +1,62 @@
+package admin
+
+import "time"
+
+// SignInResponse of SignIn only has TwoFactor, the two-factor authentication is mandatory for the admins and the token
+// is only issued by VerifyTwoFactor.
+type SignInResponse struct {
+	Token     string                      `json:"token"`
+	ExpiresAt time.Time                   `json:"expires_at"`
+	TwoFactor *TwoFactorChallengeResponse `json:"two_factor,omitempty"`
+	// RecoveryCodes are only given when the sign in has completed the enrollment.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	// Enrollment is set when the admin has not enrolled yet, the challenge is then completed with the first code of the
+	// authenticator.
+	Enrollment *TwoFactorEnrollmentResponse `json:"enrollment,omitempty"`
+}
+
+type TwoFactorEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodeResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type AdminResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r *AdminResponse) PopulateFromEntity(a Admin) {
+	r.ID = a.ID
+	r.Name = a.Name
+	r.Email = a.Email
+	r.Role = a.Role
+	r.Status = a.Status
+	r.CreatedAt = a.CreatedAt
+	r.UpdatedAt = a.UpdatedAt
+}
+
+type PasswordHashReportResponse struct {
+	Total   int64 `json:"total"`
+	Legacy  int64 `json:"legacy"`
+	Current int64 `json:"current"`
+}
+
+func (r *PasswordHashReportResponse) PopulateFromEntity(c PasswordHashCount) {
+	r.Total = c.Total
+	r.Legacy = c.Legacy
+	r.Current = c.Total - c.Legacy
+}
@@ -0,0 +1,537 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/jwt"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/twofactor"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type AdminUseCase interface {
+	SignIn(ctx context.Context, req SignInRequest) (SignInResponse, error)
+	SignOut(ctx context.Context) error
+	GetProfile(ctx context.Context) (AdminResponse, error)
+	CreateAdmin(ctx context.Context, req CreateAdminRequest) (AdminResponse, error)
+	GetPasswordHashReport(ctx context.Context) (PasswordHashReportResponse, error)
+	VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest) (SignInResponse, error)
+	RegenerateRecoveryCode(ctx context.Context, req RegenerateRecoveryCodeRequest) (RecoveryCodeResponse, error)
+	ResetTwoFactor(ctx context.Context, req ResetTwoFactorRequest) error
+}
+
+type AdminUseCaseProperty struct {
+	Logger          *logrus.Logger
+	Timeout         time.Duration
+	CryptoSecret    string
+	JSONWebToken    *jwt.JSONWebToken
+	Session         session.Session
+	Cache           redis.UniversalClient
+	AdminRepository AdminRepository
+	// SignInEmailGuard and SignInIPGuard count the failed sign ins per email and per ip address.
+	SignInEmailGuard lockout.Guard
+	SignInIPGuard    lockout.Guard
+}
+
+type adminUseCase struct {
+	logger           *logrus.Logger
+	timeout          time.Duration
+	cryptoSecret     string
+	jsonWebToken     *jwt.JSONWebToken
+	session          session.Session
+	cache            redis.UniversalClient
+	adminRepository  AdminRepository
+	signInEmailGuard lockout.Guard
+	signInIPGuard    lockout.Guard
+}
+
+// dummyPasswordHash is verified against when the email is unknown, so it takes as long as a wrong password.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := password.Hash("", util.GenerateRandomHEX(16))
+	return hash
+})
+
+func NewAdminUseCase(props AdminUseCaseProperty) AdminUseCase {
+	return &adminUseCase{
+		logger:           props.Logger,
+		timeout:          props.Timeout,
+		cryptoSecret:     props.CryptoSecret,
+		jsonWebToken:     props.JSONWebToken,
+		session:          props.Session,
+		cache:            props.Cache,
+		adminRepository:  props.AdminRepository,
+		signInEmailGuard: props.SignInEmailGuard,
+		signInIPGuard:    props.SignInIPGuard,
+	}
+}
+
+// CreateAdmin implements AdminUseCase.
+func (u *adminUseCase) CreateAdmin(ctx context.Context, req CreateAdminRequest) (AdminResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	_, err := u.adminRepository.FindByEmail(ctx, req.Email, nil)
+	if err == nil {
+		return AdminResponse{}, errors.New(http.StatusConflict, status.ALREADY_EXIST, fmt.Sprintf("admin with email '%s' is already registered", req.Email))
+	}
+
+	if !errors.MatchStatus(err, status.NOT_FOUND) {
+		return AdminResponse{}, err
+	}
+
+	now := time.Now()
+	hashedPassword, err := password.Hash(u.cryptoSecret, req.Password)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return AdminResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while creating admin")
+	}
+
+	a := Admin{
+		Name:      req.Name,
+		Email:     req.Email,
+		Password:  hashedPassword,
+		Role:      req.Role,
+		Status:    StatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	ID, err := u.adminRepository.Save(ctx, a, nil)
+	if err != nil {
+		return AdminResponse{}, err
+	}
+
+	a.ID = ID
+
+	resp := AdminResponse{}
+	resp.PopulateFromEntity(a)
+
+	return resp, nil
+}
+
+// GetPasswordHashReport implements AdminUseCase.
+func (u *adminUseCase) GetPasswordHashReport(ctx context.Context) (PasswordHashReportResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	count, err := u.adminRepository.CountPasswordHash(ctx, nil)
+	if err != nil {
+		return PasswordHashReportResponse{}, err
+	}
+
+	resp := PasswordHashReportResponse{}
+	resp.PopulateFromEntity(count)
+
+	return resp, nil
+}
+
+// GetProfile implements AdminUseCase.
+func (u *adminUseCase) GetProfile(ctx context.Context) (AdminResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return AdminResponse{}, err
+	}
+
+	a, err := u.adminRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return AdminResponse{}, err
+	}
+
+	resp := AdminResponse{}
+	resp.PopulateFromEntity(a)
+
+	return resp, nil
+}
+
+// RegenerateRecoveryCode implements AdminUseCase.
+func (u *adminUseCase) RegenerateRecoveryCode(ctx context.Context, req RegenerateRecoveryCodeRequest) (RecoveryCodeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	a, err := u.adminRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	if err := u.limit(ctx, fmt.Sprintf(twoFactorAttemptKeyPrefix, a.ID), twoFactorAttemptLimit, twoFactorAttemptWindow); err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	if ok, err := u.checkTwoFactorTOTP(ctx, a, a.TwoFactorSecret, req.Code); err != nil {
+		return RecoveryCodeResponse{}, err
+	} else if !ok {
+		return RecoveryCodeResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+	}
+
+	recoveryCodes, err := u.setRecoveryCodes(ctx, &a)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+	a.UpdatedAt = time.Now()
+
+	if err := u.adminRepository.Update(ctx, a.ID, a, nil); err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	return RecoveryCodeResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// ResetTwoFactor implements AdminUseCase.
+func (u *adminUseCase) ResetTwoFactor(ctx context.Context, req ResetTwoFactorRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	a, err := u.adminRepository.FindByEmail(ctx, req.Email, nil)
+	if err != nil {
+		return err
+	}
+
+	// the admin enrolls a new authenticator on the next sign in.
+	a.TwoFactorSecret = ""
+	a.TwoFactorEnabled = false
+	a.TwoFactorRecoveryCodes = []string{}
+	a.UpdatedAt = time.Now()
+
+	if err := u.adminRepository.Update(ctx, a.ID, a, nil); err != nil {
+		return err
+	}
+
+	// whoever holds the lost authenticator may be signed in, the session is ended along with it.
+	return u.session.Delete(ctx, fmt.Sprintf("admin:%d", a.ID))
+}
+
+// SignIn implements AdminUseCase.
+func (u *adminUseCase) SignIn(ctx context.Context, req SignInRequest) (SignInResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if err := u.signInEmailGuard.Check(ctx, req.Email); err != nil {
+		return SignInResponse{}, err
+	}
+
+	if req.IPAddress != "" {
+		if err := u.signInIPGuard.Check(ctx, req.IPAddress); err != nil {
+			return SignInResponse{}, err
+		}
+	}
+
+	a, err := u.adminRepository.FindByEmail(ctx, req.Email, nil)
+	if err != nil {
+		if !errors.MatchStatus(err, status.NOT_FOUND) {
+			return SignInResponse{}, err
+		}
+		// the password is verified anyway, so an unknown email takes as long as a wrong password.
+		password.Verify(u.cryptoSecret, req.Password, dummyPasswordHash(), "")
+		return SignInResponse{}, u.failSignIn(ctx, req)
+	}
+
+	match, rehash := password.Verify(u.cryptoSecret, req.Password, a.Password, a.PasswordSalt)
+	if !match {
+		return SignInResponse{}, u.failSignIn(ctx, req)
+	}
+
+	// the hash is upgraded while the plain password is at hand, failing to do so must not fail the sign in.
+	if rehash {
+		if hashedPassword, err := password.Hash(u.cryptoSecret, req.Password); err == nil {
+			a.Password = hashedPassword
+			a.PasswordSalt = ""
+			a.UpdatedAt = time.Now()
+			u.adminRepository.Update(ctx, a.ID, a, nil)
+		}
+	}
+
+	if a.Status != StatusActive {
+		return SignInResponse{}, errors.New(http.StatusForbidden, status.FORBIDDEN, "admin is not active")
+	}
+
+	return u.challengeTwoFactor(ctx, a)
+}
+
+// SignOut implements AdminUseCase.
+func (u *adminUseCase) SignOut(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("admin:%d", acc.ID)
+	if err := u.session.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// VerifyTwoFactor implements AdminUseCase.
+func (u *adminUseCase) VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest) (SignInResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	challengeKey := fmt.Sprintf(twoFactorChallengeKeyPrefix, req.ChallengeToken)
+	challengeBuff, err := u.cache.Get(ctx, challengeKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return SignInResponse{}, errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "two-factor challenge is not found or has expired")
+		}
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while verifying admin's two-factor code")
+	}
+
+	var challenge TwoFactorChallenge
+	json.Unmarshal(challengeBuff, &challenge)
+
+	a, err := u.adminRepository.FindByID(ctx, challenge.AdminID, nil)
+	if err != nil {
+		return SignInResponse{}, err
+	}
+
+	if err := u.limit(ctx, fmt.Sprintf(twoFactorAttemptKeyPrefix, a.ID), twoFactorAttemptLimit, twoFactorAttemptWindow); err != nil {
+		return SignInResponse{}, err
+	}
+
+	var ok bool
+	var recoveryCodes []string
+
+	if challenge.Secret != "" {
+		ok, err = u.checkTwoFactorTOTP(ctx, a, challenge.Secret, req.Code)
+		if err == nil && ok {
+			a.TwoFactorSecret = challenge.Secret
+			a.TwoFactorEnabled = true
+			recoveryCodes, err = u.setRecoveryCodes(ctx, &a)
+		}
+		if err == nil && ok {
+			a.UpdatedAt = time.Now()
+			err = u.adminRepository.Update(ctx, a.ID, a, nil)
+		}
+	} else {
+		ok, err = u.checkTwoFactorCode(ctx, &a, req.Code)
+	}
+
+	if err != nil {
+		return SignInResponse{}, err
+	}
+
+	if !ok {
+		return SignInResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+	}
+
+	if err := u.cache.Del(ctx, challengeKey).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while verifying admin's two-factor code")
+	}
+
+	// the failed sign ins are only forgotten once the second factor is verified as well.
+	if err := u.signInEmailGuard.Reset(ctx, a.Email); err != nil {
+		return SignInResponse{}, err
+	}
+
+	resp, err := u.issueToken(ctx, a)
+	if err != nil {
+		return SignInResponse{}, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+
+	return resp, nil
+}
+
+// failSignIn records the failed sign in against the email and the ip address of the request.
+func (u *adminUseCase) failSignIn(ctx context.Context, req SignInRequest) error {
+	if _, err := u.signInEmailGuard.Fail(ctx, req.Email); err != nil {
+		return err
+	}
+
+	if req.IPAddress != "" {
+		if _, err := u.signInIPGuard.Fail(ctx, req.IPAddress); err != nil {
+			return err
+		}
+	}
+
+	return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid admin's email or password")
+}
+
+// challengeTwoFactor holds the sign in of the admin until a code completes it by VerifyTwoFactor. An admin that has not
+// enrolled yet gets a new secret to enroll with.
+func (u *adminUseCase) challengeTwoFactor(ctx context.Context, a Admin) (SignInResponse, error) {
+	challengeToken := util.GenerateRandomHEX(32)
+	challenge := TwoFactorChallenge{AdminID: a.ID}
+	resp := SignInResponse{
+		TwoFactor: &TwoFactorChallengeResponse{
+			ChallengeToken: challengeToken,
+			ExpiresAt:      time.Now().Add(twoFactorChallengeExpiresIn),
+		},
+	}
+
+	if !a.TwoFactorEnabled {
+		secret, err := twofactor.GenerateSecret()
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while signing in admin")
+		}
+
+		challenge.Secret, err = twofactor.Seal(u.cryptoSecret, secret)
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while signing in admin")
+		}
+
+		resp.TwoFactor.Enrollment = &TwoFactorEnrollmentResponse{
+			Secret:          secret,
+			ProvisioningURI: twofactor.ProvisioningURI(twoFactorIssuer, a.Email, secret),
+		}
+	}
+
+	challengeBuff, _ := json.Marshal(challenge)
+	if err := u.cache.Set(ctx, fmt.Sprintf(twoFactorChallengeKeyPrefix, challengeToken), challengeBuff, twoFactorChallengeExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while signing in admin")
+	}
+
+	return resp, nil
+}
+
+// checkTwoFactorCode tells whether the code is the current code of the authenticator of the admin or one of its
+// recovery codes. A recovery code is used up.
+func (u *adminUseCase) checkTwoFactorCode(ctx context.Context, a *Admin, code string) (bool, error) {
+	if ok, err := u.checkTwoFactorTOTP(ctx, *a, a.TwoFactorSecret, code); ok || err != nil {
+		return ok, err
+	}
+
+	i := twofactor.MatchRecoveryCode(u.cryptoSecret, a.TwoFactorRecoveryCodes, code)
+	if i < 0 {
+		return false, nil
+	}
+
+	recoveryCodes := make([]string, 0, len(a.TwoFactorRecoveryCodes)-1)
+	recoveryCodes = append(recoveryCodes, a.TwoFactorRecoveryCodes[:i]...)
+	a.TwoFactorRecoveryCodes = append(recoveryCodes, a.TwoFactorRecoveryCodes[i+1:]...)
+	a.UpdatedAt = time.Now()
+
+	if err := u.adminRepository.Update(ctx, a.ID, *a, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkTwoFactorTOTP tells whether the code is the current code of the sealed secret. A code is accepted only once, so
+// an observed code can not be replayed.
+func (u *adminUseCase) checkTwoFactorTOTP(ctx context.Context, a Admin, sealedSecret, code string) (bool, error) {
+	secret, err := twofactor.Open(u.cryptoSecret, sealedSecret)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while checking admin's two-factor code")
+	}
+
+	step, ok := twofactor.Validate(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	fresh, err := u.cache.SetNX(ctx, fmt.Sprintf(twoFactorUsedCodeKeyPrefix, a.ID, step), true, (2*twofactor.Skew+1)*twofactor.Period).Result()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while checking admin's two-factor code")
+	}
+
+	return fresh, nil
+}
+
+// setRecoveryCodes replaces the recovery codes of the admin and returns the new ones, only their hashes are kept.
+func (u *adminUseCase) setRecoveryCodes(ctx context.Context, a *Admin) ([]string, error) {
+	recoveryCodes, err := twofactor.GenerateRecoveryCodes()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while generating admin's recovery codes")
+	}
+
+	a.TwoFactorRecoveryCodes = make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		a.TwoFactorRecoveryCodes[i] = twofactor.HashRecoveryCode(u.cryptoSecret, recoveryCode)
+	}
+
+	return recoveryCodes, nil
+}
+
+// limit counts the requests of the key within the window and rejects the ones above the limit.
+func (u *adminUseCase) limit(ctx context.Context, key string, limit int64, window time.Duration) error {
+	count, err := u.cache.Incr(ctx, key).Result()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while limiting the requests")
+	}
+
+	if count == 1 {
+		if err := u.cache.Expire(ctx, key, window).Err(); err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+		}
+	}
+
+	if count > limit {
+		return errors.New(http.StatusTooManyRequests, status.TOO_MANY_REQUESTS, "too many requests, please try again later")
+	}
+
+	return nil
+}
+
+// issueToken signs the token of the admin and starts its session.
+func (u *adminUseCase) issueToken(ctx context.Context, a Admin) (SignInResponse, error) {
+	now := time.Now()
+	expiresIn := time.Hour * 1
+	expiresAt := now.Add(expiresIn)
+	subject := fmt.Sprintf("admin:%d", a.ID)
+	userType := "ADMIN"
+
+	claim := jwt.Claim{}
+	claim.Subject = subject
+	claim.IssuedAt = now.Unix()
+	claim.ExpiresAt = expiresAt.Unix()
+	claim.Name = a.Name
+	claim.Email = a.Email
+	claim.Type = userType
+	claim.Issuer = "ticket-master"
+
+	idToken, err := u.jsonWebToken.Sign(ctx, claim)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, err
+	}
+
+	if err := u.session.Set(ctx, subject, session.Account{
+		ID:    a.ID,
+		Name:  a.Name,
+		Email: a.Email,
+		Type:  userType,
+		Role:  a.Role,
+	}, expiresIn); err != nil {
+		return SignInResponse{}, err
+	}
+
+	resp := SignInResponse{
+		Token:     idToken,
+		ExpiresAt: expiresAt,
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,326 @@
+package admin_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/twofactor"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+const (
+	cryptoSecret  = "secret"
+	adminEmail    = "admin@example.com"
+	adminPassword = "rahasia123"
+)
+
+type adminRepositoryStandIn struct {
+	admin.AdminRepository
+	admins map[int64]admin.Admin
+}
+
+func (r *adminRepositoryStandIn) FindByID(ctx context.Context, ID int64, tx *sql.Tx) (admin.Admin, error) {
+	a, ok := r.admins[ID]
+	if !ok {
+		return admin.Admin{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "admin is not found")
+	}
+
+	return a, nil
+}
+
+func (r *adminRepositoryStandIn) FindByEmail(ctx context.Context, email string, tx *sql.Tx) (admin.Admin, error) {
+	for _, a := range r.admins {
+		if a.Email == email {
+			return a, nil
+		}
+	}
+
+	return admin.Admin{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "admin is not found")
+}
+
+func (r *adminRepositoryStandIn) Update(ctx context.Context, ID int64, update admin.Admin, tx *sql.Tx) error {
+	r.admins[ID] = update
+
+	return nil
+}
+
+// adminFixture holds what the use case of newAdminUseCase is built on.
+type adminFixture struct {
+	admins  *adminRepositoryStandIn
+	redis   *standin.Redis
+	session session.Session
+	// secret of the authenticator that the admin has enrolled.
+	secret string
+}
+
+// newAdminUseCase returns the use case of an active admin that has enrolled the two-factor authentication.
+func newAdminUseCase(t *testing.T) (admin.AdminUseCase, adminFixture) {
+	hashedPassword, err := password.Hash(cryptoSecret, adminPassword)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	secret, err := twofactor.GenerateSecret()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	sealedSecret, err := twofactor.Seal(cryptoSecret, secret)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	logger := standin.Logger()
+	rc := standin.NewRedis()
+	f := adminFixture{
+		admins: &adminRepositoryStandIn{admins: map[int64]admin.Admin{
+			standin.AdminID: {
+				ID:               standin.AdminID,
+				Email:            adminEmail,
+				Password:         hashedPassword,
+				Role:             admin.RoleSuperAdmin,
+				Status:           admin.StatusActive,
+				TwoFactorSecret:  sealedSecret,
+				TwoFactorEnabled: true,
+			},
+		}},
+		redis:   rc,
+		session: session.NewRedisSessionStore(logger, rc),
+		secret:  secret,
+	}
+
+	u := admin.NewAdminUseCase(admin.AdminUseCaseProperty{
+		Logger:           logger,
+		Timeout:          5 * time.Second,
+		CryptoSecret:     cryptoSecret,
+		JSONWebToken:     standin.JSONWebToken(),
+		Session:          f.session,
+		Cache:            rc,
+		AdminRepository:  f.admins,
+		SignInEmailGuard: lockout.NewRedisGuard(logger, rc, lockout.AdminSignInByEmail),
+		SignInIPGuard:    lockout.NewRedisGuard(logger, rc, lockout.AdminSignInByIP),
+	})
+
+	return u, f
+}
+
+// signIn completes the sign in of the admin with the current code of the authenticator.
+func signIn(t *testing.T, u admin.AdminUseCase, secret string) admin.SignInResponse {
+	resp, err := u.SignIn(context.Background(), admin.SignInRequest{Email: adminEmail, Password: adminPassword})
+	if !assert.NoError(t, err) || !assert.NotNil(t, resp.TwoFactor) {
+		t.FailNow()
+	}
+
+	code, err := twofactor.Code(secret, time.Now())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	resp, err = u.VerifyTwoFactor(context.Background(), admin.VerifyTwoFactorRequest{ChallengeToken: resp.TwoFactor.ChallengeToken, Code: code})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return resp
+}
+
+func TestAdminUseCaseSignIn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("a wrong password holds the email back once the failures are allowed no more", func(t *testing.T) {
+		u, _ := newAdminUseCase(t)
+
+		for i := int64(0); i < lockout.AdminSignInByEmail.DelayAfter; i++ {
+			_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: "wrong"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+		}
+
+		_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword})
+		assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(err).HTTPStatusCode, "the right password waits as well")
+	})
+
+	t.Run("an unknown email is counted as a failure", func(t *testing.T) {
+		u, _ := newAdminUseCase(t)
+
+		for i := int64(0); i < lockout.AdminSignInByEmail.DelayAfter; i++ {
+			_, err := u.SignIn(ctx, admin.SignInRequest{Email: "unknown@example.com", Password: "wrong"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+			assert.Equal(t, "invalid admin's email or password", errors.Destruct(err).Message, "an unknown email reads as a wrong password")
+		}
+
+		_, err := u.SignIn(ctx, admin.SignInRequest{Email: "unknown@example.com", Password: "wrong"})
+		assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("the failures of an ip address hold back every email from it", func(t *testing.T) {
+		u, _ := newAdminUseCase(t)
+
+		for i := int64(0); i < lockout.AdminSignInByIP.DelayAfter; i++ {
+			_, err := u.SignIn(ctx, admin.SignInRequest{Email: fmt.Sprintf("guess-%d@example.com", i), Password: "wrong", IPAddress: "10.0.0.1"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+		}
+
+		_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword, IPAddress: "10.0.0.1"})
+		assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(err).HTTPStatusCode)
+
+		_, err = u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword, IPAddress: "10.0.0.2"})
+		assert.NoError(t, err, "another ip address is not held back")
+	})
+
+	t.Run("the failures are forgotten once the second factor is verified", func(t *testing.T) {
+		u, f := newAdminUseCase(t)
+
+		for i := int64(1); i < lockout.AdminSignInByEmail.DelayAfter; i++ {
+			_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: "wrong"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+		}
+
+		assert.NotEmpty(t, signIn(t, u, f.secret).Token)
+
+		for i := int64(1); i < lockout.AdminSignInByEmail.DelayAfter; i++ {
+			_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: "wrong"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode, "the earlier failures are not counted")
+		}
+	})
+
+	t.Run("repeated failures lock the email until the lock is lifted", func(t *testing.T) {
+		u, f := newAdminUseCase(t)
+		policy := lockout.AdminSignInByEmail
+
+		for i := int64(0); i < policy.LockAfter; i++ {
+			_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: "wrong"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+			f.redis.Advance(policy.MaxDelay)
+		}
+
+		_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword})
+		assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(err).HTTPStatusCode, "the lock outlasts the delay")
+
+		f.redis.Advance(policy.LockDuration)
+
+		_, err = u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword})
+		assert.NoError(t, err)
+	})
+
+	t.Run("an inactive admin is rejected", func(t *testing.T) {
+		u, f := newAdminUseCase(t)
+
+		a := f.admins.admins[standin.AdminID]
+		a.Status = admin.StatusInactive
+		f.admins.admins[standin.AdminID] = a
+
+		_, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword})
+		assert.Equal(t, http.StatusForbidden, errors.Destruct(err).HTTPStatusCode)
+	})
+}
+
+func TestAdminUseCaseVerifyTwoFactor(t *testing.T) {
+	ctx := context.Background()
+
+	challenge := func(t *testing.T, u admin.AdminUseCase) admin.SignInResponse {
+		resp, err := u.SignIn(ctx, admin.SignInRequest{Email: adminEmail, Password: adminPassword})
+		if !assert.NoError(t, err) || !assert.NotNil(t, resp.TwoFactor) {
+			t.FailNow()
+		}
+		assert.Empty(t, resp.Token, "no session is given before the second factor")
+
+		return resp
+	}
+
+	t.Run("a wrong code is rejected", func(t *testing.T) {
+		u, _ := newAdminUseCase(t)
+
+		_, err := u.VerifyTwoFactor(ctx, admin.VerifyTwoFactorRequest{ChallengeToken: challenge(t, u).TwoFactor.ChallengeToken, Code: "abcdef"})
+		assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("a code can not be replayed", func(t *testing.T) {
+		u, f := newAdminUseCase(t)
+
+		code, err := twofactor.Code(f.secret, time.Now())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		_, err = u.VerifyTwoFactor(ctx, admin.VerifyTwoFactorRequest{ChallengeToken: challenge(t, u).TwoFactor.ChallengeToken, Code: code})
+		assert.NoError(t, err)
+
+		_, err = u.VerifyTwoFactor(ctx, admin.VerifyTwoFactorRequest{ChallengeToken: challenge(t, u).TwoFactor.ChallengeToken, Code: code})
+		assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("an expired challenge is rejected", func(t *testing.T) {
+		u, f := newAdminUseCase(t)
+		challengeToken := challenge(t, u).TwoFactor.ChallengeToken
+
+		f.redis.Advance(6 * time.Minute)
+
+		code, err := twofactor.Code(f.secret, time.Now())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		_, err = u.VerifyTwoFactor(ctx, admin.VerifyTwoFactorRequest{ChallengeToken: challengeToken, Code: code})
+		assert.Equal(t, http.StatusUnauthorized, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("an admin that has not enrolled does so on the first sign in", func(t *testing.T) {
+		u, f := newAdminUseCase(t)
+
+		a := f.admins.admins[standin.AdminID]
+		a.TwoFactorSecret = ""
+		a.TwoFactorEnabled = false
+		f.admins.admins[standin.AdminID] = a
+
+		resp := challenge(t, u)
+		if !assert.NotNil(t, resp.TwoFactor.Enrollment) {
+			t.FailNow()
+		}
+
+		code, err := twofactor.Code(resp.TwoFactor.Enrollment.Secret, time.Now())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		verified, err := u.VerifyTwoFactor(ctx, admin.VerifyTwoFactorRequest{ChallengeToken: resp.TwoFactor.ChallengeToken, Code: code})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		assert.NotEmpty(t, verified.Token)
+		assert.Len(t, verified.RecoveryCodes, twofactor.RecoveryCodeCount)
+		assert.True(t, f.admins.admins[standin.AdminID].TwoFactorEnabled)
+
+		assert.Nil(t, challenge(t, u).TwoFactor.Enrollment, "an enrolled admin gets no new secret")
+	})
+}
+
+func TestAdminUseCaseResetTwoFactor(t *testing.T) {
+	u, f := newAdminUseCase(t)
+	signIn(t, u, f.secret)
+
+	subject := fmt.Sprintf("admin:%d", standin.AdminID)
+	_, err := f.session.Get(context.Background(), subject)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.NoError(t, u.ResetTwoFactor(context.Background(), admin.ResetTwoFactorRequest{Email: adminEmail}))
+
+	_, err = f.session.Get(context.Background(), subject)
+	assert.Error(t, err, "the admin is signed out")
+
+	a := f.admins.admins[standin.AdminID]
+	assert.False(t, a.TwoFactorEnabled)
+	assert.Empty(t, a.TwoFactorSecret)
+}
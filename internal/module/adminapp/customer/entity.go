@@ -0,0 +1,45 @@
+package customer
+
+import "time"
+
+// PasswordHashCount counts the customers by the scheme of their password hash.
+type PasswordHashCount struct {
+	Total  int64
+	Legacy int64
+}
+
+// Customer is the personal data of a customer, the credentials are left out of it.
+type Customer struct {
+	ID                  int64
+	Name                string
+	Email               string
+	VerificationStatus  string
+	MemberStatus        string
+	MemberTier          string
+	TwoFactorEnabled    bool
+	DeletionRequestedAt *time.Time
+	DeletedAt           *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Order is the copy of the personal data of a customer in an order.
+type Order struct {
+	ID            string
+	Status        string
+	CustomerName  string
+	CustomerEmail string
+	TotalAmount   float64
+	CreatedAt     time.Time
+}
+
+// AcquiredTicket is the copy of the personal data of a customer in an acquired ticket.
+type AcquiredTicket struct {
+	Number        string
+	EventID       string
+	ShowID        string
+	ShowTime      time.Time
+	OrderID       string
+	CustomerName  string
+	CustomerEmail string
+}
@@ -0,0 +1,126 @@
+package customer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	CustomerUseCase   CustomerUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, customerUseCase CustomerUseCase) {
+	handler := &HTTPHandler{
+		Validate:        validate,
+		CustomerUseCase: customerUseCase,
+	}
+
+	router.HandleFunc("/tm-order/v1/adminapp/customers/{id}/personal-data", publicMiddleware.SetRouteChain(handler.GetPersonalData, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/customers/unlock-sign-in", publicMiddleware.SetRouteChain(handler.UnlockSignIn, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+func (handler HTTPHandler) GetPersonalData(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := GetPersonalDataRequest{}
+	req.ID, _ = strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.CustomerUseCase.GetPersonalData(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's personal data",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) UnlockSignIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := UnlockSignInRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.CustomerUseCase.UnlockSignIn(ctx, req); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's sign in has been unlocked",
+	})
+}
@@ -0,0 +1,199 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type CustomerRepository interface {
+	CountPasswordHash(ctx context.Context, tx *sql.Tx) (PasswordHashCount, error)
+	FindByID(ctx context.Context, ID int64, tx *sql.Tx) (Customer, error)
+	FindManyOrderByCustomerID(ctx context.Context, customerID int64, tx *sql.Tx) ([]Order, error)
+	FindManyAcquiredTicketByCustomerID(ctx context.Context, customerID int64, tx *sql.Tx) ([]AcquiredTicket, error)
+}
+
+type sqlCommand interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type customerRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewCustomerRepository(logger *logrus.Logger, db *sql.DB) CustomerRepository {
+	return &customerRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// CountPasswordHash implements CustomerRepository.
+func (r *customerRepository) CountPasswordHash(ctx context.Context, tx *sql.Tx) (PasswordHashCount, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			COUNT(*), COUNT(*) FILTER (WHERE password NOT LIKE $1)
+		FROM customer
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PasswordHashCount{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting customer's password hashes")
+	}
+	defer stmt.Close()
+
+	var data PasswordHashCount
+
+	err = stmt.QueryRowContext(ctx, password.Prefix+"%").Scan(&data.Total, &data.Legacy)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return PasswordHashCount{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting customer's password hashes")
+	}
+
+	return data, nil
+}
+
+// FindByID implements CustomerRepository.
+func (r *customerRepository) FindByID(ctx context.Context, ID int64, tx *sql.Tx) (Customer, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			id, name, email, verification_status, member_status, member_tier, two_factor_enabled, deletion_requested_at, deleted_at, created_at, updated_at
+		FROM customer
+		WHERE
+			id = $1
+		LIMIT 1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Customer{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's prorperties")
+	}
+	defer stmt.Close()
+
+	var data Customer
+
+	err = stmt.QueryRowContext(ctx, ID).Scan(
+		&data.ID, &data.Name, &data.Email, &data.VerificationStatus, &data.MemberStatus, &data.MemberTier, &data.TwoFactorEnabled, &data.DeletionRequestedAt, &data.DeletedAt, &data.CreatedAt, &data.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Customer{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("customer's properties with id '%d' is not found", ID))
+		}
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Customer{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's prorperties")
+	}
+
+	return data, nil
+}
+
+// FindManyOrderByCustomerID implements CustomerRepository.
+func (r *customerRepository) FindManyOrderByCustomerID(ctx context.Context, customerID int64, tx *sql.Tx) ([]Order, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			id, status, customer_name, customer_email, total_amount, created_at
+		FROM ticket_order
+		WHERE
+			customer_id = $1
+		ORDER BY created_at DESC
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's orders")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, customerID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's orders")
+	}
+	defer rows.Close()
+
+	data := make([]Order, 0)
+	for rows.Next() {
+		var o Order
+		if err := rows.Scan(&o.ID, &o.Status, &o.CustomerName, &o.CustomerEmail, &o.TotalAmount, &o.CreatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's orders")
+		}
+		data = append(data, o)
+	}
+
+	return data, nil
+}
+
+// FindManyAcquiredTicketByCustomerID implements CustomerRepository.
+func (r *customerRepository) FindManyAcquiredTicketByCustomerID(ctx context.Context, customerID int64, tx *sql.Tx) ([]AcquiredTicket, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			number, event_id, show_id, show_time, order_id, customer_name, customer_email
+		FROM acquired_ticket
+		WHERE
+			customer_id = $1
+		ORDER BY show_time DESC
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's acquired tickets")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, customerID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's acquired tickets")
+	}
+	defer rows.Close()
+
+	data := make([]AcquiredTicket, 0)
+	for rows.Next() {
+		var at AcquiredTicket
+		if err := rows.Scan(&at.Number, &at.EventID, &at.ShowID, &at.ShowTime, &at.OrderID, &at.CustomerName, &at.CustomerEmail); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting customer's acquired tickets")
+		}
+		data = append(data, at)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,10 @@
+package customer
+
+type UnlockSignInRequest struct {
+	Email     string `json:"email" validate:"email"`
+	IPAddress string `json:"ip_address" validate:"omitempty,ip"`
+}
+
+type GetPersonalDataRequest struct {
+	ID int64 `validate:"required"`
+}
@@ -0,0 +1,102 @@
+package customer
+
+import "time"
+
+type PasswordHashReportResponse struct {
+	Total   int64 `json:"total"`
+	Legacy  int64 `json:"legacy"`
+	Current int64 `json:"current"`
+}
+
+func (r *PasswordHashReportResponse) PopulateFromEntity(c PasswordHashCount) {
+	r.Total = c.Total
+	r.Legacy = c.Legacy
+	r.Current = c.Total - c.Legacy
+}
+
+// PersonalDataResponse is everything that is stored about a customer, it answers the access request of the customer.
+type PersonalDataResponse struct {
+	Customer        PersonalDataCustomerResponse         `json:"customer"`
+	Orders          []PersonalDataOrderResponse          `json:"orders"`
+	AcquiredTickets []PersonalDataAcquiredTicketResponse `json:"acquired_tickets"`
+}
+
+func (r *PersonalDataResponse) PopulateFromEntity(c Customer, orders []Order, acquiredTickets []AcquiredTicket) {
+	r.Customer.PopulateFromEntity(c)
+
+	r.Orders = make([]PersonalDataOrderResponse, len(orders))
+	for k, o := range orders {
+		r.Orders[k].PopulateFromEntity(o)
+	}
+
+	r.AcquiredTickets = make([]PersonalDataAcquiredTicketResponse, len(acquiredTickets))
+	for k, at := range acquiredTickets {
+		r.AcquiredTickets[k].PopulateFromEntity(at)
+	}
+}
+
+type PersonalDataCustomerResponse struct {
+	ID                  int64      `json:"id"`
+	Name                string     `json:"name"`
+	Email               string     `json:"email"`
+	VerificationStatus  string     `json:"verification_status"`
+	MemberStatus        string     `json:"member_status"`
+	MemberTier          string     `json:"member_tier"`
+	TwoFactorEnabled    bool       `json:"two_factor_enabled"`
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"`
+	DeletedAt           *time.Time `json:"deleted_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+func (r *PersonalDataCustomerResponse) PopulateFromEntity(c Customer) {
+	r.ID = c.ID
+	r.Name = c.Name
+	r.Email = c.Email
+	r.VerificationStatus = c.VerificationStatus
+	r.MemberStatus = c.MemberStatus
+	r.MemberTier = c.MemberTier
+	r.TwoFactorEnabled = c.TwoFactorEnabled
+	r.DeletionRequestedAt = c.DeletionRequestedAt
+	r.DeletedAt = c.DeletedAt
+	r.CreatedAt = c.CreatedAt
+	r.UpdatedAt = c.UpdatedAt
+}
+
+type PersonalDataOrderResponse struct {
+	ID            string    `json:"id"`
+	Status        string    `json:"status"`
+	CustomerName  string    `json:"customer_name"`
+	CustomerEmail string    `json:"customer_email"`
+	TotalAmount   float64   `json:"total_amount"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (r *PersonalDataOrderResponse) PopulateFromEntity(o Order) {
+	r.ID = o.ID
+	r.Status = o.Status
+	r.CustomerName = o.CustomerName
+	r.CustomerEmail = o.CustomerEmail
+	r.TotalAmount = o.TotalAmount
+	r.CreatedAt = o.CreatedAt
+}
+
+type PersonalDataAcquiredTicketResponse struct {
+	Number        string    `json:"number"`
+	EventID       string    `json:"event_id"`
+	ShowID        string    `json:"show_id"`
+	ShowTime      time.Time `json:"show_time"`
+	OrderID       string    `json:"order_id"`
+	CustomerName  string    `json:"customer_name"`
+	CustomerEmail string    `json:"customer_email"`
+}
+
+func (r *PersonalDataAcquiredTicketResponse) PopulateFromEntity(at AcquiredTicket) {
+	r.Number = at.Number
+	r.EventID = at.EventID
+	r.ShowID = at.ShowID
+	r.ShowTime = at.ShowTime
+	r.OrderID = at.OrderID
+	r.CustomerName = at.CustomerName
+	r.CustomerEmail = at.CustomerEmail
+}
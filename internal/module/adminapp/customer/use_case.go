@@ -0,0 +1,102 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+)
+
+type CustomerUseCase interface {
+	GetPasswordHashReport(ctx context.Context) (PasswordHashReportResponse, error)
+	GetPersonalData(ctx context.Context, req GetPersonalDataRequest) (PersonalDataResponse, error)
+	UnlockSignIn(ctx context.Context, req UnlockSignInRequest) error
+}
+
+type customerUseCase struct {
+	logger             *logrus.Logger
+	timeout            time.Duration
+	signInEmailGuard   lockout.Guard
+	signInIPGuard      lockout.Guard
+	customerRepository CustomerRepository
+}
+
+type CustomerUseCaseProperty struct {
+	Logger  *logrus.Logger
+	Timeout time.Duration
+	// SignInEmailGuard and SignInIPGuard must share the policies of the guards of the customer's sign in.
+	SignInEmailGuard   lockout.Guard
+	SignInIPGuard      lockout.Guard
+	CustomerRepository CustomerRepository
+}
+
+func NewCustomerUseCase(props CustomerUseCaseProperty) CustomerUseCase {
+	return &customerUseCase{
+		logger:             props.Logger,
+		timeout:            props.Timeout,
+		signInEmailGuard:   props.SignInEmailGuard,
+		signInIPGuard:      props.SignInIPGuard,
+		customerRepository: props.CustomerRepository,
+	}
+}
+
+// GetPasswordHashReport implements CustomerUseCase.
+func (u *customerUseCase) GetPasswordHashReport(ctx context.Context) (PasswordHashReportResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	count, err := u.customerRepository.CountPasswordHash(ctx, nil)
+	if err != nil {
+		return PasswordHashReportResponse{}, err
+	}
+
+	resp := PasswordHashReportResponse{}
+	resp.PopulateFromEntity(count)
+
+	return resp, nil
+}
+
+// GetPersonalData implements CustomerUseCase.
+func (u *customerUseCase) GetPersonalData(ctx context.Context, req GetPersonalDataRequest) (PersonalDataResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	c, err := u.customerRepository.FindByID(ctx, req.ID, nil)
+	if err != nil {
+		return PersonalDataResponse{}, err
+	}
+
+	orders, err := u.customerRepository.FindManyOrderByCustomerID(ctx, c.ID, nil)
+	if err != nil {
+		return PersonalDataResponse{}, err
+	}
+
+	acquiredTickets, err := u.customerRepository.FindManyAcquiredTicketByCustomerID(ctx, c.ID, nil)
+	if err != nil {
+		return PersonalDataResponse{}, err
+	}
+
+	resp := PersonalDataResponse{}
+	resp.PopulateFromEntity(c, orders, acquiredTickets)
+
+	return resp, nil
+}
+
+// UnlockSignIn implements CustomerUseCase.
+func (u *customerUseCase) UnlockSignIn(ctx context.Context, req UnlockSignInRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if err := u.signInEmailGuard.Reset(ctx, req.Email); err != nil {
+		return err
+	}
+
+	if req.IPAddress != "" {
+		if err := u.signInIPGuard.Reset(ctx, req.IPAddress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package customer_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/customer"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+)
+
+func TestCustomerUseCaseUnlockSignIn(t *testing.T) {
+	ctx := context.Background()
+	email, ipAddress := "budi@example.com", "10.0.0.1"
+
+	logger := standin.Logger()
+	rc := standin.NewRedis()
+	emailGuard := lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByEmail)
+	ipGuard := lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByIP)
+
+	u := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+		Logger:           logger,
+		Timeout:          5 * time.Second,
+		SignInEmailGuard: emailGuard,
+		SignInIPGuard:    ipGuard,
+	})
+
+	for i := int64(0); i < lockout.CustomerSignInByEmail.LockAfter; i++ {
+		failure, err := emailGuard.Fail(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, i == lockout.CustomerSignInByEmail.LockAfter-1, failure.Locked)
+	}
+	for i := int64(0); i < lockout.CustomerSignInByIP.DelayAfter; i++ {
+		_, err := ipGuard.Fail(ctx, ipAddress)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(emailGuard.Check(ctx, email)).HTTPStatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(ipGuard.Check(ctx, ipAddress)).HTTPStatusCode)
+
+	t.Run("the email is unlocked without the ip address", func(t *testing.T) {
+		assert.NoError(t, u.UnlockSignIn(ctx, customer.UnlockSignInRequest{Email: email}))
+
+		assert.NoError(t, emailGuard.Check(ctx, email))
+		assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(ipGuard.Check(ctx, ipAddress)).HTTPStatusCode, "the ip address is still held back")
+	})
+
+	t.Run("the ip address is unlocked along with the email", func(t *testing.T) {
+		assert.NoError(t, u.UnlockSignIn(ctx, customer.UnlockSignInRequest{Email: email, IPAddress: ipAddress}))
+
+		assert.NoError(t, ipGuard.Check(ctx, ipAddress))
+	})
+}
@@ -0,0 +1,23 @@
+package dlq
+
+import "time"
+
+const (
+	ReplayStatusReplayed        = "REPLAYED"
+	ReplayStatusAlreadyReplayed = "ALREADY_REPLAYED"
+	ReplayStatusNotFound        = "NOT_FOUND"
+	ReplayStatusFailed          = "FAILED"
+)
+
+type Replay struct {
+	MessageID  string
+	Topic      string
+	Partition  int32
+	Offset     int64
+	Channel    string
+	Consumer   string
+	Key        string
+	ReplayedBy string
+	Reason     string
+	ReplayedAt time.Time
+}
@@ -0,0 +1,135 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	DLQUseCase        DLQUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, dlqUseCase DLQUseCase) {
+	handler := &HTTPHandler{
+		Validate:   validate,
+		DLQUseCase: dlqUseCase,
+	}
+
+	router.HandleFunc("/tm-order/v1/adminapp/dlq/messages", publicMiddleware.SetRouteChain(handler.GetManyMessage, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin))).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/dlq/messages/replay", publicMiddleware.SetRouteChain(handler.ReplayMessage, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin))).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+func (handler HTTPHandler) GetManyMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := GetManyMessageRequest{
+		Consumer: qs.Get("consumer"),
+		Channel:  qs.Get("channel"),
+		From:     qs.Get("from"),
+		To:       qs.Get("to"),
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.DLQUseCase.GetManyMessage(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of dead letter queue messages",
+		Data:    resp,
+		Meta:    nil,
+	})
+}
+
+func (handler HTTPHandler) ReplayMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ReplayMessageRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.DLQUseCase.ReplayMessage(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "dead letter queue messages have been processed for replay",
+		Data:    resp,
+		Meta:    nil,
+	})
+}
@@ -0,0 +1,223 @@
+package dlq
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type ReplayRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CommitTx(ctx context.Context, tx *sql.Tx) error
+	Rollback(ctx context.Context, tx *sql.Tx) error
+
+	// Save stores the replay record. It returns false if the message has been replayed before.
+	Save(ctx context.Context, rp Replay, tx *sql.Tx) (bool, error)
+	// Update overwrites who replayed the message, why and when.
+	Update(ctx context.Context, rp Replay, tx *sql.Tx) error
+	Delete(ctx context.Context, messageID string, tx *sql.Tx) error
+	FindManyByMessageIDs(ctx context.Context, messageIDs []string, tx *sql.Tx) ([]Replay, error)
+}
+
+type sqlCommand interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type replayRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewReplayRepository(logger *logrus.Logger, db *sql.DB) ReplayRepository {
+	return &replayRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// BeginTx implements ReplayRepository.
+func (r *replayRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to begin transaction")
+	}
+
+	return tx, nil
+}
+
+// CommitTx implements ReplayRepository.
+func (r *replayRepository) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to commit transaction")
+	}
+
+	return nil
+}
+
+// Rollback implements ReplayRepository.
+func (r *replayRepository) Rollback(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to rollback transaction")
+	}
+
+	return nil
+}
+
+// Save implements ReplayRepository.
+func (r *replayRepository) Save(ctx context.Context, rp Replay, tx *sql.Tx) (bool, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		INSERT INTO dlq_replay
+		(
+			message_id, topic, partition, "offset", channel, consumer, message_key, replayed_by, reason, replayed_at
+		)
+		VALUES
+		(
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)
+		ON CONFLICT (message_id) DO NOTHING
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving dlq replay's prorperties")
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, rp.MessageID, rp.Topic, rp.Partition, rp.Offset, rp.Channel, rp.Consumer, rp.Key, rp.ReplayedBy, rp.Reason, rp.ReplayedAt)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving dlq replay's prorperties")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving dlq replay's prorperties")
+	}
+
+	return affected > 0, nil
+}
+
+// Update implements ReplayRepository.
+func (r *replayRepository) Update(ctx context.Context, rp Replay, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE dlq_replay
+		SET
+			replayed_by = $1, reason = $2, replayed_at = $3
+		WHERE
+			message_id = $4
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating dlq replay's prorperties")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, rp.ReplayedBy, rp.Reason, rp.ReplayedAt, rp.MessageID); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating dlq replay's prorperties")
+	}
+
+	return nil
+}
+
+// Delete implements ReplayRepository.
+func (r *replayRepository) Delete(ctx context.Context, messageID string, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		DELETE FROM dlq_replay
+		WHERE
+			message_id = $1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting dlq replay's prorperties")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, messageID); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting dlq replay's prorperties")
+	}
+
+	return nil
+}
+
+// FindManyByMessageIDs implements ReplayRepository.
+func (r *replayRepository) FindManyByMessageIDs(ctx context.Context, messageIDs []string, tx *sql.Tx) ([]Replay, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			message_id, topic, partition, "offset", channel, consumer, message_key, replayed_by, reason, replayed_at
+		FROM dlq_replay
+		WHERE
+			message_id = ANY($1)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of dlq replay's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(messageIDs))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of dlq replay's prorperties")
+	}
+
+	defer rows.Close()
+
+	var data = make([]Replay, 0)
+	for rows.Next() {
+		var rp Replay
+
+		if err := rows.Scan(&rp.MessageID, &rp.Topic, &rp.Partition, &rp.Offset, &rp.Channel, &rp.Consumer, &rp.Key, &rp.ReplayedBy, &rp.Reason, &rp.ReplayedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of dlq replay's prorperties")
+		}
+
+		data = append(data, rp)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,16 @@
+package dlq
+
+type GetManyMessageRequest struct {
+	Consumer string `validate:"-"`
+	Channel  string `validate:"-"`
+	From     string `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To       string `validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type ReplayMessageRequest struct {
+	MessageIDs []string `json:"message_ids" validate:"required,min=1,dive,required"`
+	Reason     string   `json:"reason" validate:"required"`
+	// Force publishes the messages that have already been replayed again, e.g. when the service stopped after the
+	// replay was recorded but before the message was published.
+	Force bool `json:"force" validate:"-"`
+}
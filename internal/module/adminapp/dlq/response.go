@@ -0,0 +1,65 @@
+package dlq
+
+import (
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+)
+
+type ReplayResponse struct {
+	ReplayedBy string    `json:"replayed_by"`
+	Reason     string    `json:"reason"`
+	ReplayedAt time.Time `json:"replayed_at"`
+}
+
+type MessageResponse struct {
+	ID                string            `json:"id"`
+	Partition         int32             `json:"partition"`
+	Offset            int64             `json:"offset"`
+	Channel           string            `json:"channel"`
+	Publisher         string            `json:"publisher"`
+	Consumer          string            `json:"consumer"`
+	Key               string            `json:"key"`
+	Headers           map[string]string `json:"headers"`
+	Message           string            `json:"message"`
+	CausedBy          string            `json:"caused_by"`
+	FailedConsumeDate time.Time         `json:"failed_consume_date"`
+	Replay            *ReplayResponse   `json:"replay"`
+}
+
+func (r *MessageResponse) PopulateFromEntity(record pubsub.DeadLetterQueueRecord, replay *Replay) {
+	r.ID = record.ID
+	r.Partition = record.Partition
+	r.Offset = record.Offset
+	r.Channel = record.Message.Channel
+	r.Publisher = record.Message.Publisher
+	r.Consumer = record.Message.Consumer
+	r.Key = record.Message.Key
+	r.Headers = record.Message.Headers
+	r.Message = record.Message.Message
+	r.CausedBy = record.Message.CausedBy
+	r.FailedConsumeDate = record.FailedConsumeTime()
+
+	if replay != nil {
+		r.Replay = &ReplayResponse{
+			ReplayedBy: replay.ReplayedBy,
+			Reason:     replay.Reason,
+			ReplayedAt: replay.ReplayedAt,
+		}
+	}
+}
+
+type GetManyMessageResponse struct {
+	Total    int64             `json:"total"`
+	Messages []MessageResponse `json:"messages"`
+}
+
+type ReplayMessageResult struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type ReplayMessageResponse struct {
+	Results []ReplayMessageResult `json:"results"`
+}
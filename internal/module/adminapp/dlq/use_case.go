@@ -0,0 +1,231 @@
+package dlq
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type DLQUseCase interface {
+	GetManyMessage(ctx context.Context, req GetManyMessageRequest) (GetManyMessageResponse, error)
+	ReplayMessage(ctx context.Context, req ReplayMessageRequest) (ReplayMessageResponse, error)
+}
+
+type dlqUseCase struct {
+	logger           *logrus.Logger
+	timeout          time.Duration
+	readTimeout      time.Duration
+	topic            string
+	reader           pubsub.DeadLetterQueueReader
+	publisher        pubsub.Publisher
+	replayRepository ReplayRepository
+}
+
+type DLQUseCaseProperty struct {
+	Logger           *logrus.Logger
+	Timeout          time.Duration
+	ReadTimeout      time.Duration
+	Topic            string
+	Reader           pubsub.DeadLetterQueueReader
+	Publisher        pubsub.Publisher
+	ReplayRepository ReplayRepository
+}
+
+func NewDLQUseCase(props DLQUseCaseProperty) DLQUseCase {
+	return &dlqUseCase{
+		logger:           props.Logger,
+		timeout:          props.Timeout,
+		readTimeout:      props.ReadTimeout,
+		topic:            props.Topic,
+		reader:           props.Reader,
+		publisher:        props.Publisher,
+		replayRepository: props.ReplayRepository,
+	}
+}
+
+func (u *dlqUseCase) matchFilter(record pubsub.DeadLetterQueueRecord, req GetManyMessageRequest, from, to time.Time) bool {
+	if req.Consumer != "" && record.Message.Consumer != req.Consumer {
+		return false
+	}
+
+	if req.Channel != "" && record.Message.Channel != req.Channel {
+		return false
+	}
+
+	failedAt := record.FailedConsumeTime()
+	if !from.IsZero() && failedAt.Before(from) {
+		return false
+	}
+
+	if !to.IsZero() && failedAt.After(to) {
+		return false
+	}
+
+	return true
+}
+
+// GetManyMessage implements DLQUseCase.
+func (u *dlqUseCase) GetManyMessage(ctx context.Context, req GetManyMessageRequest) (GetManyMessageResponse, error) {
+	var from, to time.Time
+	if req.From != "" {
+		from, _ = time.Parse(time.RFC3339, req.From)
+	}
+	if req.To != "" {
+		to, _ = time.Parse(time.RFC3339, req.To)
+	}
+
+	readCtx, cancelRead := context.WithTimeout(ctx, u.readTimeout)
+	defer cancelRead()
+
+	records, err := u.reader.Read(readCtx)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return GetManyMessageResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while reading dead letter queue")
+	}
+
+	filtered := make([]pubsub.DeadLetterQueueRecord, 0)
+	messageIDs := make([]string, 0)
+	for _, record := range records {
+		if !u.matchFilter(record, req, from, to) {
+			continue
+		}
+
+		filtered = append(filtered, record)
+		messageIDs = append(messageIDs, record.ID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	replays, err := u.replayRepository.FindManyByMessageIDs(ctx, messageIDs, nil)
+	if err != nil {
+		return GetManyMessageResponse{}, err
+	}
+
+	replayByMessageID := make(map[string]*Replay, len(replays))
+	for k := range replays {
+		replayByMessageID[replays[k].MessageID] = &replays[k]
+	}
+
+	resp := GetManyMessageResponse{
+		Total:    int64(len(filtered)),
+		Messages: make([]MessageResponse, len(filtered)),
+	}
+	for k, v := range filtered {
+		m := MessageResponse{}
+		m.PopulateFromEntity(v, replayByMessageID[v.ID])
+		resp.Messages[k] = m
+	}
+
+	return resp, nil
+}
+
+func (u *dlqUseCase) replay(ctx context.Context, messageID string, actor string, reason string, force bool) ReplayMessageResult {
+	result := ReplayMessageResult{
+		ID: messageID,
+	}
+
+	topic, partition, offset, err := pubsub.ParseDeadLetterQueueRecordID(messageID)
+	if err != nil || topic != u.topic {
+		result.Status = ReplayStatusNotFound
+		result.Message = "message is not found in dead letter queue"
+		return result
+	}
+
+	readCtx, cancelRead := context.WithTimeout(ctx, u.readTimeout)
+	defer cancelRead()
+
+	record, err := u.reader.ReadOne(readCtx, partition, offset)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("message_id", messageID).Error()
+		result.Status = ReplayStatusNotFound
+		result.Message = "message is not found in dead letter queue"
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	// the replay is recorded before the message is published, so a message can never be published twice even when
+	// the record could not be stored after publishing. A message whose replay is recorded but that was never published,
+	// because the service stopped in between, is replayed again with force.
+	rp := Replay{
+		MessageID:  record.ID,
+		Topic:      record.Topic,
+		Partition:  record.Partition,
+		Offset:     record.Offset,
+		Channel:    record.Message.Channel,
+		Consumer:   record.Message.Consumer,
+		Key:        record.Message.Key,
+		ReplayedBy: actor,
+		Reason:     reason,
+		ReplayedAt: time.Now(),
+	}
+	saved, err := u.replayRepository.Save(ctx, rp, nil)
+	if err != nil {
+		result.Status = ReplayStatusFailed
+		result.Message = errors.Destruct(err).Message
+		return result
+	}
+
+	if !saved && !force {
+		result.Status = ReplayStatusAlreadyReplayed
+		result.Message = "message has already been replayed"
+		return result
+	}
+
+	if !saved {
+		if err := u.replayRepository.Update(ctx, rp, nil); err != nil {
+			result.Status = ReplayStatusFailed
+			result.Message = errors.Destruct(err).Message
+			return result
+		}
+	}
+
+	if err := u.publisher.Publish(ctx, record.Message.Channel, record.Message.Key, record.Message.Headers, []byte(record.Message.Message)); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("message_id", messageID).Error()
+		// release the record so the message can be replayed again, a forced replay keeps it since an earlier replay
+		// may have been published.
+		if saved {
+			if err := u.replayRepository.Delete(ctx, record.ID, nil); err != nil {
+				u.logger.WithContext(ctx).WithError(err).WithField("message_id", messageID).Error("replay record of the unpublished message could not be released")
+			}
+		}
+		result.Status = ReplayStatusFailed
+		result.Message = "an error occurred while publishing message to its original topic"
+		return result
+	}
+
+	result.Status = ReplayStatusReplayed
+	result.Message = "message has been successfully replayed"
+
+	return result
+}
+
+// ReplayMessage implements DLQUseCase.
+func (u *dlqUseCase) ReplayMessage(ctx context.Context, req ReplayMessageRequest) (ReplayMessageResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return ReplayMessageResponse{}, err
+	}
+
+	actor := acc.Email
+	if actor == "" {
+		actor = acc.Name
+	}
+
+	resp := ReplayMessageResponse{
+		Results: make([]ReplayMessageResult, len(req.MessageIDs)),
+	}
+	for k, messageID := range req.MessageIDs {
+		resp.Results[k] = u.replay(ctx, messageID, actor, req.Reason, req.Force)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,260 @@
+package dlq_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/dlq"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+)
+
+const dlqTopic = "tm-order-dlq"
+
+type replayRepositoryStandIn struct {
+	mu      sync.Mutex
+	replays map[string]dlq.Replay
+	deleted []string
+}
+
+func newReplayRepositoryStandIn() *replayRepositoryStandIn {
+	return &replayRepositoryStandIn{replays: make(map[string]dlq.Replay)}
+}
+
+func (r *replayRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, nil
+}
+
+func (r *replayRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *replayRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *replayRepositoryStandIn) Save(ctx context.Context, rp dlq.Replay, tx *sql.Tx) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.replays[rp.MessageID]; ok {
+		return false, nil
+	}
+	r.replays[rp.MessageID] = rp
+
+	return true, nil
+}
+
+func (r *replayRepositoryStandIn) Update(ctx context.Context, rp dlq.Replay, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.replays[rp.MessageID] = rp
+
+	return nil
+}
+
+func (r *replayRepositoryStandIn) Delete(ctx context.Context, messageID string, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.replays, messageID)
+	r.deleted = append(r.deleted, messageID)
+
+	return nil
+}
+
+func (r *replayRepositoryStandIn) FindManyByMessageIDs(ctx context.Context, messageIDs []string, tx *sql.Tx) ([]dlq.Replay, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	replays := make([]dlq.Replay, 0)
+	for _, ID := range messageIDs {
+		if rp, ok := r.replays[ID]; ok {
+			replays = append(replays, rp)
+		}
+	}
+
+	return replays, nil
+}
+
+func newDLQUseCase(broker *pubsub.InMemoryBroker, replayRepository dlq.ReplayRepository) dlq.DLQUseCase {
+	return dlq.NewDLQUseCase(dlq.DLQUseCaseProperty{
+		Logger:           standin.Logger(),
+		Timeout:          time.Second,
+		ReadTimeout:      time.Second,
+		Topic:            dlqTopic,
+		Reader:           broker.DeadLetterQueueReader(dlqTopic),
+		Publisher:        broker.Publisher(),
+		ReplayRepository: replayRepository,
+	})
+}
+
+func sendToDLQ(t *testing.T, broker *pubsub.InMemoryBroker, messages ...pubsub.DeadLetterQueueMessage) {
+	t.Helper()
+
+	handler := pubsub.NewDLQHandlerAdapter(dlqTopic, broker.Publisher())
+	for k := range messages {
+		if !assert.NoError(t, handler.Send(context.Background(), &messages[k])) {
+			t.FailNow()
+		}
+	}
+}
+
+func TestDLQUseCaseGetManyMessage(t *testing.T) {
+	broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+	sendToDLQ(t, broker,
+		pubsub.DeadLetterQueueMessage{Channel: "order-paid", Consumer: "e-ticket", Key: "order-1", Message: "1", FailedConsumeDate: "2026-10-01T10:00:00Z"},
+		pubsub.DeadLetterQueueMessage{Channel: "order-paid", Consumer: "notification", Key: "order-2", Message: "2", FailedConsumeDate: "2026-10-02T10:00:00Z"},
+		pubsub.DeadLetterQueueMessage{Channel: "order-expired", Consumer: "e-ticket", Key: "order-3", Message: "3", FailedConsumeDate: "2026-10-03T10:00:00Z"},
+	)
+
+	uc := newDLQUseCase(broker, newReplayRepositoryStandIn())
+
+	keys := func(resp dlq.GetManyMessageResponse) []string {
+		keys := make([]string, 0, len(resp.Messages))
+		for _, m := range resp.Messages {
+			keys = append(keys, m.Key)
+		}
+
+		return keys
+	}
+
+	testCases := []struct {
+		name string
+		req  dlq.GetManyMessageRequest
+		keys []string
+	}{
+		{name: "without filter", req: dlq.GetManyMessageRequest{}, keys: []string{"order-1", "order-2", "order-3"}},
+		{name: "by consumer", req: dlq.GetManyMessageRequest{Consumer: "e-ticket"}, keys: []string{"order-1", "order-3"}},
+		{name: "by channel", req: dlq.GetManyMessageRequest{Channel: "order-paid"}, keys: []string{"order-1", "order-2"}},
+		{name: "by consumer and channel", req: dlq.GetManyMessageRequest{Consumer: "e-ticket", Channel: "order-paid"}, keys: []string{"order-1"}},
+		{name: "from", req: dlq.GetManyMessageRequest{From: "2026-10-02T00:00:00Z"}, keys: []string{"order-2", "order-3"}},
+		{name: "to", req: dlq.GetManyMessageRequest{To: "2026-10-02T10:00:00Z"}, keys: []string{"order-1", "order-2"}},
+		{name: "nothing matches", req: dlq.GetManyMessageRequest{Consumer: "unknown"}, keys: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := uc.GetManyMessage(context.Background(), tc.req)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.keys)), resp.Total)
+			assert.ElementsMatch(t, tc.keys, keys(resp))
+		})
+	}
+}
+
+func TestDLQUseCaseReplayMessage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{Email: "ops@tm.id"})
+
+	setup := func(t *testing.T) (*pubsub.InMemoryBroker, *replayRepositoryStandIn, dlq.DLQUseCase, string) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		sendToDLQ(t, broker, pubsub.DeadLetterQueueMessage{
+			Channel:  "order-paid",
+			Consumer: "e-ticket",
+			Key:      "order-1",
+			Headers:  pubsub.MessageHeaders{"ce_type": "order-paid"},
+			Message:  `{"id":"order-1"}`,
+		})
+
+		records, _ := broker.DeadLetterQueueReader(dlqTopic).Read(context.Background())
+		if !assert.Len(t, records, 1) {
+			t.FailNow()
+		}
+
+		replayRepository := newReplayRepositoryStandIn()
+
+		return broker, replayRepository, newDLQUseCase(broker, replayRepository), records[0].ID
+	}
+
+	t.Run("republish the message to its original topic and record the replay", func(t *testing.T) {
+		broker, replayRepository, uc, ID := setup(t)
+
+		resp, err := uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID}, Reason: "consumer is fixed"})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusReplayed, resp.Results[0].Status)
+
+		replayed := broker.Messages("order-paid")
+		if assert.Len(t, replayed, 1) {
+			assert.Equal(t, "order-1", string(replayed[0].Key))
+			assert.Equal(t, `{"id":"order-1"}`, string(replayed[0].Value))
+		}
+
+		rp := replayRepository.replays[ID]
+		assert.Equal(t, "ops@tm.id", rp.ReplayedBy)
+		assert.Equal(t, "consumer is fixed", rp.Reason)
+	})
+
+	t.Run("a message is replayed only once", func(t *testing.T) {
+		broker, _, uc, ID := setup(t)
+
+		resp, err := uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID, ID}, Reason: "retry"})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusReplayed, resp.Results[0].Status)
+		assert.Equal(t, dlq.ReplayStatusAlreadyReplayed, resp.Results[1].Status)
+		assert.Len(t, broker.Messages("order-paid"), 1)
+	})
+
+	t.Run("a replayed message that was never published is replayed again with force", func(t *testing.T) {
+		broker, replayRepository, uc, ID := setup(t)
+		// the replay was recorded but the service stopped before it was published.
+		replayRepository.replays[ID] = dlq.Replay{MessageID: ID, ReplayedBy: "ops@tm.id", Reason: "first attempt"}
+
+		resp, err := uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID}, Reason: "retry"})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusAlreadyReplayed, resp.Results[0].Status)
+		assert.Empty(t, broker.Messages("order-paid"))
+
+		resp, err = uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID}, Reason: "never published", Force: true})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusReplayed, resp.Results[0].Status)
+		assert.Len(t, broker.Messages("order-paid"), 1)
+		assert.Equal(t, "never published", replayRepository.replays[ID].Reason)
+	})
+
+	t.Run("a forced replay that could not be published keeps the replay record", func(t *testing.T) {
+		broker, replayRepository, uc, ID := setup(t)
+		replayRepository.replays[ID] = dlq.Replay{MessageID: ID, ReplayedBy: "ops@tm.id", Reason: "first attempt"}
+		broker.FailPublish("order-paid", errors.New("broker is down"))
+
+		resp, err := uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID}, Reason: "retry", Force: true})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusFailed, resp.Results[0].Status)
+		assert.Contains(t, replayRepository.replays, ID)
+		assert.Empty(t, replayRepository.deleted)
+	})
+
+	t.Run("release the replay record when the message could not be published", func(t *testing.T) {
+		broker, replayRepository, uc, ID := setup(t)
+		broker.FailPublish("order-paid", errors.New("broker is down"))
+
+		resp, err := uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID}, Reason: "retry"})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusFailed, resp.Results[0].Status)
+		assert.Empty(t, replayRepository.replays)
+		assert.Equal(t, []string{ID}, replayRepository.deleted)
+
+		broker.ClearFailures()
+
+		resp, err = uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{ID}, Reason: "retry"})
+		assert.NoError(t, err)
+		assert.Equal(t, dlq.ReplayStatusReplayed, resp.Results[0].Status)
+		assert.Len(t, broker.Messages("order-paid"), 1)
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		_, _, uc, _ := setup(t)
+
+		resp, err := uc.ReplayMessage(ctx, dlq.ReplayMessageRequest{MessageIDs: []string{"other-topic:0:0", dlqTopic + ":0:99", "invalid"}, Reason: "retry"})
+		assert.NoError(t, err)
+		for _, result := range resp.Results {
+			assert.Equal(t, dlq.ReplayStatusNotFound, result.Status, result.ID)
+		}
+	})
+}
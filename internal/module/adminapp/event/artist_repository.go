@@ -12,6 +12,7 @@ import (
 
 type ArtistRepository interface {
 	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]Artist, error)
+	DeleteByEventID(ctx context.Context, eventID string, tx *sql.Tx) error
 	Save(ctx context.Context, a Artist, tx *sql.Tx) error
 }
 
@@ -108,3 +109,31 @@ func NewArtistRepository(logger *logrus.Logger, db *sql.DB) ArtistRepository {
 		db:     db,
 	}
 }
+
+// DeleteByEventID implements ArtistRepository.
+func (r *artistRepository) DeleteByEventID(ctx context.Context, eventID string, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		DELETE FROM event_artist WHERE event_id = $1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting event artist's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting event artist's prorperties")
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
 )
 
 const (
@@ -17,9 +18,34 @@ const (
 	TicketTierBronze       string = "BRONZE"
 	TicketTierSilver       string = "SILVER"
 	TicketTierGold         string = "GOLD"
-	TypeOrderRuleRangeDate string = "ORDER_RULE_RANGE_DATE"
+	TypeOrderRuleRangeDate string = orderrule.TypeRangeDate
+
+	StatusDraft     string = "DRAFT"
+	StatusActive    string = "ACTIVE"
+	StatusClosed    string = "CLOSED"
+	StatusCancelled string = "CANCELLED"
 )
 
+// statusTransitions holds the statuses that an event can move to from its current status. An event only moves
+// forward, a published event never goes back to draft and a closed sale is never reopened.
+var statusTransitions = map[string][]string{
+	StatusDraft:     {StatusActive, StatusCancelled},
+	StatusActive:    {StatusClosed, StatusCancelled},
+	StatusClosed:    {StatusCancelled},
+	StatusCancelled: {},
+}
+
+// CanTransitionTo reports whether the event can move from its current status to the given status.
+func (e Event) CanTransitionTo(status string) bool {
+	for _, s := range statusTransitions[e.Status] {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Location struct {
 	EventID          string
 	ShowID           string
@@ -61,14 +87,35 @@ type Event struct {
 	Shows       []Show
 	Description string
 	Status      string
-	OrderRules  OrderRuleAggregation
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Timezone is the IANA name of the zone that the times of the event are written and shown in.
+	Timezone   string
+	OrderRules OrderRuleAggregation
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+}
+
+// Location returns the zone of the event, UTC if the timezone of the event can not be loaded.
+func (e Event) Location() *time.Location {
+	location, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+type EventFilter struct {
+	Search string
+	Status string
 }
 
 type OrderRuleAggregation struct {
 	OrderRuleRangeDate order.OrderRuleRangeDate
 	OrderRuleDay       []order.OrderRuleDay
+	// Rules of the shows and ticket stocks, the most specific rule of a ticket stock wins.
+	ScopedOrderRuleRangeDate []order.OrderRuleRangeDate
+	ScopedOrderRuleDay       []order.OrderRuleDay
 }
 
 type OrderRuleRangeDate struct {
@@ -86,3 +133,72 @@ type OrderRuleMaximumTicket struct {
 	EventID string
 	Maximum int64
 }
+
+const (
+	SalesIntervalHour string = "hour"
+	SalesIntervalDay  string = "day"
+)
+
+// salesIntervals are the only units that the sales time series is truncated to.
+var salesIntervals = map[string]bool{
+	SalesIntervalHour: true,
+	SalesIntervalDay:  true,
+}
+
+// TicketStockSales is the sales figure of a ticket stock. Pending is the quantity of orders that are still
+// waiting for payment.
+type TicketStockSales struct {
+	TicketStockID string
+	ShowID        string
+	Tier          string
+	Price         float64
+	Allocation    int64
+	Acquired      int64
+	Pending       int64
+}
+
+// Remaining returns the number of tickets that are neither acquired nor held by a pending order.
+func (s TicketStockSales) Remaining() int64 {
+	remaining := s.Allocation - s.Acquired - s.Pending
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// SoldOrders counts the orders of an event that are waiting for payment or paid, the tickets that they hold are sold.
+// An order is counted once per show it has tickets of.
+type SoldOrders struct {
+	Total  int64
+	ByShow map[string]int64
+}
+
+// SalesSummary is the revenue and the order conversion of an event, the revenue only counts paid orders. Subtotal is
+// the price of the tickets, TotalBilled is what the customers paid for them including the tax and the service charge.
+type SalesSummary struct {
+	PlacedOrders            int64
+	PaidOrders              int64
+	ExpiredOrders           int64
+	WaitingForPaymentOrders int64
+	Subtotal                float64
+	Tax                     float64
+	ServiceCharge           float64
+	Discount                float64
+	TotalBilled             float64
+}
+
+// SalesBucket is the sales figure of an event within a time bucket, Revenue is the total billed to its paid orders.
+type SalesBucket struct {
+	Time         time.Time
+	PlacedOrders int64
+	PaidOrders   int64
+	TicketsSold  int64
+	Revenue      float64
+}
+
+type SalesFilter struct {
+	Interval string
+	From     time.Time
+	To       time.Time
+}
@@ -0,0 +1,47 @@
+package event_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/event"
+)
+
+func TestEventCanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		from     string
+		to       string
+		expected bool
+	}{
+		{from: event.StatusDraft, to: event.StatusActive, expected: true},
+		{from: event.StatusDraft, to: event.StatusCancelled, expected: true},
+		{from: event.StatusDraft, to: event.StatusClosed, expected: false},
+		{from: event.StatusActive, to: event.StatusDraft, expected: false},
+		{from: event.StatusActive, to: event.StatusClosed, expected: true},
+		{from: event.StatusActive, to: event.StatusCancelled, expected: true},
+		{from: event.StatusActive, to: event.StatusActive, expected: false},
+		{from: event.StatusClosed, to: event.StatusActive, expected: false},
+		{from: event.StatusClosed, to: event.StatusDraft, expected: false},
+		{from: event.StatusClosed, to: event.StatusCancelled, expected: true},
+		{from: event.StatusCancelled, to: event.StatusActive, expected: false},
+		{from: event.StatusCancelled, to: event.StatusDraft, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.from+" to "+tc.to, func(t *testing.T) {
+			e := event.Event{Status: tc.from}
+			assert.Equal(t, tc.expected, e.CanTransitionTo(tc.to))
+		})
+	}
+}
+
+func TestTicketStockSalesRemaining(t *testing.T) {
+	t.Run("remaining excludes acquired and pending tickets", func(t *testing.T) {
+		s := event.TicketStockSales{Allocation: 100, Acquired: 60, Pending: 15}
+		assert.Equal(t, int64(25), s.Remaining())
+	})
+	t.Run("remaining is never negative", func(t *testing.T) {
+		s := event.TicketStockSales{Allocation: 10, Acquired: 8, Pending: 5}
+		assert.Equal(t, int64(0), s.Remaining())
+	})
+}
@@ -3,9 +3,10 @@ package event
 import (
 	"context"
 	"database/sql"
-
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
@@ -19,9 +20,16 @@ type EventRepository interface {
 
 	Save(ctx context.Context, e Event, tx *sql.Tx) error
 	FindByID(ctx context.Context, ID string, tx *sql.Tx) (Event, error)
-	Update(ctx context.Context, ID string, update Event, tx *sql.DB) error
+	FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (Event, error)
+	FindMany(ctx context.Context, filter EventFilter, offset, limit int64, tx *sql.Tx) ([]Event, error)
+	Count(ctx context.Context, filter EventFilter, tx *sql.Tx) (int64, error)
+	Update(ctx context.Context, ID string, update Event, tx *sql.Tx) error
+	Delete(ctx context.Context, ID string, deletedAt time.Time, tx *sql.Tx) error
 }
 
+// likeEscaper escapes the wildcards of a LIKE pattern, the escape character is the default backslash.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type sqlCommand interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
@@ -72,8 +80,7 @@ func (r *eventRepository) Rollback(ctx context.Context, tx *sql.Tx) error {
 	return nil
 }
 
-// FindByID implements EventRepository.
-func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (Event, error) {
+func (r *eventRepository) findByID(ctx context.Context, ID string, forUpdate bool, tx *sql.Tx) (Event, error) {
 	var cmd sqlCommand = r.db
 
 	if tx != nil {
@@ -82,12 +89,15 @@ func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 
 	query := `
 		SELECT 
-			id, name, description, status created_at, updated_at
+			id, name, description, status, timezone, created_at, updated_at
 		FROM event
 		WHERE
-			id = $1
+			id = $1 AND deleted_at IS NULL
 		LIMIT 1
 	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
 
 	stmt, err := cmd.PrepareContext(ctx, query)
 	if err != nil {
@@ -100,7 +110,7 @@ func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 
 	var data Event
 	err = row.Scan(
-		&data.ID, &data.Name, &data.Description, &data.Status, &data.CreatedAt, &data.UpdatedAt,
+		&data.ID, &data.Name, &data.Description, &data.Status, &data.Timezone, &data.CreatedAt, &data.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -113,6 +123,120 @@ func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 	return data, nil
 }
 
+// FindByID implements EventRepository.
+func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (Event, error) {
+	return r.findByID(ctx, ID, false, tx)
+}
+
+// FindByIDForUpdate implements EventRepository.
+func (r *eventRepository) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (Event, error) {
+	return r.findByID(ctx, ID, true, tx)
+}
+
+// filterCondition returns the where clause and its arguments of the given filter.
+func (r *eventRepository) filterCondition(filter EventFilter) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := make([]interface{}, 0)
+
+	if filter.Search != "" {
+		// the wildcards of the search are matched literally.
+		args = append(args, likeEscaper.Replace(filter.Search))
+		conditions = append(conditions, fmt.Sprintf(`(name ILIKE '%%' || $%d || '%%' ESCAPE '\' OR description ILIKE '%%' || $%d || '%%' ESCAPE '\')`, len(args), len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// FindMany implements EventRepository.
+func (r *eventRepository) FindMany(ctx context.Context, filter EventFilter, offset, limit int64, tx *sql.Tx) ([]Event, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	condition, args := r.filterCondition(filter)
+	args = append(args, offset, limit)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			id, name, description, status, timezone, created_at, updated_at
+		FROM event
+		WHERE
+			%s
+		ORDER BY created_at DESC
+		OFFSET $%d
+		LIMIT $%d
+	`, condition, len(args)-1, len(args))
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]Event, 0)
+	for rows.Next() {
+		var e Event
+		err := rows.Scan(&e.ID, &e.Name, &e.Description, &e.Status, &e.Timezone, &e.CreatedAt, &e.UpdatedAt)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event's prorperties")
+		}
+
+		data = append(data, e)
+	}
+
+	return data, nil
+}
+
+// Count implements EventRepository.
+func (r *eventRepository) Count(ctx context.Context, filter EventFilter, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	condition, args := r.filterCondition(filter)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			COUNT(id)
+		FROM event
+		WHERE
+			%s
+	`, condition)
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting event's prorperties")
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.QueryRowContext(ctx, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting event's prorperties")
+	}
+
+	return count, nil
+}
+
 // Save implements EventRepository.
 func (r *eventRepository) Save(ctx context.Context, e Event, tx *sql.Tx) error {
 	var cmd sqlCommand = r.db
@@ -124,11 +248,11 @@ func (r *eventRepository) Save(ctx context.Context, e Event, tx *sql.Tx) error {
 	query := `
 		INSERT INTO event 
 		(
-			id, name, description, status, created_at, updated_at
+			id, name, description, status, timezone, created_at, updated_at
 		)
 		VALUES
 		(
-			$1, $2, $3, $4, $5, $6
+			$1, $2, $3, $4, $5, $6, $7
 		)
 	`
 
@@ -139,7 +263,7 @@ func (r *eventRepository) Save(ctx context.Context, e Event, tx *sql.Tx) error {
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, e.ID, e.Name, e.Description, e.Status, e.CreatedAt, e.UpdatedAt)
+	_, err = stmt.ExecContext(ctx, e.ID, e.Name, e.Description, e.Status, e.Timezone, e.CreatedAt, e.UpdatedAt)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving event's prorperties")
@@ -149,7 +273,7 @@ func (r *eventRepository) Save(ctx context.Context, e Event, tx *sql.Tx) error {
 }
 
 // Update implements EventRepository.
-func (r *eventRepository) Update(ctx context.Context, ID string, e Event, tx *sql.DB) error {
+func (r *eventRepository) Update(ctx context.Context, ID string, e Event, tx *sql.Tx) error {
 	var cmd sqlCommand = r.db
 
 	if tx != nil {
@@ -162,8 +286,9 @@ func (r *eventRepository) Update(ctx context.Context, ID string, e Event, tx *sq
 			name = $1,
 			description = $2,
 			status = $3,
-			updated_at = $4
-		WHERE id = $5
+			timezone = $4,
+			updated_at = $5
+		WHERE id = $6
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
@@ -173,7 +298,7 @@ func (r *eventRepository) Update(ctx context.Context, ID string, e Event, tx *sq
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, e.Name, e.Description, e.Status, e.CreatedAt, e.UpdatedAt)
+	_, err = stmt.ExecContext(ctx, e.Name, e.Description, e.Status, e.Timezone, e.UpdatedAt, ID)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating event's prorperties")
@@ -181,3 +306,35 @@ func (r *eventRepository) Update(ctx context.Context, ID string, e Event, tx *sq
 
 	return nil
 }
+
+// Delete implements EventRepository. The event is only marked as deleted.
+func (r *eventRepository) Delete(ctx context.Context, ID string, deletedAt time.Time, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE event
+		SET
+			deleted_at = $1,
+			updated_at = $1
+		WHERE id = $2
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting event's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, deletedAt, ID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting event's prorperties")
+	}
+
+	return nil
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
 	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
@@ -16,6 +18,11 @@ import (
 	"github.com/tsel-ticketmaster/tm-order/pkg/status"
 )
 
+const (
+	// Largest import file that is read, a festival with hundreds of shows is still far below it.
+	maxImportFileSize = 10 << 20
+)
+
 type HTTPHandler struct {
 	SessionMiddleware *middleware.AdminSession
 	Validate          *validator.Validate
@@ -28,7 +35,17 @@ func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession,
 		EventUseCase: eventUsecase,
 	}
 
-	router.HandleFunc("/tm-order/v1/adminapp/events", publicMiddleware.SetRouteChain(handler.CreateEvent, adminSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/events", publicMiddleware.SetRouteChain(handler.CreateEvent, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/events/import", publicMiddleware.SetRouteChain(handler.ImportEvents, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/events", publicMiddleware.SetRouteChain(handler.GetManyEvent, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}", publicMiddleware.SetRouteChain(handler.GetEvent, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}", publicMiddleware.SetRouteChain(handler.UpdateEvent, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPatch)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}/sales", publicMiddleware.SetRouteChain(handler.GetEventSales, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}/status", publicMiddleware.SetRouteChain(handler.UpdateEventStatus, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPatch)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}", publicMiddleware.SetRouteChain(handler.DeleteEvent, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodDelete)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}/order-rules", publicMiddleware.SetRouteChain(handler.GetManyOrderRule, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}/order-rules", publicMiddleware.SetRouteChain(handler.AttachOrderRule, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/events/{id}/order-rules/{rule_id}", publicMiddleware.SetRouteChain(handler.DetachOrderRule, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodDelete)
 }
 
 func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
@@ -91,3 +108,353 @@ func (handler HTTPHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	})
 
 }
+
+func (handler HTTPHandler) GetManyEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := GetManyEventRequest{}
+	req.Page, _ = strconv.ParseInt(qs.Get("page"), 10, 64)
+	req.Size, _ = strconv.ParseInt(qs.Get("size"), 10, 64)
+	req.Search = qs.Get("search")
+	req.Status = qs.Get("status")
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.GetManyEvent(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of events",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.EventUseCase.GetEvent(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "event",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := UpdateEventRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.ID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.UpdateEvent(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "event has been successfully updated",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) UpdateEventStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := UpdateEventStatusRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.ID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.UpdateEventStatus(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: fmt.Sprintf("event status has been successfully changed to '%s'", resp.Status),
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := handler.EventUseCase.DeleteEvent(ctx, mux.Vars(r)["id"]); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "event has been successfully deleted",
+	})
+}
+
+func (handler HTTPHandler) GetEventSales(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := GetEventSalesRequest{}
+	req.EventID = mux.Vars(r)["id"]
+	req.Interval = qs.Get("interval")
+	req.From = qs.Get("from")
+	req.To = qs.Get("to")
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.GetEventSales(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "event sales",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) ImportEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := ImportEventRequest{}
+	req.Format = qs.Get("format")
+	req.DryRun, _ = strconv.ParseBool(qs.Get("dry_run"))
+	req.File = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.ImportEvents(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	if len(resp.Errors) > 0 {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: "import file is invalid",
+			Data:    resp,
+		})
+
+		return
+	}
+
+	if resp.DryRun {
+		response.JSON(w, http.StatusOK, response.RESTEnvelope{
+			Status:  status.OK,
+			Message: "import file is valid",
+			Data:    resp,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.RESTEnvelope{
+		Status:  status.CREATED,
+		Message: "events have been successfully imported",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetManyOrderRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.EventUseCase.GetManyOrderRule(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of order rules",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) AttachOrderRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := AttachOrderRuleRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.EventID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.AttachOrderRule(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.RESTEnvelope{
+		Status:  status.CREATED,
+		Message: "order rule has been successfully attached",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) DetachOrderRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := DetachOrderRuleRequest{}
+	req.EventID = mux.Vars(r)["id"]
+	req.ID, _ = strconv.ParseInt(mux.Vars(r)["rule_id"], 10, 64)
+	req.AcknowledgeSoldInventory, _ = strconv.ParseBool(r.URL.Query().Get("acknowledge_sold_inventory"))
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.EventUseCase.DetachOrderRule(ctx, req); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "order rule has been successfully detached",
+	})
+}
@@ -0,0 +1,394 @@
+package event
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ImportFormatYAML = "yaml"
+	ImportFormatCSV  = "csv"
+
+	// Separator of the values of a csv cell that holds a list.
+	importCSVListSeparator = "|"
+)
+
+// ImportedEvent is an event of an import file with the row, or the line in yaml, where it starts.
+type ImportedEvent struct {
+	Row     int
+	Request CreateEventRequest
+}
+
+type ImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ParseImport reads the events of an import file, every row that can not be read is reported instead of stopping at the first one.
+func ParseImport(format string, r io.Reader) ([]ImportedEvent, []ImportError) {
+	switch format {
+	case ImportFormatYAML:
+		return parseImportYAML(r)
+	case ImportFormatCSV:
+		return parseImportCSV(r)
+	default:
+		return nil, []ImportError{{Message: fmt.Sprintf("unsupported import format '%s'", format)}}
+	}
+}
+
+// stringifyTimestamps keeps the timestamps as written, the requests expect their own datetime layout.
+func stringifyTimestamps(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+
+	for _, child := range node.Content {
+		stringifyTimestamps(child)
+	}
+}
+
+// parseImportYAML reads a document with a list of events under the "events" key, the keys of an event are the
+// json keys of CreateEventRequest.
+func parseImportYAML(r io.Reader) ([]ImportedEvent, []ImportError) {
+	var file struct {
+		Events []yaml.Node `yaml:"events"`
+	}
+
+	if err := yaml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, []ImportError{{Message: err.Error()}}
+	}
+
+	events := make([]ImportedEvent, 0, len(file.Events))
+	importErrors := make([]ImportError, 0)
+	for k := range file.Events {
+		node := &file.Events[k]
+		stringifyTimestamps(node)
+
+		var raw interface{}
+		if err := node.Decode(&raw); err != nil {
+			importErrors = append(importErrors, ImportError{Row: node.Line, Message: err.Error()})
+			continue
+		}
+
+		buff, err := json.Marshal(raw)
+		if err != nil {
+			importErrors = append(importErrors, ImportError{Row: node.Line, Message: err.Error()})
+			continue
+		}
+
+		req := CreateEventRequest{}
+		if err := json.Unmarshal(buff, &req); err != nil {
+			importErrors = append(importErrors, ImportError{Row: node.Line, Message: err.Error()})
+			continue
+		}
+
+		events = append(events, ImportedEvent{Row: node.Line, Request: req})
+	}
+
+	if len(events) == 0 && len(importErrors) == 0 {
+		importErrors = append(importErrors, ImportError{Message: "import file has no event"})
+	}
+
+	return events, importErrors
+}
+
+var (
+	importCSVEventColumns = []string{
+		"name", "description", "artists", "promotor_names", "promotor_emails", "promotor_phones",
+		"online_ticket_price", "total_online_ticket_allocation", "show_time", "order_rule_day",
+		"order_rule_start_date", "order_rule_end_date", "order_rule_maximum_ticket", "timezone",
+	}
+	importCSVShowColumns = []string{
+		"venue", "show_type", "online", "country", "city", "formatted_address", "latitude", "longitude", "total_ticket_allocation",
+		"show_order_rule_day", "show_order_rule_start_date", "show_order_rule_end_date",
+	}
+	importCSVTierColumns = []string{
+		"tier", "allocation_by_percentage", "price",
+		"tier_order_rule_day", "tier_order_rule_start_date", "tier_order_rule_end_date",
+	}
+)
+
+// importCSVRow reads the cells of a csv row by their column, a cell that can not be converted is reported as an error of the row.
+type importCSVRow struct {
+	row    int
+	index  map[string]int
+	record []string
+	errors []ImportError
+}
+
+func (r *importCSVRow) get(column string) string {
+	i, ok := r.index[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+
+	return strings.TrimSpace(r.record[i])
+}
+
+func (r *importCSVRow) list(column string) []string {
+	value := r.get(column)
+	if value == "" {
+		return nil
+	}
+
+	values := strings.Split(value, importCSVListSeparator)
+	for k := range values {
+		values[k] = strings.TrimSpace(values[k])
+	}
+
+	return values
+}
+
+func (r *importCSVRow) fail(column string, err error) {
+	r.errors = append(r.errors, ImportError{Row: r.row, Field: column, Message: err.Error()})
+}
+
+func (r *importCSVRow) float(column string) float64 {
+	value := r.get(column)
+	if value == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		r.fail(column, fmt.Errorf("'%s' is not a number", value))
+	}
+
+	return f
+}
+
+func (r *importCSVRow) int(column string) int64 {
+	value := r.get(column)
+	if value == "" {
+		return 0
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		r.fail(column, fmt.Errorf("'%s' is not an integer", value))
+	}
+
+	return i
+}
+
+func (r *importCSVRow) bool(column string) bool {
+	value := r.get(column)
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.fail(column, fmt.Errorf("'%s' is not a boolean", value))
+	}
+
+	return b
+}
+
+func (r *importCSVRow) days(column string) []int64 {
+	var days []int64
+	for _, day := range r.list(column) {
+		d, err := strconv.ParseInt(day, 10, 64)
+		if err != nil {
+			r.fail(column, fmt.Errorf("'%s' is not an integer", day))
+			continue
+		}
+		days = append(days, d)
+	}
+
+	return days
+}
+
+// rangeDate returns nil if neither the start nor the end date is given.
+func (r *importCSVRow) rangeDate(startColumn, endColumn string) *CreateOrderRuleRangeDateRequest {
+	if r.get(startColumn) == "" && r.get(endColumn) == "" {
+		return nil
+	}
+
+	return &CreateOrderRuleRangeDateRequest{
+		StartDate: r.get(startColumn),
+		EndDate:   r.get(endColumn),
+	}
+}
+
+// key joins the cells of the columns, so the repeated cells of an event or a show can be compared.
+func (r *importCSVRow) key(columns []string) string {
+	values := make([]string, len(columns))
+	for k, column := range columns {
+		values[k] = r.get(column)
+	}
+
+	return strings.Join(values, "\x1f")
+}
+
+func (r *importCSVRow) event() CreateEventRequest {
+	req := CreateEventRequest{
+		Name:                        r.get("name"),
+		Description:                 r.get("description"),
+		Artists:                     r.list("artists"),
+		OnlineTicketPrice:           r.float("online_ticket_price"),
+		TotalOnlineTicketAllocation: r.int("total_online_ticket_allocation"),
+		Shows:                       make([]CreateShowRequest, 0),
+		ShowTime:                    r.get("show_time"),
+		OrderRuleDay:                r.days("order_rule_day"),
+		OrderRuleMaximumTicket:      r.int("order_rule_maximum_ticket"),
+		Timezone:                    r.get("timezone"),
+	}
+	req.OrderRuleRangeDate.StartDate = r.get("order_rule_start_date")
+	req.OrderRuleRangeDate.EndDate = r.get("order_rule_end_date")
+
+	names, emails, phones := r.list("promotor_names"), r.list("promotor_emails"), r.list("promotor_phones")
+	if len(emails) != len(names) || len(phones) != len(names) {
+		r.fail("promotor_names", fmt.Errorf("promotor names, emails and phones must have the same number of values"))
+	} else {
+		for k := range names {
+			req.Promotors = append(req.Promotors, struct {
+				Name  string `json:"name" validate:"required"`
+				Email string `json:"email" validate:"email"`
+				Phone string `json:"phone" validate:"required"`
+			}{Name: names[k], Email: emails[k], Phone: phones[k]})
+		}
+	}
+
+	return req
+}
+
+func (r *importCSVRow) show() CreateShowRequest {
+	return CreateShowRequest{
+		Venue:  r.get("venue"),
+		Type:   r.get("show_type"),
+		Online: r.bool("online"),
+		Location: &CreateLocationRequest{
+			Country:          r.get("country"),
+			City:             r.get("city"),
+			FormattedAddress: r.get("formatted_address"),
+			Latitude:         r.float("latitude"),
+			Longitude:        r.float("longitude"),
+		},
+		TotalTicketAllocation: r.int("total_ticket_allocation"),
+		TicketAllocation:      make([]CreateTicketAllocation, 0),
+		OrderRuleDay:          r.days("show_order_rule_day"),
+		OrderRuleRangeDate:    r.rangeDate("show_order_rule_start_date", "show_order_rule_end_date"),
+	}
+}
+
+func (r *importCSVRow) tier() CreateTicketAllocation {
+	return CreateTicketAllocation{
+		Tier:                   r.get("tier"),
+		AllocationByPercentage: r.float("allocation_by_percentage"),
+		Price:                  r.float("price"),
+		OrderRuleDay:           r.days("tier_order_rule_day"),
+		OrderRuleRangeDate:     r.rangeDate("tier_order_rule_start_date", "tier_order_rule_end_date"),
+	}
+}
+
+type importCSVShow struct {
+	index int
+	row   int
+	key   string
+}
+
+type importCSVEvent struct {
+	index int
+	row   int
+	key   string
+	shows map[string]*importCSVShow
+}
+
+// parseImportCSV reads one tier allocation per row. The rows of an event share its event_ref and the rows of a show
+// share its show_ref, the event and show columns only have to be filled in the first row of the event or show.
+func parseImportCSV(r io.Reader) ([]ImportedEvent, []ImportError) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, []ImportError{{Row: 1, Message: err.Error()}}
+	}
+
+	known := map[string]bool{"event_ref": true, "show_ref": true}
+	for _, columns := range [][]string{importCSVEventColumns, importCSVShowColumns, importCSVTierColumns} {
+		for _, column := range columns {
+			known[column] = true
+		}
+	}
+
+	importErrors := make([]ImportError, 0)
+	index := make(map[string]int, len(header))
+	for k, column := range header {
+		column = strings.TrimSpace(column)
+		if !known[column] {
+			importErrors = append(importErrors, ImportError{Row: 1, Field: column, Message: "unknown column"})
+			continue
+		}
+		index[column] = k
+	}
+
+	for _, column := range []string{"event_ref", "show_ref"} {
+		if _, ok := index[column]; !ok {
+			importErrors = append(importErrors, ImportError{Row: 1, Field: column, Message: "column is required"})
+		}
+	}
+
+	if len(importErrors) > 0 {
+		return nil, importErrors
+	}
+
+	events := make([]ImportedEvent, 0)
+	eventByRef := make(map[string]*importCSVEvent)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			importErrors = append(importErrors, ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+
+		cr := &importCSVRow{row: row, index: index, record: record}
+		eventRef, showRef := cr.get("event_ref"), cr.get("show_ref")
+		if eventRef == "" || showRef == "" {
+			importErrors = append(importErrors, ImportError{Row: row, Field: "event_ref", Message: "event_ref and show_ref are required"})
+			continue
+		}
+
+		ev, ok := eventByRef[eventRef]
+		if !ok {
+			ev = &importCSVEvent{index: len(events), row: row, key: cr.key(importCSVEventColumns), shows: make(map[string]*importCSVShow)}
+			eventByRef[eventRef] = ev
+			events = append(events, ImportedEvent{Row: row, Request: cr.event()})
+		} else if key := cr.key(importCSVEventColumns); key != ev.key && strings.Trim(key, "\x1f") != "" {
+			cr.fail("event_ref", fmt.Errorf("event columns differ from row %d", ev.row))
+		}
+
+		req := &events[ev.index].Request
+
+		show, ok := ev.shows[showRef]
+		if !ok {
+			show = &importCSVShow{index: len(req.Shows), row: row, key: cr.key(importCSVShowColumns)}
+			ev.shows[showRef] = show
+			req.Shows = append(req.Shows, cr.show())
+		} else if key := cr.key(importCSVShowColumns); key != show.key && strings.Trim(key, "\x1f") != "" {
+			cr.fail("show_ref", fmt.Errorf("show columns differ from row %d", show.row))
+		}
+
+		if cr.get("tier") != "" {
+			req.Shows[show.index].TicketAllocation = append(req.Shows[show.index].TicketAllocation, cr.tier())
+		}
+
+		importErrors = append(importErrors, cr.errors...)
+	}
+
+	if len(events) == 0 && len(importErrors) == 0 {
+		importErrors = append(importErrors, ImportError{Message: "import file has no event"})
+	}
+
+	return events, importErrors
+}
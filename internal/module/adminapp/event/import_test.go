@@ -0,0 +1,110 @@
+package event_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/event"
+)
+
+func TestParseImportYAML(t *testing.T) {
+	file := `
+events:
+  - name: Festival
+    description: Three days of music
+    artists: [Band A, Band B]
+    promotors:
+      - name: Promotor
+        email: promotor@example.com
+        phone: "0812"
+    online_ticket_price: 50000
+    total_online_ticket_allocation: 100
+    show_time: 2024-08-01 19:00:00
+    order_rule_day: [1, 2]
+    order_rule_range_date:
+      start_date: 2024-07-01 00:00:00
+      end_date: 2024-07-31 23:59:59
+    shows:
+      - venue: Main Stage
+        type: LIVE
+        location:
+          country: Indonesia
+          city: Jakarta
+          formatted_address: Jl. Sudirman
+          latitude: -6.2
+          longitude: 106.8
+        total_ticket_allocation: 1000
+        ticket_allocation:
+          - tier: GOLD
+            allocation_by_percentage: 100
+            price: 150000
+  - name: [invalid]
+`
+
+	events, importErrors := event.ParseImport(event.ImportFormatYAML, strings.NewReader(file))
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, 3, events[0].Row)
+	assert.Equal(t, "2024-08-01 19:00:00", events[0].Request.ShowTime)
+	assert.Equal(t, "2024-07-01 00:00:00", events[0].Request.OrderRuleRangeDate.StartDate)
+	assert.Equal(t, []int64{1, 2}, events[0].Request.OrderRuleDay)
+	assert.Equal(t, "Jakarta", events[0].Request.Shows[0].Location.City)
+	assert.Equal(t, int64(1000), events[0].Request.Shows[0].TotalTicketAllocation)
+
+	assert.Len(t, importErrors, 1)
+	assert.Equal(t, 31, importErrors[0].Row)
+}
+
+func TestParseImportCSV(t *testing.T) {
+	t.Run("group tiers into shows and events", func(t *testing.T) {
+		file := strings.Join([]string{
+			"event_ref,name,description,artists,promotor_names,promotor_emails,promotor_phones,show_time,order_rule_day,show_ref,venue,show_type,online,city,total_ticket_allocation,tier,allocation_by_percentage,price",
+			"fest,Festival,Music,Band A|Band B,Promotor,promotor@example.com,0812,2024-08-01 19:00:00,1|2,main,Main Stage,LIVE,true,Jakarta,1000,GOLD,40,150000",
+			"fest,,,,,,,,,main,,,,,,SILVER,60,100000",
+			"fest,,,,,,,,,side,Side Stage,LIVE,false,Jakarta,200,BRONZE,100,50000",
+			"solo,Solo,Acoustic,Singer,Promotor,promotor@example.com,0812,2024-09-01 19:00:00,,main,Hall,LIVE,false,Bandung,100,GOLD,100,75000",
+		}, "\n")
+
+		events, importErrors := event.ParseImport(event.ImportFormatCSV, strings.NewReader(file))
+
+		assert.Empty(t, importErrors)
+		assert.Len(t, events, 2)
+
+		fest := events[0]
+		assert.Equal(t, 2, fest.Row)
+		assert.Equal(t, []string{"Band A", "Band B"}, fest.Request.Artists)
+		assert.Equal(t, []int64{1, 2}, fest.Request.OrderRuleDay)
+		assert.Len(t, fest.Request.Shows, 2)
+		assert.True(t, fest.Request.Shows[0].Online)
+		assert.Len(t, fest.Request.Shows[0].TicketAllocation, 2)
+		assert.Equal(t, "SILVER", fest.Request.Shows[0].TicketAllocation[1].Tier)
+		assert.Equal(t, "Side Stage", fest.Request.Shows[1].Venue)
+
+		assert.Equal(t, 5, events[1].Row)
+		assert.Equal(t, "Bandung", events[1].Request.Shows[0].Location.City)
+	})
+
+	t.Run("report row errors", func(t *testing.T) {
+		file := strings.Join([]string{
+			"event_ref,name,show_ref,venue,total_ticket_allocation,tier,price",
+			"fest,Festival,main,Main Stage,1000,GOLD,abc",
+			"fest,Other Name,main,,,SILVER,100",
+			",Festival,main,Main Stage,1000,GOLD,100",
+		}, "\n")
+
+		_, importErrors := event.ParseImport(event.ImportFormatCSV, strings.NewReader(file))
+
+		assert.Equal(t, []event.ImportError{
+			{Row: 2, Field: "price", Message: "'abc' is not a number"},
+			{Row: 3, Field: "event_ref", Message: "event columns differ from row 2"},
+			{Row: 4, Field: "event_ref", Message: "event_ref and show_ref are required"},
+		}, importErrors)
+	})
+
+	t.Run("reject unknown column", func(t *testing.T) {
+		_, importErrors := event.ParseImport(event.ImportFormatCSV, strings.NewReader("event_ref,show_ref,capacity\n"))
+
+		assert.Equal(t, []event.ImportError{{Row: 1, Field: "capacity", Message: "unknown column"}}, importErrors)
+	})
+}
@@ -14,6 +14,7 @@ import (
 type LocationRepository interface {
 	FindByShowID(ctx context.Context, showID string, tx *sql.Tx) (Location, error)
 	Save(ctx context.Context, l Location, tx *sql.Tx) error
+	Update(ctx context.Context, showID string, l Location, tx *sql.Tx) error
 }
 
 type locationRepository struct {
@@ -103,3 +104,38 @@ func NewLocationRepository(logger *logrus.Logger, db *sql.DB) LocationRepository
 		db:     db,
 	}
 }
+
+// Update implements LocationRepository.
+func (r *locationRepository) Update(ctx context.Context, showID string, l Location, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE event_show_location
+		SET
+			country = $1,
+			city = $2,
+			formatted_address = $3,
+			latitude = $4,
+			longitude = $5
+		WHERE show_id = $6
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating event show location's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, l.Country, l.City, l.FormattedAddress, l.Latitude, l.Longitude, showID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating event show location's prorperties")
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 type PromotorRepository interface {
 	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]Promotor, error)
+	DeleteByEventID(ctx context.Context, eventID string, tx *sql.Tx) error
 	Save(ctx context.Context, p Promotor, tx *sql.Tx) error
 }
 
@@ -96,14 +97,42 @@ func (r *promotorRepository) Save(ctx context.Context, p Promotor, tx *sql.Tx) e
 	stmt, err := cmd.PrepareContext(ctx, query)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
-		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving event artist's prorperties")
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving event promotor's prorperties")
 	}
 	defer stmt.Close()
 
 	_, err = stmt.ExecContext(ctx, p.EventID, p.Name, p.Email, p.Phone)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
-		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving event artist's prorperties")
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving event promotor's prorperties")
+	}
+
+	return nil
+}
+
+// DeleteByEventID implements PromotorRepository.
+func (r *promotorRepository) DeleteByEventID(ctx context.Context, eventID string, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		DELETE FROM event_promotor WHERE event_id = $1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting event promotor's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting event promotor's prorperties")
 	}
 
 	return nil
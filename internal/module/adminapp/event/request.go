@@ -1,35 +1,79 @@
 package event
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"math"
+	"net/http"
 	"time"
 
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
 )
 
 type CreateLocationRequest struct {
 	Country          string  `json:"country" validate:"required"`
 	City             string  `json:"city" validate:"required"`
-	FormattedAddress string  `json:"formatted_address" validate:"formatted_address"`
+	FormattedAddress string  `json:"formatted_address" validate:"required"`
 	Latitude         float64 `json:"latitude" validate:"required"`
 	Longitude        float64 `json:"longitude" validate:"required"`
 }
 
+type CreateOrderRuleRangeDateRequest struct {
+	StartDate string `json:"start_date" validate:"datetime=2006-01-02 15:04:05"`
+	EndDate   string `json:"end_date" validate:"datetime=2006-01-02 15:04:05"`
+}
+
+func (r CreateOrderRuleRangeDateRequest) toEntity(eventID string, showID, ticketStockID *string, location *time.Location) order.OrderRuleRangeDate {
+	startDate, _ := time.ParseInLocation(time.DateTime, r.StartDate, location)
+	endDate, _ := time.ParseInLocation(time.DateTime, r.EndDate, location)
+
+	return order.OrderRuleRangeDate{
+		EventID:       eventID,
+		ShowID:        showID,
+		TicketStockID: ticketStockID,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}
+}
+
+func newOrderRuleDays(eventID string, showID, ticketStockID *string, days []int64) []order.OrderRuleDay {
+	rules := make([]order.OrderRuleDay, len(days))
+	for k, v := range days {
+		rules[k] = order.OrderRuleDay{
+			EventID:       eventID,
+			ShowID:        showID,
+			TicketStockID: ticketStockID,
+			Day:           v,
+		}
+	}
+
+	return rules
+}
+
+// CreateTicketAllocation may have its own order rules, they take precedence over the rules of the show and the event.
 type CreateTicketAllocation struct {
-	Tier                   string  `json:"tier" validate:"oneof=WOOD BRONZE SILVER GOLD"`
-	AllocationByPercentage float64 `json:"allocation_by_percentage" validate:"required"`
-	Price                  float64 `json:"price" validate:"required"`
+	Tier                   string                           `json:"tier" validate:"oneof=WOOD BRONZE SILVER GOLD"`
+	AllocationByPercentage float64                          `json:"allocation_by_percentage" validate:"required"`
+	Price                  float64                          `json:"price" validate:"required"`
+	OrderRuleDay           []int64                          `json:"order_rule_day" validate:"omitempty,dive,min=1,max=7"`
+	OrderRuleRangeDate     *CreateOrderRuleRangeDateRequest `json:"order_rule_range_date" validate:"omitempty"`
 }
 
+// CreateShowRequest may have its own order rules, they take precedence over the rules of the event.
 type CreateShowRequest struct {
-	Venue                 string                   `json:"venue" validate:"required"`
-	Type                  string                   `json:"type" validate:"oneof=LIVE HOLOGRAM_LIVE"`
-	Online                bool                     `json:"online" validate:"-"`
-	Location              *CreateLocationRequest   `json:"location" validate:"-"`
-	TotalTicketAllocation int64                    `json:"total_ticket_allocation"`
-	TicketAllocation      []CreateTicketAllocation `json:"ticket_allocation" validate:"required,dive"`
+	Venue                 string                           `json:"venue" validate:"required"`
+	Type                  string                           `json:"type" validate:"oneof=LIVE HOLOGRAM_LIVE"`
+	Online                bool                             `json:"online" validate:"-"`
+	Location              *CreateLocationRequest           `json:"location" validate:"required"`
+	TotalTicketAllocation int64                            `json:"total_ticket_allocation"`
+	TicketAllocation      []CreateTicketAllocation         `json:"ticket_allocation" validate:"required,dive"`
+	OrderRuleDay          []int64                          `json:"order_rule_day" validate:"omitempty,dive,min=1,max=7"`
+	OrderRuleRangeDate    *CreateOrderRuleRangeDateRequest `json:"order_rule_range_date" validate:"omitempty"`
 }
 
 type CreateEventRequest struct {
@@ -41,19 +85,27 @@ type CreateEventRequest struct {
 		Email string `json:"email" validate:"email"`
 		Phone string `json:"phone" validate:"required"`
 	} `json:"promotors" validate:"required,dive"`
-	OnlineTicketPrice           float64             `json:"online_ticket_price" validate:"required"`
-	TotalOnlineTicketAllocation int64               `json:"total_online_ticket_allocation" validate:"required"`
-	Shows                       []CreateShowRequest `json:"shows" validate:"required,dive,required"`
-	ShowTime                    string              `json:"show_time" validate:"datetime=2006-01-02 15:04:05"`
-	OrderRuleDay                []int64             `json:"order_rule_day" validate:"omitempty,dive,min=1,max=7"`
-	OrderRuleRangeDate          struct {
-		StartDate string `json:"start_date" validate:"datetime=2006-01-02 15:04:05"`
-		EndDate   string `json:"end_date" validate:"datetime=2006-01-02 15:04:05"`
-	} `json:"order_rule_range_date" validate:"required"`
-	OrderRuleMaximumTicket int64 `json:"order_rule_maximum_ticket" validate:"-"`
+	OnlineTicketPrice           float64                         `json:"online_ticket_price" validate:"required"`
+	TotalOnlineTicketAllocation int64                           `json:"total_online_ticket_allocation" validate:"required"`
+	Shows                       []CreateShowRequest             `json:"shows" validate:"required,dive,required"`
+	ShowTime                    string                          `json:"show_time" validate:"datetime=2006-01-02 15:04:05"`
+	OrderRuleDay                []int64                         `json:"order_rule_day" validate:"omitempty,dive,min=1,max=7"`
+	OrderRuleRangeDate          CreateOrderRuleRangeDateRequest `json:"order_rule_range_date" validate:"required"`
+	OrderRuleMaximumTicket      int64                           `json:"order_rule_maximum_ticket" validate:"-"`
+	Timezone                    string                          `json:"timezone" validate:"omitempty,timezone"`
 }
 
+// ToEntityEvent parses the times of the request in the timezone of the event, an event without timezone takes the
+// given location.
 func (r CreateEventRequest) ToEntityEvent(location *time.Location, now time.Time) (Event, error) {
+	if r.Timezone != "" {
+		tz, err := time.LoadLocation(r.Timezone)
+		if err != nil {
+			return Event{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("invalid timezone '%s'", r.Timezone))
+		}
+		location = tz
+	}
+
 	event := Event{
 		ID:          util.GenerateTimestampWithPrefix("EVENT"),
 		Name:        r.Name,
@@ -61,7 +113,8 @@ func (r CreateEventRequest) ToEntityEvent(location *time.Location, now time.Time
 		Artists:     nil,
 		Shows:       nil,
 		Description: r.Description,
-		Status:      "ACTIVE",
+		Status:      StatusDraft,
+		Timezone:    location.String(),
 		OrderRules:  OrderRuleAggregation{},
 		CreatedAt:   now,
 		UpdatedAt:   now,
@@ -90,6 +143,8 @@ func (r CreateEventRequest) ToEntityEvent(location *time.Location, now time.Time
 	showTime, _ := time.ParseInLocation(time.DateTime, r.ShowTime, location)
 
 	shows := make([]Show, 0)
+	scopedRangeDates := make([]order.OrderRuleRangeDate, 0)
+	scopedDays := make([]order.OrderRuleDay, 0)
 	for _, v := range r.Shows {
 		liveShow := Show{
 			EventID: event.ID,
@@ -125,9 +180,20 @@ func (r CreateEventRequest) ToEntityEvent(location *time.Location, now time.Time
 				Acquired:        0,
 				LastStockUpdate: now,
 			}
+
+			ticketStockID := liveShowTicketStock[tark].ID
+			if tarv.OrderRuleRangeDate != nil {
+				scopedRangeDates = append(scopedRangeDates, tarv.OrderRuleRangeDate.toEntity(event.ID, &liveShow.ID, &ticketStockID, location))
+			}
+			scopedDays = append(scopedDays, newOrderRuleDays(event.ID, &liveShow.ID, &ticketStockID, tarv.OrderRuleDay)...)
 		}
 		liveShow.TicketStock = liveShowTicketStock
 
+		if v.OrderRuleRangeDate != nil {
+			scopedRangeDates = append(scopedRangeDates, v.OrderRuleRangeDate.toEntity(event.ID, &liveShow.ID, nil, location))
+		}
+		scopedDays = append(scopedDays, newOrderRuleDays(event.ID, &liveShow.ID, nil, v.OrderRuleDay)...)
+
 		shows = append(shows, liveShow)
 
 		if v.Online {
@@ -169,24 +235,85 @@ func (r CreateEventRequest) ToEntityEvent(location *time.Location, now time.Time
 
 	event.Shows = shows
 
-	ruleStartDate, _ := time.ParseInLocation(time.DateTime, r.OrderRuleRangeDate.StartDate, location)
-	ruleEndDate, _ := time.ParseInLocation(time.DateTime, r.OrderRuleRangeDate.EndDate, location)
-
-	orderRuleDay := make([]order.OrderRuleDay, len(r.OrderRuleDay))
-	for k, v := range r.OrderRuleDay {
-		orderRuleDay[k] = order.OrderRuleDay{
-			EventID: event.ID,
-			Day:     v,
-		}
-	}
 	event.OrderRules = OrderRuleAggregation{
-		OrderRuleRangeDate: order.OrderRuleRangeDate{
-			EventID:   event.ID,
-			StartDate: ruleStartDate,
-			EndDate:   ruleEndDate,
-		},
-		OrderRuleDay: orderRuleDay,
+		OrderRuleRangeDate:       r.OrderRuleRangeDate.toEntity(event.ID, nil, nil, location),
+		OrderRuleDay:             newOrderRuleDays(event.ID, nil, nil, r.OrderRuleDay),
+		ScopedOrderRuleRangeDate: scopedRangeDates,
+		ScopedOrderRuleDay:       scopedDays,
 	}
 
 	return event, nil
 }
+
+type GetManyEventRequest struct {
+	Page   int64  `validate:"required,min=1"`
+	Size   int64  `validate:"required,min=1,max=100"`
+	Search string `validate:"-"`
+	Status string `validate:"omitempty,oneof=DRAFT ACTIVE CLOSED CANCELLED"`
+}
+
+type UpdatePromotorRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"email"`
+	Phone string `json:"phone" validate:"required"`
+}
+
+type UpdateShowRequest struct {
+	ID       string                 `json:"id" validate:"required"`
+	Venue    *string                `json:"venue" validate:"omitempty,min=1"`
+	Time     *string                `json:"time" validate:"omitempty,datetime=2006-01-02 15:04:05"`
+	Status   *string                `json:"status" validate:"omitempty,oneof=ACTIVE CANCELLED"`
+	Location *CreateLocationRequest `json:"location" validate:"omitempty"`
+}
+
+type UpdateOrderRuleRangeDateRequest struct {
+	StartDate string `json:"start_date" validate:"datetime=2006-01-02 15:04:05"`
+	EndDate   string `json:"end_date" validate:"datetime=2006-01-02 15:04:05"`
+}
+
+// UpdateEventRequest only changes the fields that are given. Artists, promotors and order rule days are
+// replaced as a whole.
+type UpdateEventRequest struct {
+	ID                       string                           `json:"-" validate:"required"`
+	Name                     *string                          `json:"name" validate:"omitempty,min=1"`
+	Description              *string                          `json:"description" validate:"omitempty,min=1"`
+	Artists                  []string                         `json:"artists" validate:"omitempty,dive,required"`
+	Promotors                []UpdatePromotorRequest          `json:"promotors" validate:"omitempty,dive"`
+	Shows                    []UpdateShowRequest              `json:"shows" validate:"omitempty,dive"`
+	OrderRuleDay             []int64                          `json:"order_rule_day" validate:"omitempty,dive,min=1,max=7"`
+	OrderRuleRangeDate       *UpdateOrderRuleRangeDateRequest `json:"order_rule_range_date" validate:"omitempty"`
+	Timezone                 *string                          `json:"timezone" validate:"omitempty,timezone"`
+	AcknowledgeSoldInventory bool                             `json:"acknowledge_sold_inventory"`
+}
+
+type UpdateEventStatusRequest struct {
+	ID                       string `json:"-" validate:"required"`
+	Status                   string `json:"status" validate:"oneof=ACTIVE CLOSED CANCELLED"`
+	AcknowledgeSoldInventory bool   `json:"acknowledge_sold_inventory"`
+}
+
+type GetEventSalesRequest struct {
+	EventID  string `validate:"required"`
+	Interval string `validate:"omitempty,oneof=hour day"`
+	From     string `validate:"omitempty,datetime=2006-01-02 15:04:05"`
+	To       string `validate:"omitempty,datetime=2006-01-02 15:04:05"`
+}
+
+type ImportEventRequest struct {
+	Format string    `validate:"required,oneof=yaml csv"`
+	DryRun bool      `validate:"-"`
+	File   io.Reader `validate:"required"`
+}
+
+type AttachOrderRuleRequest struct {
+	EventID                  string          `json:"-" validate:"required"`
+	Type                     string          `json:"type" validate:"required"`
+	Config                   json.RawMessage `json:"config" validate:"-"`
+	AcknowledgeSoldInventory bool            `json:"acknowledge_sold_inventory"`
+}
+
+type DetachOrderRuleRequest struct {
+	EventID                  string `validate:"required"`
+	ID                       int64  `validate:"required"`
+	AcknowledgeSoldInventory bool
+}
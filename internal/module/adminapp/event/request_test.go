@@ -0,0 +1,54 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/event"
+)
+
+func TestCreateEventRequestToEntityEvent(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	newRequest := func(timezone string) event.CreateEventRequest {
+		req := event.CreateEventRequest{
+			Name:     "Concert",
+			ShowTime: "2026-07-01 20:00:00",
+			Shows:    []event.CreateShowRequest{{Venue: "Stadium", Type: event.ShowTypeLive, Location: &event.CreateLocationRequest{}}},
+			Timezone: timezone,
+		}
+		req.OrderRuleRangeDate.StartDate = "2026-01-10 09:00:00"
+		req.OrderRuleRangeDate.EndDate = "2026-06-30 23:59:59"
+
+		return req
+	}
+
+	t.Run("times are parsed in the timezone of the event across daylight saving time", func(t *testing.T) {
+		e, err := newRequest("America/New_York").ToEntityEvent(jakarta, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, "America/New_York", e.Timezone)
+		// summer time, UTC-4.
+		assert.Equal(t, time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC), e.Shows[0].Time.UTC())
+		// standard time, UTC-5.
+		assert.Equal(t, time.Date(2026, 1, 10, 14, 0, 0, 0, time.UTC), e.OrderRules.OrderRuleRangeDate.StartDate.UTC())
+		assert.Equal(t, time.Date(2026, 7, 1, 3, 59, 59, 0, time.UTC), e.OrderRules.OrderRuleRangeDate.EndDate.UTC())
+	})
+
+	t.Run("event without timezone takes the given location", func(t *testing.T) {
+		e, err := newRequest("").ToEntityEvent(jakarta, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Jakarta", e.Timezone)
+		assert.Equal(t, time.Date(2026, 7, 1, 13, 0, 0, 0, time.UTC), e.Shows[0].Time.UTC())
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		_, err := newRequest("Asia/Atlantis").ToEntityEvent(jakarta, time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestEventLocation(t *testing.T) {
+	assert.Equal(t, "Asia/Jakarta", event.Event{Timezone: "Asia/Jakarta"}.Location().String())
+	assert.Equal(t, time.UTC, event.Event{Timezone: "Asia/Atlantis"}.Location())
+}
@@ -1,6 +1,11 @@
 package event
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+)
 
 type PromotorResponse struct {
 	Name  string `json:"name"`
@@ -26,22 +31,26 @@ type ShowResponse struct {
 }
 
 type CreateEventResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	Promotors   []PromotorResponse
-	Artists     []string `json:"artists"`
-	Shows       []ShowResponse
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Status      string             `json:"status"`
+	Timezone    string             `json:"timezone"`
+	Promotors   []PromotorResponse `json:"promotors"`
+	Artists     []string           `json:"artists"`
+	Shows       []ShowResponse     `json:"shows"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
 }
 
 func (r *CreateEventResponse) PopulateFromEntity(e Event) {
+	location := e.Location()
+
 	r.ID = e.ID
 	r.Name = e.Name
 	r.Description = e.Description
 	r.Status = e.Status
+	r.Timezone = e.Timezone
 
 	for _, v := range e.Promotors {
 		r.Promotors = append(r.Promotors, PromotorResponse{
@@ -56,9 +65,9 @@ func (r *CreateEventResponse) PopulateFromEntity(e Event) {
 	}
 
 	for _, v := range e.Shows {
-		var location *LocationResponse
+		var showLocation *LocationResponse
 		if v.Location != nil {
-			location = &LocationResponse{
+			showLocation = &LocationResponse{
 				Country:          v.Location.Country,
 				City:             v.Location.City,
 				FormattedAddress: v.Location.FormattedAddress,
@@ -70,12 +79,362 @@ func (r *CreateEventResponse) PopulateFromEntity(e Event) {
 			ID:       v.ID,
 			Venue:    v.Venue,
 			Type:     v.Type,
-			Time:     v.Time,
+			Time:     v.Time.In(location),
 			Status:   v.Status,
-			Location: location,
+			Location: showLocation,
 		})
 	}
 
 	r.CreatedAt = e.CreatedAt
 	r.UpdatedAt = e.UpdatedAt
 }
+
+type TicketStockResponse struct {
+	ID              string    `json:"id"`
+	OnlineFor       *string   `json:"online_for"`
+	Tier            string    `json:"tier"`
+	Allocation      int64     `json:"allocation"`
+	Price           float64   `json:"price"`
+	Acquired        int64     `json:"acquired"`
+	LastStockUpdate time.Time `json:"last_stock_update"`
+}
+
+type EventShowResponse struct {
+	ID          string                `json:"id"`
+	Venue       string                `json:"venue"`
+	Type        string                `json:"type"`
+	Location    *LocationResponse     `json:"location"`
+	Time        time.Time             `json:"time"`
+	Status      string                `json:"status"`
+	TicketStock []TicketStockResponse `json:"ticket_stock"`
+}
+
+type OrderRuleRangeDateResponse struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// ScopedOrderRulesResponse holds the rules of a show, or of a ticket stock if the ticket stock id is given.
+type ScopedOrderRulesResponse struct {
+	ShowID             *string                     `json:"show_id"`
+	TicketStockID      *string                     `json:"ticket_stock_id"`
+	OrderRuleRangeDate *OrderRuleRangeDateResponse `json:"order_rule_range_date"`
+	OrderRuleDay       []int64                     `json:"order_rule_day"`
+}
+
+type OrderRulesResponse struct {
+	OrderRuleRangeDate *OrderRuleRangeDateResponse `json:"order_rule_range_date"`
+	OrderRuleDay       []int64                     `json:"order_rule_day"`
+	Scoped             []ScopedOrderRulesResponse  `json:"scoped"`
+}
+
+func orderRuleScopeKey(showID, ticketStockID *string) string {
+	var key string
+	if showID != nil {
+		key = *showID
+	}
+	if ticketStockID != nil {
+		key = key + "/" + *ticketStockID
+	}
+
+	return key
+}
+
+// populateScoped groups the rules of the shows and ticket stocks by their scope.
+func (r *OrderRulesResponse) populateScoped(rules OrderRuleAggregation, location *time.Location) {
+	r.Scoped = make([]ScopedOrderRulesResponse, 0)
+	indexByScope := make(map[string]int)
+
+	scoped := func(showID, ticketStockID *string) *ScopedOrderRulesResponse {
+		key := orderRuleScopeKey(showID, ticketStockID)
+		k, ok := indexByScope[key]
+		if !ok {
+			k = len(r.Scoped)
+			indexByScope[key] = k
+			r.Scoped = append(r.Scoped, ScopedOrderRulesResponse{
+				ShowID:        showID,
+				TicketStockID: ticketStockID,
+				OrderRuleDay:  make([]int64, 0),
+			})
+		}
+
+		return &r.Scoped[k]
+	}
+
+	for _, v := range rules.ScopedOrderRuleRangeDate {
+		scoped(v.ShowID, v.TicketStockID).OrderRuleRangeDate = &OrderRuleRangeDateResponse{
+			StartDate: v.StartDate.In(location),
+			EndDate:   v.EndDate.In(location),
+		}
+	}
+
+	for _, v := range rules.ScopedOrderRuleDay {
+		s := scoped(v.ShowID, v.TicketStockID)
+		s.OrderRuleDay = append(s.OrderRuleDay, v.Day)
+	}
+}
+
+type EventResponse struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Status      string              `json:"status"`
+	Timezone    string              `json:"timezone"`
+	Promotors   []PromotorResponse  `json:"promotors"`
+	Artists     []string            `json:"artists"`
+	Shows       []EventShowResponse `json:"shows"`
+	OrderRules  OrderRulesResponse  `json:"order_rules"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+func (r *EventResponse) PopulateFromEntity(e Event) {
+	location := e.Location()
+
+	r.ID = e.ID
+	r.Name = e.Name
+	r.Description = e.Description
+	r.Status = e.Status
+	r.Timezone = e.Timezone
+	r.CreatedAt = e.CreatedAt
+	r.UpdatedAt = e.UpdatedAt
+
+	r.Promotors = make([]PromotorResponse, len(e.Promotors))
+	for k, v := range e.Promotors {
+		r.Promotors[k] = PromotorResponse{
+			Name:  v.Name,
+			Email: v.Email,
+			Phone: v.Phone,
+		}
+	}
+
+	r.Artists = make([]string, len(e.Artists))
+	for k, v := range e.Artists {
+		r.Artists[k] = v.Name
+	}
+
+	r.Shows = make([]EventShowResponse, len(e.Shows))
+	for k, v := range e.Shows {
+		var showLocation *LocationResponse
+		if v.Location != nil {
+			showLocation = &LocationResponse{
+				Country:          v.Location.Country,
+				City:             v.Location.City,
+				FormattedAddress: v.Location.FormattedAddress,
+				Latitude:         v.Location.Latitude,
+				Longitude:        v.Location.Longitude,
+			}
+		}
+
+		ticketStock := make([]TicketStockResponse, len(v.TicketStock))
+		for tk, tv := range v.TicketStock {
+			ticketStock[tk] = TicketStockResponse{
+				ID:              tv.ID,
+				OnlineFor:       tv.OnlineFor,
+				Tier:            tv.Tier,
+				Allocation:      tv.Allocation,
+				Price:           tv.Price,
+				Acquired:        tv.Acquired,
+				LastStockUpdate: tv.LastStockUpdate,
+			}
+		}
+
+		r.Shows[k] = EventShowResponse{
+			ID:          v.ID,
+			Venue:       v.Venue,
+			Type:        v.Type,
+			Location:    showLocation,
+			Time:        v.Time.In(location),
+			Status:      v.Status,
+			TicketStock: ticketStock,
+		}
+	}
+
+	if !e.OrderRules.OrderRuleRangeDate.StartDate.IsZero() {
+		r.OrderRules.OrderRuleRangeDate = &OrderRuleRangeDateResponse{
+			StartDate: e.OrderRules.OrderRuleRangeDate.StartDate.In(location),
+			EndDate:   e.OrderRules.OrderRuleRangeDate.EndDate.In(location),
+		}
+	}
+
+	r.OrderRules.OrderRuleDay = make([]int64, len(e.OrderRules.OrderRuleDay))
+	for k, v := range e.OrderRules.OrderRuleDay {
+		r.OrderRules.OrderRuleDay[k] = v.Day
+	}
+
+	r.OrderRules.populateScoped(e.OrderRules, location)
+}
+
+type EventSummaryResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (r *EventSummaryResponse) PopulateFromEntity(e Event) {
+	r.ID = e.ID
+	r.Name = e.Name
+	r.Description = e.Description
+	r.Status = e.Status
+	r.Timezone = e.Timezone
+	r.CreatedAt = e.CreatedAt
+	r.UpdatedAt = e.UpdatedAt
+}
+
+type GetManyEventResponse struct {
+	Total  int64                  `json:"total"`
+	Events []EventSummaryResponse `json:"events"`
+}
+
+type TicketStockSalesResponse struct {
+	TicketStockID string  `json:"ticket_stock_id"`
+	ShowID        string  `json:"show_id"`
+	Tier          string  `json:"tier"`
+	Price         float64 `json:"price"`
+	Allocation    int64   `json:"allocation"`
+	Acquired      int64   `json:"acquired"`
+	Pending       int64   `json:"pending"`
+	Remaining     int64   `json:"remaining"`
+}
+
+// RevenueResponse is the revenue of the paid orders. Subtotal is the price of the tickets before the discount, the tax
+// and the service charge, TotalBilled is what the customers were billed for the orders with all of them applied.
+type RevenueResponse struct {
+	Subtotal      float64 `json:"subtotal"`
+	Tax           float64 `json:"tax"`
+	ServiceCharge float64 `json:"service_charge"`
+	Discount      float64 `json:"discount"`
+	TotalBilled   float64 `json:"total_billed"`
+}
+
+type ConversionResponse struct {
+	Placed            int64   `json:"placed"`
+	Paid              int64   `json:"paid"`
+	Expired           int64   `json:"expired"`
+	WaitingForPayment int64   `json:"waiting_for_payment"`
+	PaidRate          float64 `json:"paid_rate"`
+}
+
+type SalesBucketResponse struct {
+	Time         time.Time `json:"time"`
+	PlacedOrders int64     `json:"placed_orders"`
+	PaidOrders   int64     `json:"paid_orders"`
+	TicketsSold  int64     `json:"tickets_sold"`
+	Revenue      float64   `json:"revenue"`
+}
+
+type TimeSeriesResponse struct {
+	Interval string                `json:"interval"`
+	From     time.Time             `json:"from"`
+	To       time.Time             `json:"to"`
+	Buckets  []SalesBucketResponse `json:"buckets"`
+}
+
+type EventSalesResponse struct {
+	EventID      string                     `json:"event_id"`
+	TicketStocks []TicketStockSalesResponse `json:"ticket_stocks"`
+	Revenue      RevenueResponse            `json:"revenue"`
+	Conversion   ConversionResponse         `json:"conversion"`
+	TimeSeries   TimeSeriesResponse         `json:"time_series"`
+}
+
+func (r *EventSalesResponse) PopulateFromEntity(eventID string, stocks []TicketStockSales, summary SalesSummary, filter SalesFilter, buckets []SalesBucket, location *time.Location) {
+	r.EventID = eventID
+
+	r.TicketStocks = make([]TicketStockSalesResponse, len(stocks))
+	for k, v := range stocks {
+		r.TicketStocks[k] = TicketStockSalesResponse{
+			TicketStockID: v.TicketStockID,
+			ShowID:        v.ShowID,
+			Tier:          v.Tier,
+			Price:         v.Price,
+			Allocation:    v.Allocation,
+			Acquired:      v.Acquired,
+			Pending:       v.Pending,
+			Remaining:     v.Remaining(),
+		}
+	}
+
+	r.Revenue = RevenueResponse{
+		Subtotal:      summary.Subtotal,
+		Tax:           summary.Tax,
+		ServiceCharge: summary.ServiceCharge,
+		Discount:      summary.Discount,
+		TotalBilled:   summary.TotalBilled,
+	}
+
+	r.Conversion = ConversionResponse{
+		Placed:            summary.PlacedOrders,
+		Paid:              summary.PaidOrders,
+		Expired:           summary.ExpiredOrders,
+		WaitingForPayment: summary.WaitingForPaymentOrders,
+	}
+	if summary.PlacedOrders > 0 {
+		r.Conversion.PaidRate = float64(summary.PaidOrders) / float64(summary.PlacedOrders)
+	}
+
+	r.TimeSeries = TimeSeriesResponse{
+		Interval: filter.Interval,
+		From:     filter.From.In(location),
+		To:       filter.To.In(location),
+		Buckets:  make([]SalesBucketResponse, len(buckets)),
+	}
+	for k, v := range buckets {
+		r.TimeSeries.Buckets[k] = SalesBucketResponse{
+			Time:         v.Time.In(location),
+			PlacedOrders: v.PlacedOrders,
+			PaidOrders:   v.PaidOrders,
+			TicketsSold:  v.TicketsSold,
+			Revenue:      v.Revenue,
+		}
+	}
+}
+
+type ImportedEventResponse struct {
+	Row          int    `json:"row"`
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Shows        int    `json:"shows"`
+	TicketStocks int    `json:"ticket_stocks"`
+}
+
+func (r *ImportedEventResponse) PopulateFromEntity(row int, e Event) {
+	r.Row = row
+	r.ID = e.ID
+	r.Name = e.Name
+	r.Shows = len(e.Shows)
+	for _, s := range e.Shows {
+		r.TicketStocks += len(s.TicketStock)
+	}
+}
+
+type ImportEventResponse struct {
+	DryRun bool                    `json:"dry_run"`
+	Total  int                     `json:"total"`
+	Events []ImportedEventResponse `json:"events"`
+	Errors []ImportError           `json:"errors"`
+}
+
+type OrderRuleResponse struct {
+	ID        int64           `json:"id"`
+	EventID   string          `json:"event_id"`
+	Type      string          `json:"type"`
+	Config    json.RawMessage `json:"config"`
+	Position  int64           `json:"position"`
+	CreatedBy int64           `json:"created_by"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func (r *OrderRuleResponse) PopulateFromEntity(rule order.OrderRule) {
+	r.ID = rule.ID
+	r.EventID = rule.EventID
+	r.Type = rule.Type
+	r.Config = rule.Config
+	r.Position = rule.Position
+	r.CreatedBy = rule.CreatedBy
+	r.CreatedAt = rule.CreatedAt
+}
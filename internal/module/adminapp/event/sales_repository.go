@@ -0,0 +1,236 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+// SalesRepository aggregates the sales of an event from the orders and the ticket stocks.
+type SalesRepository interface {
+	FindManyTicketStockSales(ctx context.Context, eventID string, tx *sql.Tx) ([]TicketStockSales, error)
+	GetSummary(ctx context.Context, eventID string, tx *sql.Tx) (SalesSummary, error)
+	CountSoldOrders(ctx context.Context, eventID string, tx *sql.Tx) (SoldOrders, error)
+	FindManyBucket(ctx context.Context, eventID string, filter SalesFilter, location string, tx *sql.Tx) ([]SalesBucket, error)
+}
+
+type salesRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewSalesRepository(logger *logrus.Logger, db *sql.DB) SalesRepository {
+	return &salesRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// FindManyTicketStockSales implements SalesRepository.
+func (r *salesRepository) FindManyTicketStockSales(ctx context.Context, eventID string, tx *sql.Tx) ([]TicketStockSales, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			ts.id, ts.show_id, ts.tier, ts.price, ts.allocation, ts.acquired,
+			COALESCE(SUM(oi.quantity) FILTER (WHERE o.status = 'WAITING_FOR_PAYMENT'), 0)
+		FROM ticket_stock ts
+		LEFT JOIN order_item oi ON oi.ticket_stock_id = ts.id
+		LEFT JOIN ticket_order o ON o.id = oi.order_id
+		WHERE
+			ts.event_id = $1
+		GROUP BY ts.id, ts.show_id, ts.tier, ts.price, ts.allocation, ts.acquired
+		ORDER BY ts.show_id, ts.tier
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting ticket stock sales")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting ticket stock sales")
+	}
+	defer rows.Close()
+
+	var data = make([]TicketStockSales, 0)
+	for rows.Next() {
+		var s TicketStockSales
+		if err := rows.Scan(&s.TicketStockID, &s.ShowID, &s.Tier, &s.Price, &s.Allocation, &s.Acquired, &s.Pending); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting ticket stock sales")
+		}
+
+		data = append(data, s)
+	}
+
+	return data, nil
+}
+
+// CountSoldOrders implements SalesRepository. The total is the grouping set without a show.
+func (r *salesRepository) CountSoldOrders(ctx context.Context, eventID string, tx *sql.Tx) (SoldOrders, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			oi.show_id, COUNT(DISTINCT o.id)
+		FROM order_item oi
+		INNER JOIN ticket_order o ON o.id = oi.order_id
+		WHERE
+			oi.event_id = $1
+			AND o.status IN ('WAITING_FOR_PAYMENT', 'PAID')
+		GROUP BY GROUPING SETS ((oi.show_id), ())
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return SoldOrders{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting sold orders")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return SoldOrders{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting sold orders")
+	}
+	defer rows.Close()
+
+	data := SoldOrders{ByShow: make(map[string]int64)}
+	for rows.Next() {
+		var showID sql.NullString
+		var count int64
+		if err := rows.Scan(&showID, &count); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return SoldOrders{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting sold orders")
+		}
+
+		if !showID.Valid {
+			data.Total = count
+			continue
+		}
+		data.ByShow[showID.String] = count
+	}
+
+	return data, nil
+}
+
+// GetSummary implements SalesRepository.
+func (r *salesRepository) GetSummary(ctx context.Context, eventID string, tx *sql.Tx) (SalesSummary, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			COUNT(o.id),
+			COUNT(o.id) FILTER (WHERE o.status = 'PAID'),
+			COUNT(o.id) FILTER (WHERE o.status = 'EXPIRED'),
+			COUNT(o.id) FILTER (WHERE o.status = 'WAITING_FOR_PAYMENT'),
+			COALESCE(SUM(o.subtotal) FILTER (WHERE o.status = 'PAID'), 0),
+			COALESCE(SUM(o.tax) FILTER (WHERE o.status = 'PAID'), 0),
+			COALESCE(SUM(o.service_charge) FILTER (WHERE o.status = 'PAID'), 0),
+			COALESCE(SUM(o.discount) FILTER (WHERE o.status = 'PAID'), 0),
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'PAID'), 0)
+		FROM ticket_order o
+		WHERE
+			EXISTS (SELECT 1 FROM order_item oi WHERE oi.order_id = o.id AND oi.event_id = $1)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return SalesSummary{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting sales summary")
+	}
+	defer stmt.Close()
+
+	var data SalesSummary
+	err = stmt.QueryRowContext(ctx, eventID).Scan(
+		&data.PlacedOrders, &data.PaidOrders, &data.ExpiredOrders, &data.WaitingForPaymentOrders,
+		&data.Subtotal, &data.Tax, &data.ServiceCharge, &data.Discount, &data.TotalBilled,
+	)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return SalesSummary{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting sales summary")
+	}
+
+	return data, nil
+}
+
+// FindManyBucket implements SalesRepository. The buckets are truncated in the given IANA time zone, so daily
+// buckets start at the local midnight.
+func (r *salesRepository) FindManyBucket(ctx context.Context, eventID string, filter SalesFilter, location string, tx *sql.Tx) ([]SalesBucket, error) {
+	// the interval is a unit of date_trunc, anything else never reaches the database.
+	if !salesIntervals[filter.Interval] {
+		return nil, errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("unknown sales interval '%s'", filter.Interval))
+	}
+
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			date_trunc($2, o.created_at, $3) AS bucket,
+			COUNT(o.id),
+			COUNT(o.id) FILTER (WHERE o.status = 'PAID'),
+			COALESCE(SUM(oi.quantity) FILTER (WHERE o.status = 'PAID'), 0),
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'PAID'), 0)
+		FROM ticket_order o
+		JOIN (
+			SELECT order_id, SUM(quantity) AS quantity FROM order_item WHERE event_id = $1 GROUP BY order_id
+		) oi ON oi.order_id = o.id
+		WHERE
+			o.created_at >= $4 AND o.created_at < $5
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting sales time series")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID, filter.Interval, location, filter.From, filter.To)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting sales time series")
+	}
+	defer rows.Close()
+
+	var data = make([]SalesBucket, 0)
+	for rows.Next() {
+		var b SalesBucket
+		if err := rows.Scan(&b.Time, &b.PlacedOrders, &b.PaidOrders, &b.TicketsSold, &b.Revenue); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting sales time series")
+		}
+
+		data = append(data, b)
+	}
+
+	return data, nil
+}
@@ -178,7 +178,7 @@ func (r *showRepository) Update(ctx context.Context, ID string, s Show, tx *sql.
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, s.Venue, s.Type, s.Time, s.Status)
+	_, err = stmt.ExecContext(ctx, s.Venue, s.Type, s.Time, s.Status, ID)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating event show's prorperties")
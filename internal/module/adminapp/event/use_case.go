@@ -3,21 +3,42 @@ package event
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+	"golang.org/x/sync/errgroup"
 )
 
 type EventUseCase interface {
-	CreateEvent(ctx context.Context, req CreateEventRequest) (interface{}, error)
+	CreateEvent(ctx context.Context, req CreateEventRequest) (CreateEventResponse, error)
+	GetManyEvent(ctx context.Context, req GetManyEventRequest) (GetManyEventResponse, error)
+	GetEvent(ctx context.Context, ID string) (EventResponse, error)
+	UpdateEvent(ctx context.Context, req UpdateEventRequest) (EventResponse, error)
+	UpdateEventStatus(ctx context.Context, req UpdateEventStatusRequest) (EventResponse, error)
+	DeleteEvent(ctx context.Context, ID string) error
+	GetEventSales(ctx context.Context, req GetEventSalesRequest) (EventSalesResponse, error)
+	ImportEvents(ctx context.Context, req ImportEventRequest) (ImportEventResponse, error)
+	GetManyOrderRule(ctx context.Context, eventID string) ([]OrderRuleResponse, error)
+	AttachOrderRule(ctx context.Context, req AttachOrderRuleRequest) (OrderRuleResponse, error)
+	DetachOrderRule(ctx context.Context, req DetachOrderRuleRequest) error
 }
 
 type eventUseCase struct {
 	logger                       *logrus.Logger
 	location                     *time.Location
 	timeout                      time.Duration
+	validate                     *validator.Validate
 	eventRepository              EventRepository
 	artistRepository             ArtistRepository
 	promotorRepository           PromotorRepository
@@ -25,13 +46,17 @@ type eventUseCase struct {
 	locationRepository           LocationRepository
 	orderRuleDayRepository       order.OrderRuleDayRepository
 	orderRuleRangeDateRepository order.OrderRuleRangeDateRepository
+	orderRuleRepository          order.OrderRuleRepository
+	orderRuleRegistry            *orderrule.Registry
 	ticketStockRepository        ticket.TicketStockRepository
+	salesRepository              SalesRepository
 }
 
 type EventUseCaseProperty struct {
 	Logger                       *logrus.Logger
 	Location                     *time.Location
 	Timeout                      time.Duration
+	Validate                     *validator.Validate
 	EventRepository              EventRepository
 	ArtistRepository             ArtistRepository
 	PromotorRepository           PromotorRepository
@@ -39,7 +64,10 @@ type EventUseCaseProperty struct {
 	LocationRepository           LocationRepository
 	OrderRuleDayRepository       order.OrderRuleDayRepository
 	OrderRuleRangeDateRepository order.OrderRuleRangeDateRepository
+	OrderRuleRepository          order.OrderRuleRepository
+	OrderRuleRegistry            *orderrule.Registry
 	TicketStockRepository        ticket.TicketStockRepository
+	SalesRepository              SalesRepository
 }
 
 func NewEventUseCase(props EventUseCaseProperty) EventUseCase {
@@ -47,6 +75,7 @@ func NewEventUseCase(props EventUseCaseProperty) EventUseCase {
 		logger:                       props.Logger,
 		location:                     props.Location,
 		timeout:                      props.Timeout,
+		validate:                     props.Validate,
 		eventRepository:              props.EventRepository,
 		artistRepository:             props.ArtistRepository,
 		promotorRepository:           props.PromotorRepository,
@@ -54,7 +83,10 @@ func NewEventUseCase(props EventUseCaseProperty) EventUseCase {
 		locationRepository:           props.LocationRepository,
 		orderRuleDayRepository:       props.OrderRuleDayRepository,
 		orderRuleRangeDateRepository: props.OrderRuleRangeDateRepository,
+		orderRuleRepository:          props.OrderRuleRepository,
+		orderRuleRegistry:            props.OrderRuleRegistry,
 		ticketStockRepository:        props.TicketStockRepository,
+		salesRepository:              props.SalesRepository,
 	}
 }
 
@@ -109,58 +141,892 @@ func (u *eventUseCase) createRules(ctx context.Context, e Event, tx *sql.Tx) err
 		}
 	}
 
+	for _, v := range e.OrderRules.ScopedOrderRuleRangeDate {
+		if err := u.orderRuleRangeDateRepository.Save(ctx, v, tx); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range e.OrderRules.ScopedOrderRuleDay {
+		if err := u.orderRuleDayRepository.Save(ctx, v, tx); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// createEvent saves the event with its artists, promotors, shows and order rules in the transaction.
+func (u *eventUseCase) createEvent(ctx context.Context, e Event, tx *sql.Tx) error {
+	if err := u.eventRepository.Save(ctx, e, tx); err != nil {
+		return err
+	}
+
+	if err := u.createArtists(ctx, e, tx); err != nil {
+		return err
+	}
+
+	if err := u.createPromotors(ctx, e, tx); err != nil {
+		return err
+	}
+
+	if err := u.createShows(ctx, e, tx); err != nil {
+		return err
+	}
+
+	return u.createRules(ctx, e, tx)
+}
+
 // CreateEvent implements EventUseCase.
-func (u *eventUseCase) CreateEvent(ctx context.Context, req CreateEventRequest) (interface{}, error) {
+func (u *eventUseCase) CreateEvent(ctx context.Context, req CreateEventRequest) (CreateEventResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
 	now := time.Now()
 	e, err := req.ToEntityEvent(u.location, now)
 	if err != nil {
-		return nil, err
+		return CreateEventResponse{}, err
 	}
 
 	tx, err := u.eventRepository.BeginTx(ctx)
 	if err != nil {
-		tx.Rollback()
-		return nil, err
+		return CreateEventResponse{}, err
 	}
 
-	if err := u.eventRepository.Save(ctx, e, tx); err != nil {
-		tx.Rollback()
-		return nil, err
+	if err := u.createEvent(ctx, e, tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return CreateEventResponse{}, err
 	}
 
-	if err := u.createArtists(ctx, e, tx); err != nil {
-		tx.Rollback()
-		return nil, err
+	if err := u.eventRepository.CommitTx(ctx, tx); err != nil {
+		return CreateEventResponse{}, err
 	}
 
-	if err := u.createPromotors(ctx, e, tx); err != nil {
-		tx.Rollback()
-		return nil, err
+	resp := CreateEventResponse{}
+	resp.PopulateFromEntity(e)
+
+	return resp, nil
+}
+
+// validateImportedEvent reports every invalid field of the event as an error of its row.
+func (u *eventUseCase) validateImportedEvent(ctx context.Context, v ImportedEvent) []ImportError {
+	err := u.validate.StructCtx(ctx, v.Request)
+	if err == nil {
+		return nil
 	}
 
-	if err := u.createShows(ctx, e, tx); err != nil {
-		tx.Rollback()
-		return nil, err
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []ImportError{{Row: v.Row, Message: err.Error()}}
+	}
+
+	importErrors := make([]ImportError, len(fieldErrors))
+	for k, fe := range fieldErrors {
+		field := fe.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+
+		importErrors[k] = ImportError{
+			Row:     v.Row,
+			Field:   field,
+			Message: fmt.Sprintf("invalid value '%v' for rule '%s'", fe.Value(), fe.Tag()),
+		}
+	}
+
+	return importErrors
+}
+
+// ImportEvents implements EventUseCase. Every event of the file is validated before any of them is created, the events
+// are only created, in one transaction, if the whole file is valid.
+func (u *eventUseCase) ImportEvents(ctx context.Context, req ImportEventRequest) (ImportEventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	imported, importErrors := ParseImport(req.Format, req.File)
+
+	resp := ImportEventResponse{
+		DryRun: req.DryRun,
+		Total:  len(imported),
+		Events: make([]ImportedEventResponse, 0, len(imported)),
+		Errors: append(make([]ImportError, 0), importErrors...),
+	}
+
+	now := time.Now()
+	events := make([]Event, 0, len(imported))
+	rowByName := make(map[string]int, len(imported))
+	for _, v := range imported {
+		if row, ok := rowByName[v.Request.Name]; ok && v.Request.Name != "" {
+			resp.Errors = append(resp.Errors, ImportError{Row: v.Row, Field: "Name", Message: fmt.Sprintf("event is already defined at row %d", row)})
+			continue
+		}
+		rowByName[v.Request.Name] = v.Row
+
+		if fieldErrors := u.validateImportedEvent(ctx, v); len(fieldErrors) > 0 {
+			resp.Errors = append(resp.Errors, fieldErrors...)
+			continue
+		}
+
+		e, err := v.Request.ToEntityEvent(u.location, now)
+		if err != nil {
+			resp.Errors = append(resp.Errors, ImportError{Row: v.Row, Message: errors.Destruct(err).Message})
+			continue
+		}
+		events = append(events, e)
+
+		r := ImportedEventResponse{}
+		r.PopulateFromEntity(v.Row, e)
+		resp.Events = append(resp.Events, r)
+	}
+
+	if len(resp.Errors) > 0 || req.DryRun {
+		// Nothing is created, so there is no id to refer to.
+		for k := range resp.Events {
+			resp.Events[k].ID = ""
+		}
+
+		return resp, nil
+	}
+
+	tx, err := u.eventRepository.BeginTx(ctx)
+	if err != nil {
+		return ImportEventResponse{}, err
+	}
+
+	for _, e := range events {
+		if err := u.createEvent(ctx, e, tx); err != nil {
+			u.eventRepository.Rollback(ctx, tx)
+			return ImportEventResponse{}, err
+		}
+	}
+
+	if err := u.eventRepository.CommitTx(ctx, tx); err != nil {
+		return ImportEventResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// loadShows fills the shows of the event with their location and ticket stocks.
+func (u *eventUseCase) loadShows(ctx context.Context, e *Event, tx *sql.Tx) error {
+	shows, err := u.showRepository.FindManyByEventID(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range shows {
+		location, err := u.locationRepository.FindByShowID(ctx, v.ID, tx)
+		if err != nil && !errors.MatchStatus(err, status.NOT_FOUND) {
+			return err
+		}
+		if err == nil {
+			shows[k].Location = &location
+		}
+
+		ticketStock, err := u.ticketStockRepository.FindManyByShowID(ctx, v.ID, tx)
+		if err != nil {
+			return err
+		}
+		shows[k].TicketStock = ticketStock
+	}
+
+	e.Shows = shows
+
+	return nil
+}
+
+// loadAggregate fills the event with its artists, promotors, shows and order rules.
+func (u *eventUseCase) loadAggregate(ctx context.Context, e *Event, tx *sql.Tx) error {
+	artists, err := u.artistRepository.FindManyByEventID(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+	e.Artists = artists
+
+	promotors, err := u.promotorRepository.FindManyByEventID(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+	e.Promotors = promotors
+
+	if err := u.loadShows(ctx, e, tx); err != nil {
+		return err
+	}
+
+	rangeDate, err := u.orderRuleRangeDateRepository.FindByEventID(ctx, e.ID, tx)
+	if err != nil && !errors.MatchStatus(err, status.NOT_FOUND) {
+		return err
+	}
+	e.OrderRules.OrderRuleRangeDate = rangeDate
+
+	days, err := u.orderRuleDayRepository.FindManyByEventID(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.OrderRuleDay = days
+
+	scopedRangeDates, err := u.orderRuleRangeDateRepository.FindManyScopedByEventID(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.ScopedOrderRuleRangeDate = scopedRangeDates
+
+	scopedDays, err := u.orderRuleDayRepository.FindManyScopedByEventID(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.ScopedOrderRuleDay = scopedDays
+
+	return nil
+}
+
+// GetManyEvent implements EventUseCase.
+func (u *eventUseCase) GetManyEvent(ctx context.Context, req GetManyEventRequest) (GetManyEventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	offset := (req.Page - 1) * req.Size
+	limit := req.Size
+	filter := EventFilter{
+		Search: req.Search,
+		Status: req.Status,
+	}
+
+	var events []Event
+	var total int64
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		count, err := u.eventRepository.Count(gctx, filter, nil)
+		if err != nil {
+			return err
+		}
+		total = count
+
+		return nil
+	})
+	g.Go(func() error {
+		bunchOfEvents, err := u.eventRepository.FindMany(gctx, filter, offset, limit, nil)
+		if err != nil {
+			return err
+		}
+		events = bunchOfEvents
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return GetManyEventResponse{}, err
+	}
+
+	resp := GetManyEventResponse{
+		Total:  total,
+		Events: make([]EventSummaryResponse, len(events)),
+	}
+	for k, v := range events {
+		resp.Events[k].PopulateFromEntity(v)
+	}
+
+	return resp, nil
+}
+
+// GetEvent implements EventUseCase.
+func (u *eventUseCase) GetEvent(ctx context.Context, ID string) (EventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	e, err := u.eventRepository.FindByID(ctx, ID, nil)
+	if err != nil {
+		return EventResponse{}, err
+	}
+
+	if err := u.loadAggregate(ctx, &e, nil); err != nil {
+		return EventResponse{}, err
+	}
+
+	resp := EventResponse{}
+	resp.PopulateFromEntity(e)
+
+	return resp, nil
+}
+
+func (u *eventUseCase) errSoldInventory(orders int64) error {
+	return errors.New(http.StatusConflict, status.CONFLICT, fmt.Sprintf("the change affects %d orders that are waiting for payment or paid, set 'acknowledge_sold_inventory' to proceed", orders))
+}
+
+func (u *eventUseCase) updateArtists(ctx context.Context, e *Event, names []string, tx *sql.Tx) error {
+	if err := u.artistRepository.DeleteByEventID(ctx, e.ID, tx); err != nil {
+		return err
+	}
+
+	artists := make([]Artist, len(names))
+	for k, v := range names {
+		artists[k] = Artist{
+			EventID: e.ID,
+			Name:    v,
+		}
+	}
+	e.Artists = artists
+
+	return u.createArtists(ctx, *e, tx)
+}
+
+func (u *eventUseCase) updatePromotors(ctx context.Context, e *Event, req []UpdatePromotorRequest, tx *sql.Tx) error {
+	if err := u.promotorRepository.DeleteByEventID(ctx, e.ID, tx); err != nil {
+		return err
+	}
+
+	promotors := make([]Promotor, len(req))
+	for k, v := range req {
+		promotors[k] = Promotor{
+			EventID: e.ID,
+			Name:    v.Name,
+			Email:   v.Email,
+			Phone:   v.Phone,
+		}
+	}
+	e.Promotors = promotors
+
+	return u.createPromotors(ctx, *e, tx)
+}
+
+// updateShows reschedules, moves or cancels the given shows. Shows that already sold tickets are only
+// changed when the change is acknowledged.
+func (u *eventUseCase) updateShows(ctx context.Context, e *Event, req []UpdateShowRequest, acknowledged bool, tx *sql.Tx) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	sold, err := u.salesRepository.CountSoldOrders(ctx, e.ID, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range req {
+		idx := -1
+		for k, s := range e.Shows {
+			if s.ID == v.ID {
+				idx = k
+				break
+			}
+		}
+		if idx < 0 {
+			return errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("show with id '%s' is not found in event '%s'", v.ID, e.ID))
+		}
+
+		show := e.Shows[idx]
+		if v.Venue != nil {
+			show.Venue = *v.Venue
+		}
+		if v.Time != nil {
+			showTime, err := time.ParseInLocation(time.DateTime, *v.Time, e.Location())
+			if err != nil {
+				return errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("invalid show time '%s'", *v.Time))
+			}
+			show.Time = showTime
+		}
+		if v.Status != nil {
+			show.Status = *v.Status
+		}
+
+		changed := show.Venue != e.Shows[idx].Venue || !show.Time.Equal(e.Shows[idx].Time) || show.Status != e.Shows[idx].Status || v.Location != nil
+		if !changed {
+			continue
+		}
+
+		if orders := sold.ByShow[show.ID]; orders > 0 && !acknowledged {
+			return u.errSoldInventory(orders)
+		}
+
+		if err := u.showRepository.Update(ctx, show.ID, show, tx); err != nil {
+			return err
+		}
+
+		if v.Location != nil {
+			location := Location{
+				EventID:          e.ID,
+				ShowID:           show.ID,
+				Country:          v.Location.Country,
+				City:             v.Location.City,
+				FormattedAddress: v.Location.FormattedAddress,
+				Latitude:         v.Location.Latitude,
+				Longitude:        v.Location.Longitude,
+			}
+
+			if show.Location == nil {
+				if err := u.locationRepository.Save(ctx, location, tx); err != nil {
+					return err
+				}
+			} else {
+				if err := u.locationRepository.Update(ctx, show.ID, location, tx); err != nil {
+					return err
+				}
+			}
+			show.Location = &location
+		}
+
+		e.Shows[idx] = show
+	}
+
+	return nil
+}
+
+// updateRules replaces the order rules of the event. The rules of an active event are enforced on the ongoing sale,
+// so they are only changed when the change is acknowledged.
+func (u *eventUseCase) updateRules(ctx context.Context, e *Event, req UpdateEventRequest, tx *sql.Tx) error {
+	if req.OrderRuleRangeDate != nil || req.OrderRuleDay != nil {
+		if err := u.checkRuleChange(*e, req.AcknowledgeSoldInventory); err != nil {
+			return err
+		}
+	}
+
+	if req.OrderRuleRangeDate != nil {
+		startDate, err := time.ParseInLocation(time.DateTime, req.OrderRuleRangeDate.StartDate, e.Location())
+		if err != nil {
+			return errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("invalid order rule start date '%s'", req.OrderRuleRangeDate.StartDate))
+		}
+		endDate, err := time.ParseInLocation(time.DateTime, req.OrderRuleRangeDate.EndDate, e.Location())
+		if err != nil {
+			return errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("invalid order rule end date '%s'", req.OrderRuleRangeDate.EndDate))
+		}
+
+		rule := order.OrderRuleRangeDate{
+			EventID:   e.ID,
+			StartDate: startDate,
+			EndDate:   endDate,
+		}
+
+		if e.OrderRules.OrderRuleRangeDate.EventID == "" {
+			err = u.orderRuleRangeDateRepository.Save(ctx, rule, tx)
+		} else {
+			err = u.orderRuleRangeDateRepository.Update(ctx, e.ID, rule, tx)
+		}
+		if err != nil {
+			return err
+		}
+		e.OrderRules.OrderRuleRangeDate = rule
 	}
 
-	if err := u.createRules(ctx, e, tx); err != nil {
-		tx.Rollback()
+	if req.OrderRuleDay != nil {
+		if err := u.orderRuleDayRepository.Delete(ctx, e.ID, tx); err != nil {
+			return err
+		}
+
+		days := make([]order.OrderRuleDay, len(req.OrderRuleDay))
+		for k, v := range req.OrderRuleDay {
+			days[k] = order.OrderRuleDay{
+				EventID: e.ID,
+				Day:     v,
+			}
+
+			if err := u.orderRuleDayRepository.Save(ctx, days[k], tx); err != nil {
+				return err
+			}
+		}
+		e.OrderRules.OrderRuleDay = days
+	}
+
+	return nil
+}
+
+// checkRuleChange returns an error when the order rules of an ongoing sale are changed without an acknowledgement.
+func (u *eventUseCase) checkRuleChange(e Event, acknowledged bool) error {
+	if e.Status == StatusActive && !acknowledged {
+		return errors.New(http.StatusConflict, status.CONFLICT, "the change affects the order rules of an ongoing sale, set 'acknowledge_sold_inventory' to proceed")
+	}
+
+	return nil
+}
+
+func (u *eventUseCase) applyUpdate(ctx context.Context, e *Event, req UpdateEventRequest, tx *sql.Tx) error {
+	if req.Name != nil {
+		e.Name = *req.Name
+	}
+
+	if req.Description != nil {
+		e.Description = *req.Description
+	}
+
+	// the times of the request are written in the new timezone.
+	if req.Timezone != nil {
+		e.Timezone = *req.Timezone
+	}
+
+	if req.Artists != nil {
+		if err := u.updateArtists(ctx, e, req.Artists, tx); err != nil {
+			return err
+		}
+	}
+
+	if req.Promotors != nil {
+		if err := u.updatePromotors(ctx, e, req.Promotors, tx); err != nil {
+			return err
+		}
+	}
+
+	if err := u.updateShows(ctx, e, req.Shows, req.AcknowledgeSoldInventory, tx); err != nil {
+		return err
+	}
+
+	if err := u.updateRules(ctx, e, req, tx); err != nil {
+		return err
+	}
+
+	e.UpdatedAt = time.Now()
+
+	return u.eventRepository.Update(ctx, e.ID, *e, tx)
+}
+
+// UpdateEvent implements EventUseCase.
+func (u *eventUseCase) UpdateEvent(ctx context.Context, req UpdateEventRequest) (EventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.eventRepository.BeginTx(ctx)
+	if err != nil {
+		return EventResponse{}, err
+	}
+
+	e, err := u.eventRepository.FindByIDForUpdate(ctx, req.ID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	if e.Status == StatusCancelled {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, errors.New(http.StatusForbidden, status.FORBIDDEN, "cancelled event can not be updated")
+	}
+
+	if err := u.loadAggregate(ctx, &e, tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	if err := u.applyUpdate(ctx, &e, req, tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	if err := u.eventRepository.CommitTx(ctx, tx); err != nil {
+		return EventResponse{}, err
+	}
+
+	resp := EventResponse{}
+	resp.PopulateFromEntity(e)
+
+	return resp, nil
+}
+
+// checkStatusTransition returns an error when the event can not be moved to the given status, sold is the number of
+// orders that hold its tickets.
+func (u *eventUseCase) checkStatusTransition(e Event, req UpdateEventStatusRequest, sold int64) error {
+	if !e.CanTransitionTo(req.Status) {
+		return errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("event can not be moved from '%s' to '%s'", e.Status, req.Status))
+	}
+
+	if sold > 0 && req.Status == StatusCancelled && !req.AcknowledgeSoldInventory {
+		return u.errSoldInventory(sold)
+	}
+
+	return nil
+}
+
+// UpdateEventStatus implements EventUseCase.
+func (u *eventUseCase) UpdateEventStatus(ctx context.Context, req UpdateEventStatusRequest) (EventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.eventRepository.BeginTx(ctx)
+	if err != nil {
+		return EventResponse{}, err
+	}
+
+	e, err := u.eventRepository.FindByIDForUpdate(ctx, req.ID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	if err := u.loadAggregate(ctx, &e, tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	sold, err := u.salesRepository.CountSoldOrders(ctx, e.ID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	if err := u.checkStatusTransition(e, req, sold.Total); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	e.Status = req.Status
+	e.UpdatedAt = time.Now()
+
+	if err := u.eventRepository.Update(ctx, e.ID, e, tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return EventResponse{}, err
+	}
+
+	if err := u.eventRepository.CommitTx(ctx, tx); err != nil {
+		return EventResponse{}, err
+	}
+
+	resp := EventResponse{}
+	resp.PopulateFromEntity(e)
+
+	return resp, nil
+}
+
+// DeleteEvent implements EventUseCase. Events that already sold tickets have to be cancelled instead.
+func (u *eventUseCase) DeleteEvent(ctx context.Context, ID string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.eventRepository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	e, err := u.eventRepository.FindByIDForUpdate(ctx, ID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return err
+	}
+
+	sold, err := u.salesRepository.CountSoldOrders(ctx, e.ID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return err
+	}
+
+	if sold.Total > 0 {
+		u.eventRepository.Rollback(ctx, tx)
+		return errors.New(http.StatusConflict, status.CONFLICT, fmt.Sprintf("event has %d orders that are waiting for payment or paid and can not be deleted, cancel it instead", sold.Total))
+	}
+
+	if err := u.eventRepository.Delete(ctx, e.ID, time.Now(), tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return err
+	}
+
+	if err := u.eventRepository.CommitTx(ctx, tx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// salesFilter builds the time range of the sales time series. By default the last 30 days are bucketed daily and
+// the last 48 hours are bucketed hourly. The given times are in the timezone of the event.
+func (u *eventUseCase) salesFilter(req GetEventSalesRequest, location *time.Location, now time.Time) (SalesFilter, error) {
+	filter := SalesFilter{
+		Interval: req.Interval,
+		To:       now,
+	}
+	if filter.Interval == "" {
+		filter.Interval = SalesIntervalDay
+	}
+
+	if !salesIntervals[filter.Interval] {
+		return SalesFilter{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("interval must be either '%s' or '%s'", SalesIntervalHour, SalesIntervalDay))
+	}
+
+	maxRange := 366 * 24 * time.Hour
+	defaultRange := 30 * 24 * time.Hour
+	if filter.Interval == SalesIntervalHour {
+		maxRange = 31 * 24 * time.Hour
+		defaultRange = 48 * time.Hour
+	}
+
+	if req.To != "" {
+		filter.To, _ = time.ParseInLocation(time.DateTime, req.To, location)
+	}
+
+	filter.From = filter.To.Add(-defaultRange)
+	if req.From != "" {
+		filter.From, _ = time.ParseInLocation(time.DateTime, req.From, location)
+	}
+
+	if !filter.From.Before(filter.To) {
+		return SalesFilter{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "'from' must be before 'to'")
+	}
+
+	if filter.To.Sub(filter.From) > maxRange {
+		return SalesFilter{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("time range of '%s' interval must not be longer than %d days", filter.Interval, int(maxRange.Hours()/24)))
+	}
+
+	return filter, nil
+}
+
+// GetEventSales implements EventUseCase.
+func (u *eventUseCase) GetEventSales(ctx context.Context, req GetEventSalesRequest) (EventSalesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	e, err := u.eventRepository.FindByID(ctx, req.EventID, nil)
+	if err != nil {
+		return EventSalesResponse{}, err
+	}
+
+	location := e.Location()
+
+	filter, err := u.salesFilter(req, location, time.Now())
+	if err != nil {
+		return EventSalesResponse{}, err
+	}
+
+	var stocks []TicketStockSales
+	var summary SalesSummary
+	var buckets []SalesBucket
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		result, err := u.salesRepository.FindManyTicketStockSales(gctx, req.EventID, nil)
+		if err != nil {
+			return err
+		}
+		stocks = result
+
+		return nil
+	})
+	g.Go(func() error {
+		result, err := u.salesRepository.GetSummary(gctx, req.EventID, nil)
+		if err != nil {
+			return err
+		}
+		summary = result
+
+		return nil
+	})
+	g.Go(func() error {
+		result, err := u.salesRepository.FindManyBucket(gctx, req.EventID, filter, location.String(), nil)
+		if err != nil {
+			return err
+		}
+		buckets = result
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return EventSalesResponse{}, err
+	}
+
+	resp := EventSalesResponse{}
+	resp.PopulateFromEntity(req.EventID, stocks, summary, filter, buckets, location)
+
+	return resp, nil
+}
+
+// GetManyOrderRule implements EventUseCase.
+func (u *eventUseCase) GetManyOrderRule(ctx context.Context, eventID string) ([]OrderRuleResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if _, err := u.eventRepository.FindByID(ctx, eventID, nil); err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		tx.Rollback()
+	rules, err := u.orderRuleRepository.FindManyByEventID(ctx, eventID, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	resp := CreateEventResponse{}
-	resp.PopulateFromEntity(e)
+	resp := make([]OrderRuleResponse, len(rules))
+	for k, rule := range rules {
+		resp[k].PopulateFromEntity(rule)
+	}
+
+	return resp, nil
+}
+
+// AttachOrderRule implements EventUseCase. The rule is evaluated after the rules that are already attached.
+func (u *eventUseCase) AttachOrderRule(ctx context.Context, req AttachOrderRuleRequest) (OrderRuleResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return OrderRuleResponse{}, err
+	}
+
+	if _, err := u.orderRuleRegistry.Build(req.Type, req.Config); err != nil {
+		return OrderRuleResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, err.Error())
+	}
+
+	config := req.Config
+	if len(config) == 0 {
+		config = json.RawMessage("{}")
+	}
+
+	tx, err := u.eventRepository.BeginTx(ctx)
+	if err != nil {
+		return OrderRuleResponse{}, err
+	}
+
+	e, err := u.eventRepository.FindByIDForUpdate(ctx, req.EventID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return OrderRuleResponse{}, err
+	}
+
+	if err := u.checkRuleChange(e, req.AcknowledgeSoldInventory); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return OrderRuleResponse{}, err
+	}
+
+	rule, err := u.orderRuleRepository.Save(ctx, order.OrderRule{
+		EventID:   req.EventID,
+		Type:      req.Type,
+		Config:    config,
+		CreatedBy: acc.ID,
+		CreatedAt: time.Now(),
+	}, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return OrderRuleResponse{}, err
+	}
+
+	if err := u.eventRepository.CommitTx(ctx, tx); err != nil {
+		return OrderRuleResponse{}, err
+	}
+
+	resp := OrderRuleResponse{}
+	resp.PopulateFromEntity(rule)
 
 	return resp, nil
 }
+
+// DetachOrderRule implements EventUseCase.
+func (u *eventUseCase) DetachOrderRule(ctx context.Context, req DetachOrderRuleRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	tx, err := u.eventRepository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	e, err := u.eventRepository.FindByIDForUpdate(ctx, req.EventID, tx)
+	if err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return err
+	}
+
+	if err := u.checkRuleChange(e, req.AcknowledgeSoldInventory); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return err
+	}
+
+	if err := u.orderRuleRepository.Delete(ctx, e.ID, req.ID, tx); err != nil {
+		u.eventRepository.Rollback(ctx, tx)
+		return err
+	}
+
+	return u.eventRepository.CommitTx(ctx, tx)
+}
@@ -0,0 +1,543 @@
+package event_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/event"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type eventRepositoryStandIn struct {
+	event.EventRepository
+	events map[string]event.Event
+}
+
+func (r *eventRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, nil
+}
+
+func (r *eventRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *eventRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *eventRepositoryStandIn) FindByID(ctx context.Context, ID string, tx *sql.Tx) (event.Event, error) {
+	e, ok := r.events[ID]
+	if !ok {
+		return event.Event{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "event is not found")
+	}
+
+	return e, nil
+}
+
+func (r *eventRepositoryStandIn) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (event.Event, error) {
+	return r.FindByID(ctx, ID, tx)
+}
+
+func (r *eventRepositoryStandIn) Update(ctx context.Context, ID string, update event.Event, tx *sql.Tx) error {
+	r.events[ID] = update
+
+	return nil
+}
+
+func (r *eventRepositoryStandIn) Delete(ctx context.Context, ID string, deletedAt time.Time, tx *sql.Tx) error {
+	delete(r.events, ID)
+
+	return nil
+}
+
+type artistRepositoryStandIn struct {
+	event.ArtistRepository
+}
+
+func (r artistRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]event.Artist, error) {
+	return []event.Artist{}, nil
+}
+
+type promotorRepositoryStandIn struct {
+	event.PromotorRepository
+}
+
+func (r promotorRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]event.Promotor, error) {
+	return []event.Promotor{}, nil
+}
+
+type showRepositoryStandIn struct {
+	event.ShowRepository
+	shows []event.Show
+}
+
+func (r *showRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]event.Show, error) {
+	shows := []event.Show{}
+	for _, s := range r.shows {
+		if s.EventID == eventID {
+			shows = append(shows, s)
+		}
+	}
+
+	return shows, nil
+}
+
+func (r *showRepositoryStandIn) Update(ctx context.Context, ID string, update event.Show, tx *sql.Tx) error {
+	for k, v := range r.shows {
+		if v.ID == ID {
+			r.shows[k] = update
+		}
+	}
+
+	return nil
+}
+
+type locationRepositoryStandIn struct {
+	event.LocationRepository
+}
+
+func (r locationRepositoryStandIn) FindByShowID(ctx context.Context, showID string, tx *sql.Tx) (event.Location, error) {
+	return event.Location{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "location is not found")
+}
+
+type ticketStockRepositoryStandIn struct {
+	ticket.TicketStockRepository
+	ticketStocks []ticket.TicketStock
+}
+
+func (r *ticketStockRepositoryStandIn) FindManyByShowID(ctx context.Context, showID string, tx *sql.Tx) ([]ticket.TicketStock, error) {
+	ticketStocks := []ticket.TicketStock{}
+	for _, ts := range r.ticketStocks {
+		if ts.ShowID == showID {
+			ticketStocks = append(ticketStocks, ts)
+		}
+	}
+
+	return ticketStocks, nil
+}
+
+type orderRuleRangeDateRepositoryStandIn struct {
+	order.OrderRuleRangeDateRepository
+	saved []order.OrderRuleRangeDate
+}
+
+func (r *orderRuleRangeDateRepositoryStandIn) FindByEventID(ctx context.Context, eventID string, tx *sql.Tx) (order.OrderRuleRangeDate, error) {
+	return order.OrderRuleRangeDate{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "order rule range date is not found")
+}
+
+func (r *orderRuleRangeDateRepositoryStandIn) FindManyScopedByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]order.OrderRuleRangeDate, error) {
+	return []order.OrderRuleRangeDate{}, nil
+}
+
+func (r *orderRuleRangeDateRepositoryStandIn) Save(ctx context.Context, rule order.OrderRuleRangeDate, tx *sql.Tx) error {
+	r.saved = append(r.saved, rule)
+
+	return nil
+}
+
+type orderRuleDayRepositoryStandIn struct {
+	order.OrderRuleDayRepository
+}
+
+func (r orderRuleDayRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]order.OrderRuleDay, error) {
+	return []order.OrderRuleDay{}, nil
+}
+
+func (r orderRuleDayRepositoryStandIn) FindManyScopedByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]order.OrderRuleDay, error) {
+	return []order.OrderRuleDay{}, nil
+}
+
+// newEventUseCase returns a use case of an event with a single show that has the given number of sold orders, along
+// with the stand-ins that record the changes of the event, of its show and of its range date.
+func newEventUseCase(eventStatus string, sold int64) (event.EventUseCase, *eventRepositoryStandIn, *showRepositoryStandIn, *orderRuleRangeDateRepositoryStandIn) {
+	events := &eventRepositoryStandIn{events: map[string]event.Event{
+		"event-1": {ID: "event-1", Name: "Concert", Status: eventStatus, Timezone: "Asia/Jakarta"},
+	}}
+	shows := &showRepositoryStandIn{shows: []event.Show{
+		{EventID: "event-1", ID: "show-1", Venue: "Stadium", Type: event.ShowTypeLive, Status: event.StatusActive},
+	}}
+	rangeDates := &orderRuleRangeDateRepositoryStandIn{}
+
+	return event.NewEventUseCase(event.EventUseCaseProperty{
+		Logger:             standin.Logger(),
+		Timeout:            5 * time.Second,
+		EventRepository:    events,
+		ArtistRepository:   artistRepositoryStandIn{},
+		PromotorRepository: promotorRepositoryStandIn{},
+		ShowRepository:     shows,
+		LocationRepository: locationRepositoryStandIn{},
+		TicketStockRepository: &ticketStockRepositoryStandIn{ticketStocks: []ticket.TicketStock{
+			{EventID: "event-1", ShowID: "show-1", ID: "stock-1", Tier: event.TicketTierGold, Allocation: 100},
+		}},
+		OrderRuleRangeDateRepository: rangeDates,
+		OrderRuleDayRepository:       orderRuleDayRepositoryStandIn{},
+		SalesRepository:              &salesRepositoryStandIn{sold: event.SoldOrders{Total: sold, ByShow: map[string]int64{"show-1": sold}}},
+	}), events, shows, rangeDates
+}
+
+func TestEventUseCaseUpdateEventStatus(t *testing.T) {
+	testCases := []struct {
+		name         string
+		from         string
+		sold         int64
+		req          event.UpdateEventStatusRequest
+		expectedCode int
+	}{
+		{name: "publish a draft", from: event.StatusDraft, req: event.UpdateEventStatusRequest{Status: event.StatusActive}},
+		{name: "close an active event", from: event.StatusActive, sold: 10, req: event.UpdateEventStatusRequest{Status: event.StatusClosed}},
+		{name: "an active event never goes back to draft", from: event.StatusActive, req: event.UpdateEventStatusRequest{Status: event.StatusDraft}, expectedCode: http.StatusBadRequest},
+		{name: "a closed sale is never reopened", from: event.StatusClosed, req: event.UpdateEventStatusRequest{Status: event.StatusActive}, expectedCode: http.StatusBadRequest},
+		{name: "cancel an event that sold tickets without acknowledging", from: event.StatusActive, sold: 10, req: event.UpdateEventStatusRequest{Status: event.StatusCancelled}, expectedCode: http.StatusConflict},
+		{name: "cancel an event that sold tickets", from: event.StatusClosed, sold: 10, req: event.UpdateEventStatusRequest{Status: event.StatusCancelled, AcknowledgeSoldInventory: true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, events, _, _ := newEventUseCase(tc.from, tc.sold)
+			tc.req.ID = "event-1"
+
+			resp, err := u.UpdateEventStatus(context.Background(), tc.req)
+			if tc.expectedCode != 0 {
+				assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+				assert.Equal(t, tc.from, events.events["event-1"].Status)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.req.Status, resp.Status)
+			assert.Equal(t, tc.req.Status, events.events["event-1"].Status)
+		})
+	}
+}
+
+func TestEventUseCaseUpdateEventOrderRules(t *testing.T) {
+	rangeDate := &event.UpdateOrderRuleRangeDateRequest{StartDate: "2026-01-10 09:00:00", EndDate: "2026-06-30 23:59:59"}
+
+	testCases := []struct {
+		name         string
+		eventStatus  string
+		acknowledged bool
+		expectedCode int
+	}{
+		{name: "the rules of a draft are changed freely", eventStatus: event.StatusDraft},
+		{name: "the rules of an ongoing sale are only changed when acknowledged", eventStatus: event.StatusActive, expectedCode: http.StatusConflict},
+		{name: "the rules of an ongoing sale are changed once acknowledged", eventStatus: event.StatusActive, acknowledged: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _, _, rangeDates := newEventUseCase(tc.eventStatus, 0)
+
+			_, err := u.UpdateEvent(context.Background(), event.UpdateEventRequest{
+				ID:                       "event-1",
+				OrderRuleRangeDate:       rangeDate,
+				AcknowledgeSoldInventory: tc.acknowledged,
+			})
+			if tc.expectedCode != 0 {
+				assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+				assert.Empty(t, rangeDates.saved)
+				return
+			}
+
+			assert.NoError(t, err)
+			if assert.Len(t, rangeDates.saved, 1) {
+				jakarta, _ := time.LoadLocation("Asia/Jakarta")
+				assert.Equal(t, time.Date(2026, 1, 10, 9, 0, 0, 0, jakarta), rangeDates.saved[0].StartDate)
+			}
+		})
+	}
+
+	t.Run("a change without rules does not need an acknowledgement", func(t *testing.T) {
+		u, _, _, _ := newEventUseCase(event.StatusActive, 0)
+		name := "Concert Reloaded"
+
+		resp, err := u.UpdateEvent(context.Background(), event.UpdateEventRequest{ID: "event-1", Name: &name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, resp.Name)
+	})
+}
+
+func TestEventUseCaseUpdateEventShows(t *testing.T) {
+	venue := "Arena"
+
+	testCases := []struct {
+		name         string
+		sold         int64
+		acknowledged bool
+		expectedCode int
+	}{
+		{name: "a show without orders is moved freely", sold: 0},
+		{name: "a show with orders is only moved when acknowledged", sold: 3, expectedCode: http.StatusConflict},
+		{name: "a show with orders is moved once acknowledged", sold: 3, acknowledged: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _, shows, _ := newEventUseCase(event.StatusActive, tc.sold)
+
+			_, err := u.UpdateEvent(context.Background(), event.UpdateEventRequest{
+				ID:                       "event-1",
+				Shows:                    []event.UpdateShowRequest{{ID: "show-1", Venue: &venue}},
+				AcknowledgeSoldInventory: tc.acknowledged,
+			})
+			if tc.expectedCode != 0 {
+				assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+				assert.Equal(t, "Stadium", shows.shows[0].Venue)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, venue, shows.shows[0].Venue)
+		})
+	}
+}
+
+func TestEventUseCaseDeleteEvent(t *testing.T) {
+	t.Run("an event without orders is deleted", func(t *testing.T) {
+		u, events, _, _ := newEventUseCase(event.StatusDraft, 0)
+
+		assert.NoError(t, u.DeleteEvent(context.Background(), "event-1"))
+		assert.NotContains(t, events.events, "event-1")
+	})
+
+	t.Run("an event with orders has to be cancelled instead", func(t *testing.T) {
+		u, events, _, _ := newEventUseCase(event.StatusActive, 2)
+
+		err := u.DeleteEvent(context.Background(), "event-1")
+		assert.Equal(t, http.StatusConflict, errors.Destruct(err).HTTPStatusCode)
+		assert.Contains(t, events.events, "event-1")
+	})
+}
+
+type orderRuleRepositoryStandIn struct {
+	order.OrderRuleRepository
+	rules []order.OrderRule
+}
+
+func (r *orderRuleRepositoryStandIn) Save(ctx context.Context, rule order.OrderRule, tx *sql.Tx) (order.OrderRule, error) {
+	rule.ID = int64(len(r.rules) + 1)
+	r.rules = append(r.rules, rule)
+
+	return rule, nil
+}
+
+func (r *orderRuleRepositoryStandIn) Delete(ctx context.Context, eventID string, ID int64, tx *sql.Tx) error {
+	for k, v := range r.rules {
+		if v.EventID == eventID && v.ID == ID {
+			r.rules = append(r.rules[:k], r.rules[k+1:]...)
+			return nil
+		}
+	}
+
+	return errors.New(http.StatusNotFound, status.NOT_FOUND, "order rule is not found")
+}
+
+func TestEventUseCaseAttachAndDetachOrderRule(t *testing.T) {
+	newRuleUseCase := func(eventStatus string) (event.EventUseCase, *orderRuleRepositoryStandIn) {
+		rules := &orderRuleRepositoryStandIn{rules: []order.OrderRule{{ID: 1, EventID: "event-1", Type: orderrule.TypeVerifiedEmail}}}
+		u := event.NewEventUseCase(event.EventUseCaseProperty{
+			Logger:  standin.Logger(),
+			Timeout: 5 * time.Second,
+			EventRepository: &eventRepositoryStandIn{events: map[string]event.Event{
+				"event-1": {ID: "event-1", Name: "Concert", Status: eventStatus, Timezone: "Asia/Jakarta"},
+			}},
+			OrderRuleRepository: rules,
+			OrderRuleRegistry:   orderrule.NewDefaultRegistry(),
+		})
+
+		return u, rules
+	}
+
+	testCases := []struct {
+		name         string
+		eventStatus  string
+		acknowledged bool
+		expectedCode int
+	}{
+		{name: "the rules of a draft are changed freely", eventStatus: event.StatusDraft},
+		{name: "the rules of an ongoing sale are only changed when acknowledged", eventStatus: event.StatusActive, expectedCode: http.StatusConflict},
+		{name: "the rules of an ongoing sale are changed once acknowledged", eventStatus: event.StatusActive, acknowledged: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run("attach: "+tc.name, func(t *testing.T) {
+			u, rules := newRuleUseCase(tc.eventStatus)
+
+			_, err := u.AttachOrderRule(standin.AdminContext(), event.AttachOrderRuleRequest{
+				EventID:                  "event-1",
+				Type:                     orderrule.TypeVerifiedEmail,
+				AcknowledgeSoldInventory: tc.acknowledged,
+			})
+			if tc.expectedCode != 0 {
+				assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+				assert.Len(t, rules.rules, 1)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, rules.rules, 2)
+		})
+
+		t.Run("detach: "+tc.name, func(t *testing.T) {
+			u, rules := newRuleUseCase(tc.eventStatus)
+
+			err := u.DetachOrderRule(standin.AdminContext(), event.DetachOrderRuleRequest{
+				EventID:                  "event-1",
+				ID:                       1,
+				AcknowledgeSoldInventory: tc.acknowledged,
+			})
+			if tc.expectedCode != 0 {
+				assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+				assert.Len(t, rules.rules, 1)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Empty(t, rules.rules)
+		})
+	}
+
+	t.Run("the rules of an unknown event are not detached", func(t *testing.T) {
+		u, rules := newRuleUseCase(event.StatusDraft)
+
+		err := u.DetachOrderRule(standin.AdminContext(), event.DetachOrderRuleRequest{EventID: "event-2", ID: 1})
+		assert.Equal(t, http.StatusNotFound, errors.Destruct(err).HTTPStatusCode)
+		assert.Len(t, rules.rules, 1)
+	})
+}
+
+type salesRepositoryStandIn struct {
+	sold      event.SoldOrders
+	filters   []event.SalesFilter
+	locations []string
+}
+
+func (r *salesRepositoryStandIn) CountSoldOrders(ctx context.Context, eventID string, tx *sql.Tx) (event.SoldOrders, error) {
+	return r.sold, nil
+}
+
+func (r *salesRepositoryStandIn) FindManyTicketStockSales(ctx context.Context, eventID string, tx *sql.Tx) ([]event.TicketStockSales, error) {
+	return []event.TicketStockSales{
+		{TicketStockID: "stock-1", ShowID: "show-1", Tier: event.TicketTierGold, Price: 1000, Allocation: 100, Acquired: 60, Pending: 15},
+		{TicketStockID: "stock-2", ShowID: "show-1", Tier: event.TicketTierSilver, Price: 500, Allocation: 50, Acquired: 45, Pending: 10},
+	}, nil
+}
+
+func (r *salesRepositoryStandIn) GetSummary(ctx context.Context, eventID string, tx *sql.Tx) (event.SalesSummary, error) {
+	return event.SalesSummary{PlacedOrders: 80, PaidOrders: 60, ExpiredOrders: 12, WaitingForPaymentOrders: 8, Subtotal: 75000, TotalBilled: 87000}, nil
+}
+
+func (r *salesRepositoryStandIn) FindManyBucket(ctx context.Context, eventID string, filter event.SalesFilter, location string, tx *sql.Tx) ([]event.SalesBucket, error) {
+	r.filters = append(r.filters, filter)
+	r.locations = append(r.locations, location)
+
+	return []event.SalesBucket{
+		{Time: time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC), PlacedOrders: 10, PaidOrders: 8, TicketsSold: 12, Revenue: 12000},
+	}, nil
+}
+
+func TestEventUseCaseGetEventSales(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	newSalesUseCase := func() (event.EventUseCase, *salesRepositoryStandIn) {
+		sales := &salesRepositoryStandIn{}
+		u := event.NewEventUseCase(event.EventUseCaseProperty{
+			Logger:  standin.Logger(),
+			Timeout: 5 * time.Second,
+			EventRepository: &eventRepositoryStandIn{events: map[string]event.Event{
+				"event-1": {ID: "event-1", Name: "Concert", Status: event.StatusActive, Timezone: "Asia/Jakarta"},
+			}},
+			SalesRepository: sales,
+		})
+
+		return u, sales
+	}
+
+	t.Run("the figures of the ticket stocks, the revenue and the conversion", func(t *testing.T) {
+		u, sales := newSalesUseCase()
+
+		resp, err := u.GetEventSales(context.Background(), event.GetEventSalesRequest{EventID: "event-1"})
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		if assert.Len(t, resp.TicketStocks, 2) {
+			assert.Equal(t, int64(25), resp.TicketStocks[0].Remaining)
+			assert.Equal(t, int64(0), resp.TicketStocks[1].Remaining, "a pending order never makes the remaining negative")
+		}
+		assert.Equal(t, float64(75000), resp.Revenue.Subtotal)
+		assert.Equal(t, float64(87000), resp.Revenue.TotalBilled)
+		assert.Equal(t, 0.75, resp.Conversion.PaidRate)
+
+		assert.Equal(t, []string{"Asia/Jakarta"}, sales.locations, "the buckets are truncated in the timezone of the event")
+		if assert.Len(t, resp.TimeSeries.Buckets, 1) {
+			assert.Equal(t, jakarta, resp.TimeSeries.Buckets[0].Time.Location())
+			assert.Equal(t, 0, resp.TimeSeries.Buckets[0].Time.Hour(), "a daily bucket starts at the local midnight")
+		}
+	})
+
+	t.Run("the last 30 days are bucketed daily by default", func(t *testing.T) {
+		u, sales := newSalesUseCase()
+
+		resp, err := u.GetEventSales(context.Background(), event.GetEventSalesRequest{EventID: "event-1"})
+		assert.NoError(t, err)
+		assert.Equal(t, event.SalesIntervalDay, resp.TimeSeries.Interval)
+		if assert.Len(t, sales.filters, 1) {
+			assert.Equal(t, event.SalesIntervalDay, sales.filters[0].Interval)
+			assert.WithinDuration(t, time.Now(), sales.filters[0].To, time.Minute)
+			assert.Equal(t, 30*24*time.Hour, sales.filters[0].To.Sub(sales.filters[0].From))
+		}
+	})
+
+	t.Run("the last 48 hours are bucketed hourly by default", func(t *testing.T) {
+		u, sales := newSalesUseCase()
+
+		_, err := u.GetEventSales(context.Background(), event.GetEventSalesRequest{EventID: "event-1", Interval: event.SalesIntervalHour})
+		assert.NoError(t, err)
+		if assert.Len(t, sales.filters, 1) {
+			assert.Equal(t, 48*time.Hour, sales.filters[0].To.Sub(sales.filters[0].From))
+		}
+	})
+
+	t.Run("the given range is written in the timezone of the event", func(t *testing.T) {
+		u, sales := newSalesUseCase()
+
+		_, err := u.GetEventSales(context.Background(), event.GetEventSalesRequest{EventID: "event-1", From: "2026-03-01 00:00:00", To: "2026-03-08 00:00:00"})
+		assert.NoError(t, err)
+		if assert.Len(t, sales.filters, 1) {
+			assert.Equal(t, time.Date(2026, 2, 28, 17, 0, 0, 0, time.UTC), sales.filters[0].From.UTC())
+			assert.Equal(t, time.Date(2026, 3, 7, 17, 0, 0, 0, time.UTC), sales.filters[0].To.UTC())
+		}
+	})
+
+	testCases := []struct {
+		name         string
+		req          event.GetEventSalesRequest
+		expectedCode int
+	}{
+		{name: "an interval that is not whitelisted never reaches the repository", req: event.GetEventSalesRequest{EventID: "event-1", Interval: "day'); DROP TABLE ticket_order; --"}, expectedCode: http.StatusBadRequest},
+		{name: "from must be before to", req: event.GetEventSalesRequest{EventID: "event-1", From: "2026-03-08 00:00:00", To: "2026-03-01 00:00:00"}, expectedCode: http.StatusBadRequest},
+		{name: "an hourly range longer than 31 days", req: event.GetEventSalesRequest{EventID: "event-1", Interval: event.SalesIntervalHour, From: "2026-01-01 00:00:00", To: "2026-02-02 00:00:00"}, expectedCode: http.StatusBadRequest},
+		{name: "a daily range longer than 366 days", req: event.GetEventSalesRequest{EventID: "event-1", From: "2025-01-01 00:00:00", To: "2026-01-03 00:00:00"}, expectedCode: http.StatusBadRequest},
+		{name: "an unknown event", req: event.GetEventSalesRequest{EventID: "event-2"}, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, sales := newSalesUseCase()
+
+			_, err := u.GetEventSales(context.Background(), tc.req)
+			assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+			assert.Empty(t, sales.filters)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package export
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DatasetOrder          = "orders"
+	DatasetAcquiredTicket = "acquired-tickets"
+	DatasetStockJournal   = "stock-journals"
+
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	JobStatusPending   = "PENDING"
+	JobStatusRunning   = "RUNNING"
+	JobStatusCompleted = "COMPLETED"
+	JobStatusFailed    = "FAILED"
+
+	// JobQueue is the cloud tasks queue of the export jobs, its dispatch deadline must cover the job timeout.
+	JobQueue        = "export-job"
+	OnRunJobURLPath = "/v1/adminapp/exports/jobs/on-run"
+)
+
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ContentType returns the media type of the export format.
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// FileName returns the name of the exported file of the dataset.
+func FileName(dataset, format string, at time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", dataset, at.UTC().Format("20060102T150405Z"), format)
+}
+
+type Column struct {
+	Name       string
+	Expression string
+}
+
+// Dataset describes what an export reads, every column is selected as text so a row can be written as is.
+type Dataset struct {
+	Name    string
+	Columns []Column
+	// Source is the FROM clause, including the joins, of the dataset.
+	Source  string
+	OrderBy string
+	// Expressions that are compared with the filter.
+	EventID string
+	ShowID  string
+	Status  string
+	Date    string
+}
+
+// Select returns the columns of the given names in the given order, or every column if no name is given.
+func (d Dataset) Select(names []string) ([]Column, error) {
+	if len(names) == 0 {
+		return d.Columns, nil
+	}
+
+	byName := make(map[string]Column, len(d.Columns))
+	for _, c := range d.Columns {
+		byName[c.Name] = c
+	}
+
+	columns := make([]Column, 0, len(names))
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("column '%s' is not available in %s export", name, d.Name)
+		}
+
+		if selected[name] {
+			continue
+		}
+		selected[name] = true
+		columns = append(columns, c)
+	}
+
+	return columns, nil
+}
+
+// Datasets are the exports that are offered to the admin, keyed by their name.
+var Datasets = map[string]Dataset{
+	// One row for every item of an order, so the order ledger can be grouped by event and show.
+	DatasetOrder: {
+		Name: DatasetOrder,
+		Columns: []Column{
+			{Name: "order_id", Expression: "o.id"},
+			{Name: "status", Expression: "o.status"},
+			{Name: "payment_method", Expression: "o.payment_method"},
+			{Name: "transaction_id", Expression: "o.transaction_id"},
+			{Name: "virtual_account", Expression: "o.virtual_account"},
+			{Name: "customer_id", Expression: "o.customer_id"},
+			{Name: "customer_name", Expression: "o.customer_name"},
+			{Name: "customer_email", Expression: "o.customer_email"},
+			{Name: "event_id", Expression: "i.event_id"},
+			{Name: "event_name", Expression: "i.event_name"},
+			{Name: "show_id", Expression: "i.show_id"},
+			{Name: "show_venue", Expression: "i.show_venue"},
+			{Name: "ticket_stock_id", Expression: "i.ticket_stock_id"},
+			{Name: "tier", Expression: "i.tier"},
+			{Name: "price", Expression: "i.price"},
+			{Name: "quantity", Expression: "i.quantity"},
+			{Name: "subtotal", Expression: "o.subtotal"},
+			{Name: "tax", Expression: "o.tax"},
+			{Name: "service_charge", Expression: "o.service_charge"},
+			{Name: "discount", Expression: "o.discount"},
+			{Name: "total_amount", Expression: "o.total_amount"},
+			{Name: "created_at", Expression: "o.created_at"},
+			{Name: "updated_at", Expression: "o.updated_at"},
+		},
+		Source:  "ticket_order o JOIN order_item i ON i.order_id = o.id",
+		OrderBy: "o.created_at, o.id",
+		EventID: "i.event_id",
+		ShowID:  "i.show_id",
+		Status:  "o.status",
+		Date:    "o.created_at",
+	},
+	// The attendee list, the status and date filter apply to the order of the ticket.
+	DatasetAcquiredTicket: {
+		Name: DatasetAcquiredTicket,
+		Columns: []Column{
+			{Name: "number", Expression: "a.number"},
+			{Name: "event_id", Expression: "a.event_id"},
+			{Name: "show_id", Expression: "a.show_id"},
+			{Name: "ticket_stock_id", Expression: "a.ticket_stock_id"},
+			{Name: "tier", Expression: "ts.tier"},
+			{Name: "show_time", Expression: "a.show_time"},
+			{Name: "customer_id", Expression: "a.customer_id"},
+			{Name: "customer_name", Expression: "a.customer_name"},
+			{Name: "customer_email", Expression: "a.customer_email"},
+			{Name: "order_id", Expression: "a.order_id"},
+			{Name: "order_status", Expression: "o.status"},
+			{Name: "ordered_at", Expression: "o.created_at"},
+		},
+		Source:  "acquired_ticket a JOIN ticket_order o ON o.id = a.order_id LEFT JOIN ticket_stock ts ON ts.id = a.ticket_stock_id",
+		OrderBy: "a.show_id, a.number",
+		EventID: "a.event_id",
+		ShowID:  "a.show_id",
+		Status:  "o.status",
+		Date:    "o.created_at",
+	},
+	// The status filter applies to the action of the journal.
+	DatasetStockJournal: {
+		Name: DatasetStockJournal,
+		Columns: []Column{
+			{Name: "id", Expression: "j.id"},
+			{Name: "event_id", Expression: "ts.event_id"},
+			{Name: "show_id", Expression: "ts.show_id"},
+			{Name: "ticket_stock_id", Expression: "j.ticket_stock_id"},
+			{Name: "tier", Expression: "ts.tier"},
+			{Name: "action", Expression: "j.action"},
+			{Name: "quantity", Expression: "j.quantity"},
+			{Name: "stock", Expression: "j.stock"},
+			{Name: "price", Expression: "j.price"},
+			{Name: "description", Expression: "j.description"},
+			{Name: "created_by", Expression: "j.created_by"},
+			{Name: "created_at", Expression: "j.created_at"},
+		},
+		Source:  "ticket_stock_journal j JOIN ticket_stock ts ON ts.id = j.ticket_stock_id",
+		OrderBy: "j.created_at, j.id",
+		EventID: "ts.event_id",
+		ShowID:  "ts.show_id",
+		Status:  "j.action",
+		Date:    "j.created_at",
+	},
+}
+
+type Filter struct {
+	EventID string
+	ShowID  string
+	Status  string
+	From    time.Time
+	To      time.Time
+}
+
+// Job is an export that is written to an object of the export bucket by a cloud task.
+type Job struct {
+	ID         string    `json:"id"`
+	Dataset    string    `json:"dataset"`
+	Format     string    `json:"format"`
+	Columns    []string  `json:"columns"`
+	Filter     Filter    `json:"filter"`
+	Status     string    `json:"status"`
+	FileName   string    `json:"file_name"`
+	Rows       int64     `json:"rows"`
+	Error      string    `json:"error"`
+	CreatedBy  int64     `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// ObjectName is the name of the object that holds the file of the job in the export bucket.
+func (j Job) ObjectName() string {
+	return fmt.Sprintf("exports/%s.%s", j.ID, j.Format)
+}
+
+// RunJobEvent is the task that writes the file of an export job.
+type RunJobEvent struct {
+	JobID string `json:"job_id"`
+}
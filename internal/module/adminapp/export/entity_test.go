@@ -0,0 +1,32 @@
+package export_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/export"
+)
+
+func TestDatasetSelect(t *testing.T) {
+	dataset := export.Datasets[export.DatasetAcquiredTicket]
+
+	t.Run("select every column by default", func(t *testing.T) {
+		columns, err := dataset.Select(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, dataset.Columns, columns)
+	})
+
+	t.Run("select columns in the requested order", func(t *testing.T) {
+		columns, err := dataset.Select([]string{"customer_email", "number", "customer_email"})
+		assert.NoError(t, err)
+		assert.Equal(t, []export.Column{
+			{Name: "customer_email", Expression: "a.customer_email"},
+			{Name: "number", Expression: "a.number"},
+		}, columns)
+	})
+
+	t.Run("reject unknown column", func(t *testing.T) {
+		_, err := dataset.Select([]string{"number", "password"})
+		assert.EqualError(t, err, "column 'password' is not available in acquired-tickets export")
+	})
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type ExportRepository interface {
+	Count(ctx context.Context, dataset Dataset, filter Filter) (int64, error)
+	// Stream calls fn for every row of the dataset as soon as the row is read, so the rows are never held in memory.
+	// The record is reused between calls.
+	Stream(ctx context.Context, dataset Dataset, columns []Column, filter Filter, fn func(record []string) error) error
+}
+
+type exportRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewExportRepository(logger *logrus.Logger, db *sql.DB) ExportRepository {
+	return &exportRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (r *exportRepository) filterCondition(dataset Dataset, filter Filter) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	args := make([]interface{}, 0)
+
+	if filter.EventID != "" {
+		args = append(args, filter.EventID)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", dataset.EventID, len(args)))
+	}
+
+	if filter.ShowID != "" {
+		args = append(args, filter.ShowID)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", dataset.ShowID, len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", dataset.Status, len(args)))
+	}
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", dataset.Date, len(args)))
+	}
+
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("%s < $%d", dataset.Date, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// Count implements ExportRepository.
+func (r *exportRepository) Count(ctx context.Context, dataset Dataset, filter Filter) (int64, error) {
+	condition, args := r.filterCondition(dataset, filter)
+
+	query := fmt.Sprintf(`
+		SELECT count(1)
+		FROM %s
+		WHERE
+			%s
+	`, dataset.Source, condition)
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while counting %s export's prorperties", dataset.Name))
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.QueryRowContext(ctx, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while counting %s export's prorperties", dataset.Name))
+	}
+
+	return count, nil
+}
+
+// Stream implements ExportRepository.
+func (r *exportRepository) Stream(ctx context.Context, dataset Dataset, columns []Column, filter Filter, fn func(record []string) error) error {
+	condition, args := r.filterCondition(dataset, filter)
+
+	expressions := make([]string, len(columns))
+	for k, c := range columns {
+		expressions[k] = c.Expression
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			%s
+		FROM %s
+		WHERE
+			%s
+		ORDER BY %s
+	`, strings.Join(expressions, ", "), dataset.Source, condition, dataset.OrderBy)
+
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while streaming %s export's prorperties", dataset.Name))
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while streaming %s export's prorperties", dataset.Name))
+	}
+	defer rows.Close()
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for k := range values {
+		dest[k] = &values[k]
+	}
+	record := make([]string, len(columns))
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while streaming %s export's prorperties", dataset.Name))
+		}
+
+		for k, v := range values {
+			record[k] = v.String
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while streaming %s export's prorperties", dataset.Name))
+	}
+
+	return nil
+}
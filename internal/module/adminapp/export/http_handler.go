@@ -0,0 +1,251 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	ExportUseCase     ExportUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, exportUseCase ExportUseCase) {
+	handler := &HTTPHandler{
+		Validate:      validate,
+		ExportUseCase: exportUseCase,
+	}
+
+	router.HandleFunc("/tm-order/v1/adminapp/exports/jobs/on-run", publicMiddleware.SetRouteChain(handler.OnRunJob)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/exports/jobs/{id}", publicMiddleware.SetRouteChain(handler.GetJob, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/exports/jobs/{id}/download", publicMiddleware.SetRouteChain(handler.DownloadJob, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/exports/{dataset}", publicMiddleware.SetRouteChain(handler.Export, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/exports/{dataset}/jobs", publicMiddleware.SetRouteChain(handler.CreateJob, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+// attachment sends the headers of the file on the first write, so an error that occurs before any row is
+// written can still be responded as JSON.
+type attachment struct {
+	w           http.ResponseWriter
+	name        string
+	contentType string
+	written     bool
+}
+
+func (a *attachment) Write(p []byte) (int, error) {
+	if !a.written {
+		a.written = true
+		a.w.Header().Set("Content-Type", a.contentType)
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.name))
+		a.w.WriteHeader(http.StatusOK)
+	}
+
+	return a.w.Write(p)
+}
+
+func (handler HTTPHandler) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := ExportRequest{}
+	req.Dataset = mux.Vars(r)["dataset"]
+	req.Format = qs.Get("format")
+	if req.Format == "" {
+		req.Format = FormatCSV
+	}
+	if columns := qs.Get("columns"); columns != "" {
+		req.Columns = strings.Split(columns, ",")
+	}
+	req.EventID = qs.Get("event_id")
+	req.ShowID = qs.Get("show_id")
+	req.Status = qs.Get("status")
+	req.From = qs.Get("from")
+	req.To = qs.Get("to")
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	file := &attachment{
+		w:           w,
+		name:        FileName(req.Dataset, req.Format, time.Now()),
+		contentType: ContentType(req.Format),
+	}
+
+	resp, err := handler.ExportUseCase.Export(ctx, req, file)
+	if err != nil {
+		if file.written {
+			return
+		}
+
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	if resp.Job != nil {
+		response.JSON(w, http.StatusAccepted, response.RESTEnvelope{
+			Status:  status.ACCEPTED,
+			Message: "export is too large to be streamed, it has been scheduled as a job",
+			Data:    resp.Job,
+		})
+	}
+}
+
+func (handler HTTPHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ExportRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.Dataset = mux.Vars(r)["dataset"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.ExportUseCase.CreateJob(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, response.RESTEnvelope{
+		Status:  status.ACCEPTED,
+		Message: "export job has been successfully scheduled",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.ExportUseCase.GetJob(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "export job",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) DownloadJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	file, err := handler.ExportUseCase.OpenJobFile(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+	defer file.File.Close()
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	w.Header().Set("Last-Modified", file.ModTime.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, file.File)
+}
+
+func (handler HTTPHandler) OnRunJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	e := RunJobEvent{}
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.ExportUseCase.RunJob(ctx, e); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "export job has been run",
+	})
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+var (
+	jobKeyPrefix string = "export:job:%s"
+)
+
+type JobRepository interface {
+	Save(ctx context.Context, job Job, ttl time.Duration) error
+	FindByID(ctx context.Context, ID string) (Job, error)
+}
+
+type redisJobRepository struct {
+	logger *logrus.Logger
+	rc     redis.UniversalClient
+}
+
+func NewJobRepository(logger *logrus.Logger, rc redis.UniversalClient) JobRepository {
+	return &redisJobRepository{
+		logger: logger,
+		rc:     rc,
+	}
+}
+
+// FindByID implements JobRepository.
+func (r *redisJobRepository) FindByID(ctx context.Context, ID string) (Job, error) {
+	dataBuff, err := r.rc.Get(ctx, fmt.Sprintf(jobKeyPrefix, ID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return Job{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("export job with id '%s' is not found", ID))
+		}
+
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Job{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting export job's prorperties")
+	}
+
+	var job Job
+	if err := json.Unmarshal(dataBuff, &job); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Job{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting export job's prorperties")
+	}
+
+	return job, nil
+}
+
+// Save implements JobRepository.
+func (r *redisJobRepository) Save(ctx context.Context, job Job, ttl time.Duration) error {
+	dataBuff, _ := json.Marshal(job)
+	if err := r.rc.Set(ctx, fmt.Sprintf(jobKeyPrefix, job.ID), dataBuff, ttl).Err(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving export job's prorperties")
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package export
+
+type ExportRequest struct {
+	Dataset string   `json:"-" validate:"required,oneof=orders acquired-tickets stock-journals"`
+	Format  string   `json:"format" validate:"required,oneof=csv xlsx"`
+	Columns []string `json:"columns" validate:"omitempty,dive,required"`
+	EventID string   `json:"event_id" validate:"-"`
+	ShowID  string   `json:"show_id" validate:"-"`
+	Status  string   `json:"status" validate:"-"`
+	From    string   `json:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To      string   `json:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
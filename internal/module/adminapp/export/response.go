@@ -0,0 +1,50 @@
+package export
+
+import (
+	"io"
+	"time"
+)
+
+type JobResponse struct {
+	ID         string     `json:"id"`
+	Dataset    string     `json:"dataset"`
+	Format     string     `json:"format"`
+	Columns    []string   `json:"columns"`
+	Status     string     `json:"status"`
+	FileName   string     `json:"file_name"`
+	Rows       int64      `json:"rows"`
+	Error      string     `json:"error,omitempty"`
+	CreatedBy  int64      `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+func (r *JobResponse) PopulateFromEntity(j Job) {
+	r.ID = j.ID
+	r.Dataset = j.Dataset
+	r.Format = j.Format
+	r.Columns = j.Columns
+	r.Status = j.Status
+	r.FileName = j.FileName
+	r.Rows = j.Rows
+	r.Error = j.Error
+	r.CreatedBy = j.CreatedBy
+	r.CreatedAt = j.CreatedAt
+	if !j.FinishedAt.IsZero() {
+		finishedAt := j.FinishedAt
+		r.FinishedAt = &finishedAt
+	}
+}
+
+// ExportResponse holds the job of an export that is too large to be streamed, it is nil if the export has been streamed.
+type ExportResponse struct {
+	Job *JobResponse
+}
+
+// JobFile is the completed file of an export job, it is read from the export bucket and the caller must close it.
+type JobFile struct {
+	Name        string
+	ContentType string
+	ModTime     time.Time
+	File        io.ReadCloser
+}
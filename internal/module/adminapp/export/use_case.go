@@ -0,0 +1,394 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/cloudtasks/apiv2/cloudtaskspb"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gcstorage"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gctasks"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type ExportUseCase interface {
+	// Export streams the rows into w, an export with more rows than the sync limit becomes a job instead.
+	Export(ctx context.Context, req ExportRequest, w io.Writer) (ExportResponse, error)
+	CreateJob(ctx context.Context, req ExportRequest) (JobResponse, error)
+	// RunJob writes the file of the job, it is called by the task that is created with the job.
+	RunJob(ctx context.Context, e RunJobEvent) error
+	GetJob(ctx context.Context, ID string) (JobResponse, error)
+	OpenJobFile(ctx context.Context, ID string) (JobFile, error)
+}
+
+type ExportUseCaseProperty struct {
+	Logger           *logrus.Logger
+	Timeout          time.Duration
+	StreamTimeout    time.Duration
+	JobTimeout       time.Duration
+	JobRetention     time.Duration
+	SyncMaxRows      int64
+	BaseURL          string
+	ExportRepository ExportRepository
+	JobRepository    JobRepository
+	// Storage holds the files of the jobs, so they can be downloaded from any instance of the service.
+	Storage   gcstorage.Client
+	CloudTask gctasks.Client
+}
+
+type exportUseCase struct {
+	logger           *logrus.Logger
+	timeout          time.Duration
+	streamTimeout    time.Duration
+	jobTimeout       time.Duration
+	jobRetention     time.Duration
+	syncMaxRows      int64
+	baseURL          string
+	exportRepository ExportRepository
+	jobRepository    JobRepository
+	storage          gcstorage.Client
+	cloudTask        gctasks.Client
+}
+
+func NewExportUseCase(props ExportUseCaseProperty) ExportUseCase {
+	return &exportUseCase{
+		logger:           props.Logger,
+		timeout:          props.Timeout,
+		streamTimeout:    props.StreamTimeout,
+		jobTimeout:       props.JobTimeout,
+		jobRetention:     props.JobRetention,
+		syncMaxRows:      props.SyncMaxRows,
+		baseURL:          props.BaseURL,
+		exportRepository: props.ExportRepository,
+		jobRepository:    props.JobRepository,
+		storage:          props.Storage,
+		cloudTask:        props.CloudTask,
+	}
+}
+
+type exportSpec struct {
+	dataset Dataset
+	columns []Column
+	filter  Filter
+}
+
+func (u *exportUseCase) spec(req ExportRequest) (exportSpec, error) {
+	dataset, ok := Datasets[req.Dataset]
+	if !ok {
+		return exportSpec{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("export '%s' is not found", req.Dataset))
+	}
+
+	columns, err := dataset.Select(req.Columns)
+	if err != nil {
+		return exportSpec{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, err.Error())
+	}
+
+	filter := Filter{
+		EventID: req.EventID,
+		ShowID:  req.ShowID,
+		Status:  req.Status,
+	}
+	if req.From != "" {
+		filter.From, _ = time.Parse(time.RFC3339, req.From)
+	}
+	if req.To != "" {
+		filter.To, _ = time.Parse(time.RFC3339, req.To)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return exportSpec{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "'from' must be before 'to'")
+	}
+
+	return exportSpec{
+		dataset: dataset,
+		columns: columns,
+		filter:  filter,
+	}, nil
+}
+
+func (u *exportUseCase) write(ctx context.Context, format string, spec exportSpec, w io.Writer) (int64, error) {
+	rw, err := NewRowWriter(format, w)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while writing export")
+	}
+
+	header := make([]string, len(spec.columns))
+	for k, c := range spec.columns {
+		header[k] = c.Name
+	}
+
+	if err := rw.Write(header); err != nil {
+		rw.Close()
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while writing export")
+	}
+
+	var rows int64
+	err = u.exportRepository.Stream(ctx, spec.dataset, spec.columns, spec.filter, func(record []string) error {
+		if err := rw.Write(record); err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while writing export")
+		}
+		rows++
+		return nil
+	})
+	if err != nil {
+		rw.Close()
+		return rows, err
+	}
+
+	if err := rw.Close(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return rows, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while writing export")
+	}
+
+	return rows, nil
+}
+
+// Export implements ExportUseCase.
+func (u *exportUseCase) Export(ctx context.Context, req ExportRequest, w io.Writer) (ExportResponse, error) {
+	spec, err := u.spec(req)
+	if err != nil {
+		return ExportResponse{}, err
+	}
+
+	countCtx, cancelCount := context.WithTimeout(ctx, u.timeout)
+	defer cancelCount()
+
+	total, err := u.exportRepository.Count(countCtx, spec.dataset, spec.filter)
+	if err != nil {
+		return ExportResponse{}, err
+	}
+
+	if total > u.syncMaxRows {
+		job, err := u.CreateJob(ctx, req)
+		if err != nil {
+			return ExportResponse{}, err
+		}
+
+		return ExportResponse{Job: &job}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.streamTimeout)
+	defer cancel()
+
+	if _, err := u.write(ctx, req.Format, spec, w); err != nil {
+		return ExportResponse{}, err
+	}
+
+	return ExportResponse{}, nil
+}
+
+// jobSpec rebuilds the spec of the job from the columns and the filter that were checked when it was created.
+func (u *exportUseCase) jobSpec(job Job) (exportSpec, error) {
+	dataset, ok := Datasets[job.Dataset]
+	if !ok {
+		return exportSpec{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("export '%s' is not found", job.Dataset))
+	}
+
+	columns, err := dataset.Select(job.Columns)
+	if err != nil {
+		return exportSpec{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, err.Error())
+	}
+
+	return exportSpec{
+		dataset: dataset,
+		columns: columns,
+		filter:  job.Filter,
+	}, nil
+}
+
+// writeObject writes the file of the job into the export bucket, the object is discarded unless the export is complete.
+func (u *exportUseCase) writeObject(ctx context.Context, job Job, spec exportSpec) (int64, error) {
+	ctx, abort := context.WithCancel(ctx)
+	defer abort()
+
+	w := u.storage.NewWriter(ctx, job.ObjectName(), ContentType(job.Format))
+
+	rows, err := u.write(ctx, job.Format, spec, w)
+	if err != nil {
+		abort()
+		w.Close()
+		return rows, err
+	}
+
+	if err := w.Close(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return rows, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while creating export file")
+	}
+
+	return rows, nil
+}
+
+// CreateJob implements ExportUseCase.
+func (u *exportUseCase) CreateJob(ctx context.Context, req ExportRequest) (JobResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return JobResponse{}, err
+	}
+
+	spec, err := u.spec(req)
+	if err != nil {
+		return JobResponse{}, err
+	}
+
+	columns := make([]string, len(spec.columns))
+	for k, c := range spec.columns {
+		columns[k] = c.Name
+	}
+
+	now := time.Now()
+	job := Job{
+		ID:        util.GenerateRandomHEX(16),
+		Dataset:   spec.dataset.Name,
+		Format:    req.Format,
+		Columns:   columns,
+		Filter:    spec.filter,
+		Status:    JobStatusPending,
+		FileName:  FileName(spec.dataset.Name, req.Format, now),
+		CreatedBy: acc.ID,
+		CreatedAt: now,
+	}
+
+	if err := u.jobRepository.Save(ctx, job, u.jobRetention); err != nil {
+		return JobResponse{}, err
+	}
+
+	runJobBuff, _ := json.Marshal(RunJobEvent{JobID: job.ID})
+	tasksRequest := gctasks.Request{
+		URL:    fmt.Sprintf("%s%s", u.baseURL, OnRunJobURLPath),
+		Method: cloudtaskspb.HttpMethod_POST,
+		Body:   runJobBuff,
+	}
+	if err := u.cloudTask.CreateTask(JobQueue, tasksRequest); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+
+		job.Status = JobStatusFailed
+		job.Error = "the export job could not be scheduled"
+		job.FinishedAt = time.Now()
+		u.jobRepository.Save(ctx, job, u.jobRetention)
+
+		return JobResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while scheduling export job")
+	}
+
+	resp := JobResponse{}
+	resp.PopulateFromEntity(job)
+
+	return resp, nil
+}
+
+// RunJob implements ExportUseCase.
+func (u *exportUseCase) RunJob(ctx context.Context, e RunJobEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, u.jobTimeout)
+	defer cancel()
+
+	job, err := u.jobRepository.FindByID(ctx, e.JobID)
+	if err != nil {
+		if errors.MatchStatus(err, status.NOT_FOUND) {
+			u.logger.WithContext(ctx).WithField("job_id", e.JobID).Warn("the export job is no longer kept")
+			return nil
+		}
+
+		return err
+	}
+
+	switch job.Status {
+	case JobStatusCompleted, JobStatusFailed:
+		// the task has been retried after the job had finished.
+		return nil
+	case JobStatusRunning:
+		// an attempt that is still within the job timeout may yet finish, the task is retried later.
+		if time.Since(job.StartedAt) < u.jobTimeout {
+			return errors.New(http.StatusConflict, status.CONFLICT, "export job is already running")
+		}
+	}
+
+	spec, err := u.jobSpec(job)
+	if err != nil {
+		job.Status = JobStatusFailed
+		job.Error = errors.Destruct(err).Message
+		job.FinishedAt = time.Now()
+		return u.jobRepository.Save(ctx, job, u.jobRetention)
+	}
+
+	job.Status = JobStatusRunning
+	job.StartedAt = time.Now()
+	if err := u.jobRepository.Save(ctx, job, u.jobRetention); err != nil {
+		return err
+	}
+
+	rows, err := u.writeObject(ctx, job, spec)
+
+	job.Rows = rows
+	job.FinishedAt = time.Now()
+	job.Status = JobStatusCompleted
+	if err != nil {
+		job.Status = JobStatusFailed
+		job.Error = errors.Destruct(err).Message
+	}
+
+	// the job is saved with a fresh context, so a job that ran out of time is still marked as failed.
+	saveCtx, cancelSave := context.WithTimeout(context.Background(), u.timeout)
+	defer cancelSave()
+
+	return u.jobRepository.Save(saveCtx, job, u.jobRetention)
+}
+
+// GetJob implements ExportUseCase.
+func (u *exportUseCase) GetJob(ctx context.Context, ID string) (JobResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	job, err := u.jobRepository.FindByID(ctx, ID)
+	if err != nil {
+		return JobResponse{}, err
+	}
+
+	resp := JobResponse{}
+	resp.PopulateFromEntity(job)
+
+	return resp, nil
+}
+
+// OpenJobFile implements ExportUseCase.
+func (u *exportUseCase) OpenJobFile(ctx context.Context, ID string) (JobFile, error) {
+	findCtx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	job, err := u.jobRepository.FindByID(findCtx, ID)
+	if err != nil {
+		return JobFile{}, err
+	}
+
+	if job.Status != JobStatusCompleted {
+		return JobFile{}, errors.New(http.StatusConflict, status.CONFLICT, fmt.Sprintf("export job is %s", job.Status))
+	}
+
+	// the file is read with the context of the caller, it is still being read after this returns.
+	f, err := u.storage.NewReader(ctx, job.ObjectName())
+	if err != nil {
+		if err == gcstorage.ErrObjectNotExist {
+			return JobFile{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "export file is no longer available")
+		}
+
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return JobFile{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while opening export file")
+	}
+
+	return JobFile{
+		Name:        job.FileName,
+		ContentType: ContentType(job.Format),
+		ModTime:     job.FinishedAt,
+		File:        f,
+	}, nil
+}
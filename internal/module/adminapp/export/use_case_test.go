@@ -0,0 +1,256 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/export"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gcstorage"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gctasks"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+// backendStandIn is what an export job runs on: the rows of the dataset, the store of the jobs, the bucket of the files
+// and the queue of the cloud tasks. An object is only kept once its writer is closed with a live context.
+type backendStandIn struct {
+	gctasks.Client
+	records   [][]string
+	streamErr error
+	jobs      map[string]export.Job
+	objects   map[string][]byte
+	queues    []string
+	tasks     []gctasks.Request
+	taskErr   error
+}
+
+func newBackendStandIn(records ...[]string) *backendStandIn {
+	return &backendStandIn{records: records, jobs: make(map[string]export.Job), objects: make(map[string][]byte)}
+}
+
+func (b *backendStandIn) Count(ctx context.Context, dataset export.Dataset, filter export.Filter) (int64, error) {
+	return int64(len(b.records)), nil
+}
+
+func (b *backendStandIn) Stream(ctx context.Context, dataset export.Dataset, columns []export.Column, filter export.Filter, fn func(record []string) error) error {
+	for _, record := range b.records {
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	return b.streamErr
+}
+
+func (b *backendStandIn) Save(ctx context.Context, job export.Job, ttl time.Duration) error {
+	b.jobs[job.ID] = job
+
+	return nil
+}
+
+func (b *backendStandIn) FindByID(ctx context.Context, ID string) (export.Job, error) {
+	job, ok := b.jobs[ID]
+	if !ok {
+		return export.Job{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "export job is not found")
+	}
+
+	return job, nil
+}
+
+type objectWriter struct {
+	ctx     context.Context
+	name    string
+	buff    bytes.Buffer
+	backend *backendStandIn
+}
+
+func (w *objectWriter) Write(p []byte) (int, error) {
+	return w.buff.Write(p)
+}
+
+func (w *objectWriter) Close() error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	w.backend.objects[w.name] = w.buff.Bytes()
+
+	return nil
+}
+
+func (b *backendStandIn) NewWriter(ctx context.Context, name string, contentType string) io.WriteCloser {
+	return &objectWriter{ctx: ctx, name: name, backend: b}
+}
+
+func (b *backendStandIn) NewReader(ctx context.Context, name string) (io.ReadCloser, error) {
+	object, ok := b.objects[name]
+	if !ok {
+		return nil, gcstorage.ErrObjectNotExist
+	}
+
+	return io.NopCloser(bytes.NewReader(object)), nil
+}
+
+func (b *backendStandIn) Delete(ctx context.Context, name string) error {
+	delete(b.objects, name)
+
+	return nil
+}
+
+func (b *backendStandIn) Close() error {
+	return nil
+}
+
+func (b *backendStandIn) CreateTask(queueID string, request gctasks.Request) error {
+	if b.taskErr != nil {
+		return b.taskErr
+	}
+	b.queues = append(b.queues, queueID)
+	b.tasks = append(b.tasks, request)
+
+	return nil
+}
+
+func newExportUseCase(backend *backendStandIn) export.ExportUseCase {
+	return export.NewExportUseCase(export.ExportUseCaseProperty{
+		Logger:           standin.Logger(),
+		Timeout:          5 * time.Second,
+		StreamTimeout:    5 * time.Second,
+		JobTimeout:       5 * time.Second,
+		JobRetention:     time.Hour,
+		SyncMaxRows:      1,
+		BaseURL:          "https://tm-order.example.com/tm-order",
+		ExportRepository: backend,
+		JobRepository:    backend,
+		Storage:          backend,
+		CloudTask:        backend,
+	})
+}
+
+func TestExportUseCaseJob(t *testing.T) {
+	req := export.ExportRequest{Dataset: export.DatasetStockJournal, Format: export.FormatCSV, Columns: []string{"ticket_stock_id", "quantity"}}
+	records := [][]string{{"gold", "10"}, {"silver", "20"}}
+	ctx := standin.AdminContext()
+
+	t.Run("a job is run by a cloud task and its file is written to the bucket", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		u := newExportUseCase(backend)
+
+		job, err := u.CreateJob(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, export.JobStatusPending, job.Status)
+
+		if assert.Len(t, backend.tasks, 1) {
+			assert.Equal(t, export.JobQueue, backend.queues[0])
+			assert.Equal(t, "https://tm-order.example.com/tm-order/v1/adminapp/exports/jobs/on-run", backend.tasks[0].URL)
+
+			var e export.RunJobEvent
+			assert.NoError(t, json.Unmarshal(backend.tasks[0].Body, &e))
+			assert.Equal(t, job.ID, e.JobID)
+		}
+		assert.Empty(t, backend.objects, "nothing is written until the task runs")
+
+		assert.NoError(t, u.RunJob(context.Background(), export.RunJobEvent{JobID: job.ID}))
+
+		completed, err := u.GetJob(context.Background(), job.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, export.JobStatusCompleted, completed.Status)
+		assert.Equal(t, int64(2), completed.Rows)
+
+		file, err := u.OpenJobFile(context.Background(), job.ID)
+		if assert.NoError(t, err) {
+			defer file.File.Close()
+
+			content, _ := io.ReadAll(file.File)
+			assert.Equal(t, "ticket_stock_id,quantity\ngold,10\nsilver,20\n", string(content))
+			assert.Equal(t, "text/csv", file.ContentType)
+		}
+	})
+
+	t.Run("a retried task does not run a finished job again", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		u := newExportUseCase(backend)
+
+		job, _ := u.CreateJob(ctx, req)
+		assert.NoError(t, u.RunJob(context.Background(), export.RunJobEvent{JobID: job.ID}))
+		delete(backend.objects, "exports/"+job.ID+".csv")
+
+		assert.NoError(t, u.RunJob(context.Background(), export.RunJobEvent{JobID: job.ID}))
+		assert.Empty(t, backend.objects)
+	})
+
+	t.Run("a job that is still running is retried later", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		u := newExportUseCase(backend)
+
+		backend.jobs["job-1"] = export.Job{ID: "job-1", Dataset: export.DatasetStockJournal, Format: export.FormatCSV, Status: export.JobStatusRunning, StartedAt: time.Now()}
+
+		err := u.RunJob(context.Background(), export.RunJobEvent{JobID: "job-1"})
+		assert.Equal(t, http.StatusConflict, errors.Destruct(err).HTTPStatusCode)
+		assert.Empty(t, backend.objects)
+	})
+
+	t.Run("a failed export leaves no file behind", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		backend.streamErr = errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "connection reset")
+		u := newExportUseCase(backend)
+
+		job, _ := u.CreateJob(ctx, req)
+		assert.NoError(t, u.RunJob(context.Background(), export.RunJobEvent{JobID: job.ID}))
+
+		failed, _ := u.GetJob(context.Background(), job.ID)
+		assert.Equal(t, export.JobStatusFailed, failed.Status)
+		assert.Equal(t, "connection reset", failed.Error)
+		assert.Empty(t, backend.objects)
+
+		_, err := u.OpenJobFile(context.Background(), job.ID)
+		assert.Equal(t, http.StatusConflict, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("a job that can not be scheduled is marked as failed", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		u := newExportUseCase(backend)
+		backend.taskErr = io.ErrClosedPipe
+
+		_, err := u.CreateJob(ctx, req)
+		assert.Equal(t, http.StatusInternalServerError, errors.Destruct(err).HTTPStatusCode)
+
+		if assert.Len(t, backend.jobs, 1) {
+			for _, job := range backend.jobs {
+				assert.Equal(t, export.JobStatusFailed, job.Status)
+			}
+		}
+	})
+
+	t.Run("the file of a job is gone once the bucket has deleted it", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		u := newExportUseCase(backend)
+
+		job, _ := u.CreateJob(ctx, req)
+		assert.NoError(t, u.RunJob(context.Background(), export.RunJobEvent{JobID: job.ID}))
+		delete(backend.objects, "exports/"+job.ID+".csv")
+
+		_, err := u.OpenJobFile(context.Background(), job.ID)
+		assert.Equal(t, http.StatusNotFound, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("an export with more rows than the sync limit becomes a job", func(t *testing.T) {
+		backend := newBackendStandIn(records...)
+		u := newExportUseCase(backend)
+
+		var buff bytes.Buffer
+		resp, err := u.Export(ctx, req, &buff)
+		assert.NoError(t, err)
+		if assert.NotNil(t, resp.Job) {
+			assert.Equal(t, export.JobStatusPending, resp.Job.Status)
+		}
+		assert.Empty(t, buff.String())
+		assert.Len(t, backend.tasks, 1)
+	})
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	xlsxSheet = "Sheet1"
+)
+
+// RowWriter writes the records of an export in a file format. Close must be called to complete the file.
+type RowWriter interface {
+	Write(record []string) error
+	Close() error
+}
+
+// NewRowWriter returns the writer of the format that writes into w.
+func NewRowWriter(format string, w io.Writer) (RowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvRowWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		f := excelize.NewFile()
+		sw, err := f.NewStreamWriter(xlsxSheet)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &xlsxRowWriter{w: w, file: f, stream: sw}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format '%s'", format)
+	}
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+// Write implements RowWriter.
+func (rw *csvRowWriter) Write(record []string) error {
+	cells := make([]string, len(record))
+	for k, v := range record {
+		cells[k] = neutraliseFormula(v)
+	}
+
+	return rw.w.Write(cells)
+}
+
+// neutraliseFormula prefixes the cell with a quote when a spreadsheet would read it as a formula, the data of a
+// customer must never be run when the csv is opened. A cell of xlsx is always written as a string.
+func neutraliseFormula(cell string) string {
+	if cell == "" {
+		return cell
+	}
+
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	}
+
+	return cell
+}
+
+// Close implements RowWriter.
+func (rw *csvRowWriter) Close() error {
+	rw.w.Flush()
+	return rw.w.Error()
+}
+
+// xlsxRowWriter writes the rows through the stream writer of excelize which keeps the sheet in a temporary
+// file instead of memory, the workbook is only written into w once it is closed.
+type xlsxRowWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// Write implements RowWriter.
+func (rw *xlsxRowWriter) Write(record []string) error {
+	rw.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, rw.row)
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(record))
+	for k, v := range record {
+		values[k] = v
+	}
+
+	return rw.stream.SetRow(cell, values)
+}
+
+// Close implements RowWriter.
+func (rw *xlsxRowWriter) Close() error {
+	defer rw.file.Close()
+
+	if err := rw.stream.Flush(); err != nil {
+		return err
+	}
+
+	return rw.file.Write(rw.w)
+}
@@ -0,0 +1,66 @@
+package export_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/export"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestRowWriter(t *testing.T) {
+	records := [][]string{
+		{"order_id", "customer_name"},
+		{"TO1", "Doe, John"},
+		{"TO2", ""},
+	}
+
+	t.Run("write csv", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		rw, err := export.NewRowWriter(export.FormatCSV, buf)
+		assert.NoError(t, err)
+
+		for _, record := range records {
+			assert.NoError(t, rw.Write(record))
+		}
+		assert.NoError(t, rw.Close())
+
+		assert.Equal(t, "order_id,customer_name\nTO1,\"Doe, John\"\nTO2,\n", buf.String())
+	})
+
+	t.Run("neutralise the formulas of csv", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		rw, err := export.NewRowWriter(export.FormatCSV, buf)
+		assert.NoError(t, err)
+
+		assert.NoError(t, rw.Write([]string{"=1+2", "+1", "-2", "@SUM(A1)", "\tcmd", "\rcmd", "a=b"}))
+		assert.NoError(t, rw.Close())
+
+		assert.Equal(t, "'=1+2,'+1,'-2,'@SUM(A1),'\tcmd,\"'\rcmd\",a=b\n", buf.String())
+	})
+
+	t.Run("write xlsx", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		rw, err := export.NewRowWriter(export.FormatXLSX, buf)
+		assert.NoError(t, err)
+
+		for _, record := range records {
+			assert.NoError(t, rw.Write(record))
+		}
+		assert.NoError(t, rw.Close())
+
+		f, err := excelize.OpenReader(buf)
+		assert.NoError(t, err)
+		defer f.Close()
+
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"order_id", "customer_name"}, {"TO1", "Doe, John"}, {"TO2"}}, rows)
+	})
+
+	t.Run("reject unsupported format", func(t *testing.T) {
+		_, err := export.NewRowWriter("pdf", new(bytes.Buffer))
+		assert.EqualError(t, err, "unsupported export format 'pdf'")
+	})
+}
@@ -1,14 +1,104 @@
 package order
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
+const (
+	StatusWaitingForPayment string = "WAITING_FOR_PAYMENT"
+	StatusPaid              string = "PAID"
+	StatusExpired           string = "EXPIRED"
+
+	AuditActionMarkPaid     string = "MARK_PAID"
+	AuditActionForceExpire  string = "FORCE_EXPIRE"
+	AuditActionResendTicket string = "RESEND_TICKET"
+)
+
+// OrderRuleRangeDate without show and ticket stock applies to the whole event, the rule of a show or a ticket stock
+// takes precedence over it.
 type OrderRuleRangeDate struct {
-	EventID   string
-	StartDate time.Time
-	EndDate   time.Time
+	EventID       string
+	ShowID        *string
+	TicketStockID *string
+	StartDate     time.Time
+	EndDate       time.Time
 }
 
+// OrderRuleDay is scoped the same way as OrderRuleRangeDate.
 type OrderRuleDay struct {
-	EventID string
-	Day     int64
+	EventID       string
+	ShowID        *string
+	TicketStockID *string
+	Day           int64
+}
+
+// OrderRule is a rule of the order rule engine attached to an event, the rules of an event are evaluated by their
+// position.
+type OrderRule struct {
+	ID        int64
+	EventID   string
+	Type      string
+	Config    json.RawMessage
+	Position  int64
+	CreatedBy int64
+	CreatedAt time.Time
+}
+
+type Order struct {
+	ID                      string
+	PaymentMethod           string
+	VirtualAccount          *string
+	TransactionID           *string
+	Status                  string
+	CustomerID              int64
+	CustomerName            string
+	CustomerEmail           string
+	TaxPercentage           float64
+	ServiceChargePercentage float64
+	DiscountPercentage      float64
+	ServiceCharge           float64
+	Tax                     float64
+	Discount                float64
+	Items                   []Item
+	Subtotal                float64
+	TotalAmount             float64
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+type Item struct {
+	ID            int64
+	OrderID       string
+	TicketStockID string
+	ShowID        string
+	EventID       string
+	EventName     string
+	ShowVenue     string
+	Tier          string
+	Price         float64
+	Quantity      int64
+}
+
+// OrderFilter narrows down the orders of every customer, empty fields are ignored.
+type OrderFilter struct {
+	OrderID        string
+	TransactionID  string
+	VirtualAccount string
+	CustomerEmail  string
+	EventID        string
+	Status         string
+}
+
+// OrderAudit records a manual intervention of an admin on an order.
+type OrderAudit struct {
+	ID             int64
+	OrderID        string
+	Action         string
+	Reason         string
+	Proof          *string
+	PreviousStatus string
+	Status         string
+	CreatedBy      int64
+	CreatedAt      time.Time
 }
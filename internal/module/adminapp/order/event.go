@@ -0,0 +1,62 @@
+package order
+
+import (
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+)
+
+func newOrderItems(o Order) []contract.OrderItem {
+	items := make([]contract.OrderItem, len(o.Items))
+	for k, v := range o.Items {
+		items[k] = contract.OrderItem{
+			TicketStockID: v.TicketStockID,
+			ShowID:        v.ShowID,
+			EventID:       v.EventID,
+			EventName:     v.EventName,
+			ShowVenue:     v.ShowVenue,
+			Tier:          v.Tier,
+			Price:         v.Price,
+			Quantity:      v.Quantity,
+		}
+	}
+
+	return items
+}
+
+func newOrderPaidEvent(o Order) contract.OrderPaid {
+	return contract.OrderPaid{
+		ID:                      o.ID,
+		PaymentMethod:           o.PaymentMethod,
+		VirtualAccount:          o.VirtualAccount,
+		TransactionID:           o.TransactionID,
+		Status:                  o.Status,
+		CustomerID:              o.CustomerID,
+		CustomerName:            o.CustomerName,
+		CustomerEmail:           o.CustomerEmail,
+		TaxPercentage:           o.TaxPercentage,
+		ServiceChargePercentage: o.ServiceChargePercentage,
+		DiscountPercentage:      o.DiscountPercentage,
+		ServiceCharge:           o.ServiceCharge,
+		Tax:                     o.Tax,
+		Discount:                o.Discount,
+		Items:                   newOrderItems(o),
+		Subtotal:                o.Subtotal,
+		TotalAmount:             o.TotalAmount,
+		CreatedAt:               o.CreatedAt,
+		UpdatedAt:               o.UpdatedAt,
+	}
+}
+
+func newOrderTicketResendEvent(o Order, reason string, requestedBy int64, requestedAt time.Time) contract.OrderTicketResend {
+	return contract.OrderTicketResend{
+		OrderID:       o.ID,
+		CustomerID:    o.CustomerID,
+		CustomerName:  o.CustomerName,
+		CustomerEmail: o.CustomerEmail,
+		Items:         newOrderItems(o),
+		Reason:        reason,
+		RequestedBy:   requestedBy,
+		RequestedAt:   requestedAt,
+	}
+}
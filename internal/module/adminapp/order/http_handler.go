@@ -0,0 +1,244 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	OrderUseCase      OrderUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, orderUseCase OrderUseCase) {
+	handler := &HTTPHandler{
+		Validate:     validate,
+		OrderUseCase: orderUseCase,
+	}
+
+	router.HandleFunc("/tm-order/v1/adminapp/orders", publicMiddleware.SetRouteChain(handler.GetManyOrder, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/orders/{id}", publicMiddleware.SetRouteChain(handler.GetOrder, adminSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/adminapp/orders/{id}/mark-paid", publicMiddleware.SetRouteChain(handler.MarkOrderPaid, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/orders/{id}/expire", publicMiddleware.SetRouteChain(handler.ForceExpireOrder, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/orders/{id}/resend-ticket", publicMiddleware.SetRouteChain(handler.ResendTicket, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleSupport))).Methods(http.MethodPost)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+func (handler HTTPHandler) GetManyOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := GetManyOrderRequest{}
+	req.Page, _ = strconv.ParseInt(qs.Get("page"), 10, 64)
+	req.Size, _ = strconv.ParseInt(qs.Get("size"), 10, 64)
+	req.OrderID = qs.Get("order_id")
+	req.TransactionID = qs.Get("transaction_id")
+	req.VirtualAccount = qs.Get("virtual_account")
+	req.CustomerEmail = qs.Get("customer_email")
+	req.EventID = qs.Get("event_id")
+	req.Status = qs.Get("status")
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.OrderUseCase.GetManyOrder(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of orders",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.OrderUseCase.GetOrder(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "order",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) MarkOrderPaid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := MarkOrderPaidRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.OrderID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.OrderUseCase.MarkOrderPaid(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "order has been successfully marked as paid",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) ForceExpireOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ForceExpireOrderRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.OrderID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.OrderUseCase.ForceExpireOrder(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "order has been successfully expired",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) ResendTicket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ResendTicketRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.OrderID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.OrderUseCase.ResendTicket(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket email has been successfully requested to be sent again",
+		Data:    resp,
+	})
+}
@@ -0,0 +1,120 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type OrderAuditRepository interface {
+	Save(ctx context.Context, a OrderAudit, tx *sql.Tx) error
+	FindManyByOrderID(ctx context.Context, orderID string, tx *sql.Tx) ([]OrderAudit, error)
+}
+
+type orderAuditRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewOrderAuditRepository(logger *logrus.Logger, db *sql.DB) OrderAuditRepository {
+	return &orderAuditRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// FindManyByOrderID implements OrderAuditRepository.
+func (r *orderAuditRepository) FindManyByOrderID(ctx context.Context, orderID string, tx *sql.Tx) ([]OrderAudit, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			id, order_id, action, reason, proof, previous_status, status, created_by, created_at
+		FROM order_audit
+		WHERE
+			order_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order audit's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, orderID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order audit's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]OrderAudit, 0)
+	for rows.Next() {
+		var a OrderAudit
+		var proof sql.NullString
+
+		if err := rows.Scan(&a.ID, &a.OrderID, &a.Action, &a.Reason, &proof, &a.PreviousStatus, &a.Status, &a.CreatedBy, &a.CreatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order audit's prorperties")
+		}
+
+		if proof.Valid {
+			a.Proof = &proof.String
+		}
+
+		data = append(data, a)
+	}
+
+	return data, nil
+}
+
+// Save implements OrderAuditRepository.
+func (r *orderAuditRepository) Save(ctx context.Context, a OrderAudit, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		INSERT INTO order_audit
+		(
+			order_id, action, reason, proof, previous_status, status, created_by, created_at
+		)
+		VALUES
+		(
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving order audit's prorperties")
+	}
+	defer stmt.Close()
+
+	var proof sql.NullString
+	if a.Proof != nil {
+		proof.Valid = true
+		proof.String = *a.Proof
+	}
+
+	_, err = stmt.ExecContext(ctx, a.OrderID, a.Action, a.Reason, proof, a.PreviousStatus, a.Status, a.CreatedBy, a.CreatedAt)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving order audit's prorperties")
+	}
+
+	return nil
+}
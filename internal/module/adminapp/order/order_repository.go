@@ -0,0 +1,301 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type OrderRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CommitTx(ctx context.Context, tx *sql.Tx) error
+	Rollback(ctx context.Context, tx *sql.Tx) error
+	FindByID(ctx context.Context, ID string, tx *sql.Tx) (Order, error)
+	FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (Order, error)
+	FindMany(ctx context.Context, filter OrderFilter, offset, limit int64, tx *sql.Tx) ([]Order, error)
+	Count(ctx context.Context, filter OrderFilter, tx *sql.Tx) (int64, error)
+	Update(ctx context.Context, ID string, o Order, tx *sql.Tx) error
+}
+
+type orderRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewOrderRepository(logger *logrus.Logger, db *sql.DB) OrderRepository {
+	return &orderRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// BeginTx implements OrderRepository.
+func (r *orderRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to begin transaction")
+	}
+
+	return tx, nil
+}
+
+// CommitTx implements OrderRepository.
+func (r *orderRepository) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to commit transaction")
+	}
+
+	return nil
+}
+
+// Rollback implements OrderRepository.
+func (r *orderRepository) Rollback(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to rollback transaction")
+	}
+
+	return nil
+}
+
+func (r *orderRepository) findByID(ctx context.Context, ID string, forUpdate bool, tx *sql.Tx) (Order, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			id, payment_method, transaction_id, virtual_account, status, customer_id, customer_name, customer_email,
+			tax_percentage, service_charge_percentage, discount_percentage, service_charge,
+			tax, discount, subtotal, total_amount, created_at, updated_at
+		FROM ticket_order
+		WHERE
+			id = $1
+		LIMIT 1
+	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Order{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting order's prorperties")
+	}
+	defer stmt.Close()
+
+	o, err := r.scan(stmt.QueryRowContext(ctx, ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Order{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("order's properties with id '%s' is not found", ID))
+		}
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return Order{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting order's prorperties")
+	}
+
+	return o, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *orderRepository) scan(row rowScanner) (Order, error) {
+	var o Order
+	var virtualAccount sql.NullString
+	var transactionID sql.NullString
+
+	err := row.Scan(
+		&o.ID, &o.PaymentMethod, &transactionID, &virtualAccount, &o.Status, &o.CustomerID, &o.CustomerName, &o.CustomerEmail,
+		&o.TaxPercentage, &o.ServiceChargePercentage, &o.DiscountPercentage, &o.ServiceCharge,
+		&o.Tax, &o.Discount, &o.Subtotal, &o.TotalAmount, &o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		return Order{}, err
+	}
+
+	if virtualAccount.Valid {
+		o.VirtualAccount = &virtualAccount.String
+	}
+	if transactionID.Valid {
+		o.TransactionID = &transactionID.String
+	}
+
+	return o, nil
+}
+
+// FindByID implements OrderRepository.
+func (r *orderRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (Order, error) {
+	return r.findByID(ctx, ID, false, tx)
+}
+
+// FindByIDForUpdate implements OrderRepository.
+func (r *orderRepository) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (Order, error) {
+	return r.findByID(ctx, ID, true, tx)
+}
+
+// filterCondition returns the where clause and its arguments of the given filter.
+func (r *orderRepository) filterCondition(filter OrderFilter) (string, []interface{}) {
+	conditions := []string{"TRUE"}
+	args := make([]interface{}, 0)
+
+	if filter.OrderID != "" {
+		args = append(args, filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("id = $%d", len(args)))
+	}
+
+	if filter.TransactionID != "" {
+		args = append(args, filter.TransactionID)
+		conditions = append(conditions, fmt.Sprintf("transaction_id = $%d", len(args)))
+	}
+
+	if filter.VirtualAccount != "" {
+		args = append(args, filter.VirtualAccount)
+		conditions = append(conditions, fmt.Sprintf("virtual_account = $%d", len(args)))
+	}
+
+	if filter.CustomerEmail != "" {
+		args = append(args, filter.CustomerEmail)
+		conditions = append(conditions, fmt.Sprintf("LOWER(customer_email) = LOWER($%d)", len(args)))
+	}
+
+	if filter.EventID != "" {
+		args = append(args, filter.EventID)
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM order_item WHERE order_item.order_id = ticket_order.id AND order_item.event_id = $%d)", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// FindMany implements OrderRepository.
+func (r *orderRepository) FindMany(ctx context.Context, filter OrderFilter, offset, limit int64, tx *sql.Tx) ([]Order, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	condition, args := r.filterCondition(filter)
+	args = append(args, offset, limit)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			id, payment_method, transaction_id, virtual_account, status, customer_id, customer_name, customer_email,
+			tax_percentage, service_charge_percentage, discount_percentage, service_charge,
+			tax, discount, subtotal, total_amount, created_at, updated_at
+		FROM ticket_order
+		WHERE
+			%s
+		ORDER BY created_at DESC
+		OFFSET $%d
+		LIMIT $%d
+	`, condition, len(args)-1, len(args))
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]Order, 0)
+	for rows.Next() {
+		o, err := r.scan(rows)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order's prorperties")
+		}
+
+		data = append(data, o)
+	}
+
+	return data, nil
+}
+
+// Count implements OrderRepository.
+func (r *orderRepository) Count(ctx context.Context, filter OrderFilter, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	condition, args := r.filterCondition(filter)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			COUNT(id)
+		FROM ticket_order
+		WHERE
+			%s
+	`, condition)
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting order's prorperties")
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.QueryRowContext(ctx, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting order's prorperties")
+	}
+
+	return count, nil
+}
+
+// Update implements OrderRepository.
+func (r *orderRepository) Update(ctx context.Context, ID string, o Order, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE ticket_order
+		SET
+			status = $1,
+			updated_at = $2
+		WHERE id = $3
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating order's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, o.Status, o.UpdatedAt, ID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating order's prorperties")
+	}
+
+	return nil
+}
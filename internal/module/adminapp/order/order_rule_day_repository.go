@@ -11,8 +11,12 @@ import (
 )
 
 type OrderRuleDayRepository interface {
+	// FindManyByEventID returns the days of the whole event.
 	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleDay, error)
+	// FindManyScopedByEventID returns the days of the shows and ticket stocks of the event.
+	FindManyScopedByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleDay, error)
 	Save(ctx context.Context, rule OrderRuleDay, tx *sql.Tx) error
+	// Delete deletes the days of the whole event, the days of the shows and ticket stocks are kept.
 	Delete(ctx context.Context, eventID string, tx *sql.Tx) error
 }
 
@@ -30,7 +34,7 @@ func (r *orderRuleDayRepository) Delete(ctx context.Context, eventID string, tx
 	}
 
 	query := `
-		DELETE FROM order_rule_day WHERE event_id = $1
+		DELETE FROM order_rule_day WHERE event_id = $1 AND show_id IS NULL AND ticket_stock_id IS NULL
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
@@ -59,10 +63,10 @@ func (r *orderRuleDayRepository) FindManyByEventID(ctx context.Context, eventID
 
 	query := `
 		SELECT 
-			event_id, day
+			event_id, show_id, ticket_stock_id, day
 		FROM order_rule_day
 		WHERE
-			event_id = $1
+			event_id = $1 AND show_id IS NULL AND ticket_stock_id IS NULL
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
@@ -84,7 +88,54 @@ func (r *orderRuleDayRepository) FindManyByEventID(ctx context.Context, eventID
 	for rows.Next() {
 		var rule OrderRuleDay
 
-		err := rows.Scan(&rule.EventID, &rule.Day)
+		err := rows.Scan(&rule.EventID, &rule.ShowID, &rule.TicketStockID, &rule.Day)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule day's prorperties")
+		}
+
+		data = append(data, rule)
+	}
+
+	return data, nil
+}
+
+// FindManyScopedByEventID implements OrderRuleDayRepository.
+func (r *orderRuleDayRepository) FindManyScopedByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleDay, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			event_id, show_id, ticket_stock_id, day
+		FROM order_rule_day
+		WHERE
+			event_id = $1 AND (show_id IS NOT NULL OR ticket_stock_id IS NOT NULL)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule day's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule day's prorperties")
+	}
+
+	defer rows.Close()
+
+	var data = make([]OrderRuleDay, 0)
+	for rows.Next() {
+		var rule OrderRuleDay
+
+		err := rows.Scan(&rule.EventID, &rule.ShowID, &rule.TicketStockID, &rule.Day)
 		if err != nil {
 			r.logger.WithContext(ctx).WithError(err).Error()
 			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule day's prorperties")
@@ -107,11 +158,11 @@ func (r *orderRuleDayRepository) Save(ctx context.Context, rule OrderRuleDay, tx
 	query := `
 		INSERT INTO order_rule_day
 		(
-			event_id, day
+			event_id, show_id, ticket_stock_id, day
 		)
 		VALUES
 		(
-			$1, $2
+			$1, $2, $3, $4
 		)
 	`
 
@@ -122,7 +173,7 @@ func (r *orderRuleDayRepository) Save(ctx context.Context, rule OrderRuleDay, tx
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, rule.EventID, rule.Day)
+	_, err = stmt.ExecContext(ctx, rule.EventID, rule.ShowID, rule.TicketStockID, rule.Day)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving order rule days's prorperties")
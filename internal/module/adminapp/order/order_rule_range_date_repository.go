@@ -12,7 +12,10 @@ import (
 )
 
 type OrderRuleRangeDateRepository interface {
+	// FindByEventID returns the rule of the whole event.
 	FindByEventID(ctx context.Context, eventID string, tx *sql.Tx) (OrderRuleRangeDate, error)
+	// FindManyScopedByEventID returns the rules of the shows and ticket stocks of the event.
+	FindManyScopedByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleRangeDate, error)
 	Save(ctx context.Context, rule OrderRuleRangeDate, tx *sql.Tx) error
 	Update(ctx context.Context, eventID string, rule OrderRuleRangeDate, tx *sql.Tx) error
 }
@@ -46,10 +49,10 @@ func (r *orderRuleRangeDateRepository) FindByEventID(ctx context.Context, eventI
 
 	query := `
 		SELECT 
-			event_id, start_date, end_date
+			event_id, show_id, ticket_stock_id, start_date, end_date
 		FROM order_rule_range_date
 		WHERE
-			event_id = $1
+			event_id = $1 AND show_id IS NULL AND ticket_stock_id IS NULL
 		LIMIT 1
 	`
 
@@ -64,7 +67,7 @@ func (r *orderRuleRangeDateRepository) FindByEventID(ctx context.Context, eventI
 
 	var data OrderRuleRangeDate
 	err = row.Scan(
-		&data.EventID, &data.StartDate, &data.EndDate,
+		&data.EventID, &data.ShowID, &data.TicketStockID, &data.StartDate, &data.EndDate,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -77,6 +80,50 @@ func (r *orderRuleRangeDateRepository) FindByEventID(ctx context.Context, eventI
 	return data, nil
 }
 
+// FindManyScopedByEventID implements OrderRuleRangeDateRepository.
+func (r *orderRuleRangeDateRepository) FindManyScopedByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleRangeDate, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			event_id, show_id, ticket_stock_id, start_date, end_date
+		FROM order_rule_range_date
+		WHERE
+			event_id = $1 AND (show_id IS NOT NULL OR ticket_stock_id IS NOT NULL)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule range date's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule range date's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]OrderRuleRangeDate, 0)
+	for rows.Next() {
+		var rule OrderRuleRangeDate
+		err := rows.Scan(&rule.EventID, &rule.ShowID, &rule.TicketStockID, &rule.StartDate, &rule.EndDate)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule range date's prorperties")
+		}
+		data = append(data, rule)
+	}
+
+	return data, nil
+}
+
 // Save implements OrderRuleRangeDateRepository.
 func (r *orderRuleRangeDateRepository) Save(ctx context.Context, rule OrderRuleRangeDate, tx *sql.Tx) error {
 	var cmd sqlCommand = r.db
@@ -88,11 +135,11 @@ func (r *orderRuleRangeDateRepository) Save(ctx context.Context, rule OrderRuleR
 	query := `
 		INSERT INTO order_rule_range_date
 		(
-			event_id, start_date, end_date
+			event_id, show_id, ticket_stock_id, start_date, end_date
 		)
 		VALUES
 		(
-			$1, $2, $3
+			$1, $2, $3, $4, $5
 		)
 	`
 
@@ -103,7 +150,7 @@ func (r *orderRuleRangeDateRepository) Save(ctx context.Context, rule OrderRuleR
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, rule.EventID, rule.StartDate, rule.EndDate)
+	_, err = stmt.ExecContext(ctx, rule.EventID, rule.ShowID, rule.TicketStockID, rule.StartDate, rule.EndDate)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving order rule range date's prorperties")
@@ -123,9 +170,9 @@ func (r *orderRuleRangeDateRepository) Update(ctx context.Context, eventID strin
 	query := `
 		UPDATE order_rule_range_date
 		SET
-			start_date = $2,
-			end_date = $3,
-		WHERE event_id = $4
+			start_date = $1,
+			end_date = $2
+		WHERE event_id = $3 AND show_id IS NULL AND ticket_stock_id IS NULL
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
@@ -135,7 +182,7 @@ func (r *orderRuleRangeDateRepository) Update(ctx context.Context, eventID strin
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, rule.StartDate, rule.EndDate)
+	_, err = stmt.ExecContext(ctx, rule.StartDate, rule.EndDate, eventID)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating order rule range date's prorperties")
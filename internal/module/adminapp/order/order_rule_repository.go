@@ -0,0 +1,155 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type OrderRuleRepository interface {
+	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRule, error)
+	// Save appends the rule after the last rule of the event.
+	Save(ctx context.Context, rule OrderRule, tx *sql.Tx) (OrderRule, error)
+	Delete(ctx context.Context, eventID string, ID int64, tx *sql.Tx) error
+}
+
+type orderRuleRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewOrderRuleRepository(logger *logrus.Logger, db *sql.DB) OrderRuleRepository {
+	return &orderRuleRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// FindManyByEventID implements OrderRuleRepository.
+func (r *orderRuleRepository) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRule, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			id, event_id, type, config, position, created_by, created_at
+		FROM order_rule
+		WHERE
+			event_id = $1
+		ORDER BY position ASC
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]OrderRule, 0)
+	for rows.Next() {
+		var rule OrderRule
+		var config []byte
+
+		if err := rows.Scan(&rule.ID, &rule.EventID, &rule.Type, &config, &rule.Position, &rule.CreatedBy, &rule.CreatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule's prorperties")
+		}
+		rule.Config = config
+
+		data = append(data, rule)
+	}
+
+	return data, nil
+}
+
+// Save implements OrderRuleRepository.
+func (r *orderRuleRepository) Save(ctx context.Context, rule OrderRule, tx *sql.Tx) (OrderRule, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		INSERT INTO order_rule
+		(
+			event_id, type, config, position, created_by, created_at
+		)
+		SELECT
+			$1, $2, $3, COALESCE(MAX(position), 0) + 1, $4, $5
+		FROM order_rule
+		WHERE
+			event_id = $1
+		RETURNING id, position
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return OrderRule{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving order rule's prorperties")
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, rule.EventID, rule.Type, []byte(rule.Config), rule.CreatedBy, rule.CreatedAt)
+
+	if err := row.Scan(&rule.ID, &rule.Position); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return OrderRule{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving order rule's prorperties")
+	}
+
+	return rule, nil
+}
+
+// Delete implements OrderRuleRepository.
+func (r *orderRuleRepository) Delete(ctx context.Context, eventID string, ID int64, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		DELETE FROM order_rule WHERE event_id = $1 AND id = $2
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting order rule's prorperties")
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, eventID, ID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting order rule's prorperties")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while deleting order rule's prorperties")
+	}
+
+	if affected == 0 {
+		return errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("order rule's properties with id '%d' is not found", ID))
+	}
+
+	return nil
+}
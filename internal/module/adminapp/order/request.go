@@ -0,0 +1,28 @@
+package order
+
+type GetManyOrderRequest struct {
+	Page           int64  `validate:"required,min=1"`
+	Size           int64  `validate:"required,min=1,max=100"`
+	OrderID        string `validate:"-"`
+	TransactionID  string `validate:"-"`
+	VirtualAccount string `validate:"-"`
+	CustomerEmail  string `validate:"omitempty,email"`
+	EventID        string `validate:"-"`
+	Status         string `validate:"omitempty,oneof=WAITING_FOR_PAYMENT PAID EXPIRED"`
+}
+
+type MarkOrderPaidRequest struct {
+	OrderID  string `json:"-" validate:"required"`
+	Reason   string `json:"reason" validate:"required"`
+	ProofURL string `json:"proof_url" validate:"required,url"`
+}
+
+type ForceExpireOrderRequest struct {
+	OrderID string `json:"-" validate:"required"`
+	Reason  string `json:"reason" validate:"required"`
+}
+
+type ResendTicketRequest struct {
+	OrderID string `json:"-" validate:"required"`
+	Reason  string `json:"reason" validate:"required"`
+}
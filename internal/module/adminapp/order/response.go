@@ -0,0 +1,108 @@
+package order
+
+import "time"
+
+type ItemResponse struct {
+	ID            int64   `json:"id"`
+	TicketStockID string  `json:"ticket_stock_id"`
+	ShowID        string  `json:"show_id"`
+	EventID       string  `json:"event_id"`
+	EventName     string  `json:"event_name"`
+	ShowVenue     string  `json:"show_venue"`
+	Tier          string  `json:"tier"`
+	Price         float64 `json:"price"`
+	Quantity      int64   `json:"quantity"`
+}
+
+type OrderAuditResponse struct {
+	ID             int64     `json:"id"`
+	Action         string    `json:"action"`
+	Reason         string    `json:"reason"`
+	Proof          *string   `json:"proof"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	CreatedBy      int64     `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type OrderResponse struct {
+	ID                      string               `json:"id"`
+	PaymentMethod           string               `json:"payment_method"`
+	VirtualAccount          *string              `json:"virtual_account"`
+	TransactionID           *string              `json:"transaction_id"`
+	Status                  string               `json:"status"`
+	CustomerID              int64                `json:"customer_id"`
+	CustomerName            string               `json:"customer_name"`
+	CustomerEmail           string               `json:"customer_email"`
+	TaxPercentage           float64              `json:"tax_percentage"`
+	ServiceChargePercentage float64              `json:"service_charge_percentage"`
+	DiscountPercentage      float64              `json:"discount_percentage"`
+	ServiceCharge           float64              `json:"service_charge"`
+	Tax                     float64              `json:"tax"`
+	Discount                float64              `json:"discount"`
+	Items                   []ItemResponse       `json:"items,omitempty"`
+	Audits                  []OrderAuditResponse `json:"audits,omitempty"`
+	Subtotal                float64              `json:"subtotal"`
+	TotalAmount             float64              `json:"total_amount"`
+	CreatedAt               time.Time            `json:"created_at"`
+	UpdatedAt               time.Time            `json:"updated_at"`
+}
+
+func (r *OrderResponse) PopulateFromEntity(o Order) {
+	r.ID = o.ID
+	r.PaymentMethod = o.PaymentMethod
+	r.VirtualAccount = o.VirtualAccount
+	r.TransactionID = o.TransactionID
+	r.Status = o.Status
+	r.CustomerID = o.CustomerID
+	r.CustomerName = o.CustomerName
+	r.CustomerEmail = o.CustomerEmail
+	r.TaxPercentage = o.TaxPercentage
+	r.ServiceChargePercentage = o.ServiceChargePercentage
+	r.DiscountPercentage = o.DiscountPercentage
+	r.ServiceCharge = o.ServiceCharge
+	r.Tax = o.Tax
+	r.Discount = o.Discount
+	r.Subtotal = o.Subtotal
+	r.TotalAmount = o.TotalAmount
+	r.CreatedAt = o.CreatedAt
+	r.UpdatedAt = o.UpdatedAt
+
+	if o.Items != nil {
+		r.Items = make([]ItemResponse, len(o.Items))
+		for k, v := range o.Items {
+			r.Items[k] = ItemResponse{
+				ID:            v.ID,
+				TicketStockID: v.TicketStockID,
+				ShowID:        v.ShowID,
+				EventID:       v.EventID,
+				EventName:     v.EventName,
+				ShowVenue:     v.ShowVenue,
+				Tier:          v.Tier,
+				Price:         v.Price,
+				Quantity:      v.Quantity,
+			}
+		}
+	}
+}
+
+func (r *OrderResponse) PopulateAudits(audits []OrderAudit) {
+	r.Audits = make([]OrderAuditResponse, len(audits))
+	for k, v := range audits {
+		r.Audits[k] = OrderAuditResponse{
+			ID:             v.ID,
+			Action:         v.Action,
+			Reason:         v.Reason,
+			Proof:          v.Proof,
+			PreviousStatus: v.PreviousStatus,
+			Status:         v.Status,
+			CreatedBy:      v.CreatedBy,
+			CreatedAt:      v.CreatedAt,
+		}
+	}
+}
+
+type GetManyOrderResponse struct {
+	Total  int64           `json:"total"`
+	Orders []OrderResponse `json:"orders"`
+}
@@ -0,0 +1,297 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/outbox"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+	"golang.org/x/sync/errgroup"
+)
+
+type OrderUseCase interface {
+	GetManyOrder(ctx context.Context, req GetManyOrderRequest) (GetManyOrderResponse, error)
+	GetOrder(ctx context.Context, ID string) (OrderResponse, error)
+	MarkOrderPaid(ctx context.Context, req MarkOrderPaidRequest) (OrderResponse, error)
+	ForceExpireOrder(ctx context.Context, req ForceExpireOrderRequest) (OrderResponse, error)
+	ResendTicket(ctx context.Context, req ResendTicketRequest) (OrderResponse, error)
+}
+
+type OrderUseCaseProperty struct {
+	AppName              string
+	Logger               *logrus.Logger
+	Timeout              time.Duration
+	OrderRepository      OrderRepository
+	ItemRepository       ItemRepository
+	OrderAuditRepository OrderAuditRepository
+	Outbox               outbox.Outbox
+}
+
+type orderUseCase struct {
+	appName              string
+	logger               *logrus.Logger
+	timeout              time.Duration
+	orderRepository      OrderRepository
+	itemRepository       ItemRepository
+	orderAuditRepository OrderAuditRepository
+	outbox               outbox.Outbox
+}
+
+func NewOrderUseCase(props OrderUseCaseProperty) OrderUseCase {
+	return &orderUseCase{
+		appName:              props.AppName,
+		logger:               props.Logger,
+		timeout:              props.Timeout,
+		orderRepository:      props.OrderRepository,
+		itemRepository:       props.ItemRepository,
+		orderAuditRepository: props.OrderAuditRepository,
+		outbox:               props.Outbox,
+	}
+}
+
+// GetManyOrder implements OrderUseCase.
+func (u *orderUseCase) GetManyOrder(ctx context.Context, req GetManyOrderRequest) (GetManyOrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	offset := (req.Page - 1) * req.Size
+	limit := req.Size
+	filter := OrderFilter{
+		OrderID:        req.OrderID,
+		TransactionID:  req.TransactionID,
+		VirtualAccount: req.VirtualAccount,
+		CustomerEmail:  req.CustomerEmail,
+		EventID:        req.EventID,
+		Status:         req.Status,
+	}
+
+	var bunchOfOrders []Order
+	var total int64
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		count, err := u.orderRepository.Count(gctx, filter, nil)
+		if err != nil {
+			return err
+		}
+		total = count
+
+		return nil
+	})
+	g.Go(func() error {
+		orders, err := u.orderRepository.FindMany(gctx, filter, offset, limit, nil)
+		if err != nil {
+			return err
+		}
+		bunchOfOrders = orders
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return GetManyOrderResponse{}, err
+	}
+
+	resp := GetManyOrderResponse{
+		Total:  total,
+		Orders: make([]OrderResponse, len(bunchOfOrders)),
+	}
+	for k, v := range bunchOfOrders {
+		resp.Orders[k].PopulateFromEntity(v)
+	}
+
+	return resp, nil
+}
+
+// GetOrder implements OrderUseCase.
+func (u *orderUseCase) GetOrder(ctx context.Context, ID string) (OrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	o, err := u.orderRepository.FindByID(ctx, ID, nil)
+	if err != nil {
+		return OrderResponse{}, err
+	}
+
+	items, err := u.itemRepository.FindManyByOrderID(ctx, o.ID, nil)
+	if err != nil {
+		return OrderResponse{}, err
+	}
+	o.Items = items
+
+	audits, err := u.orderAuditRepository.FindManyByOrderID(ctx, o.ID, nil)
+	if err != nil {
+		return OrderResponse{}, err
+	}
+
+	resp := OrderResponse{}
+	resp.PopulateFromEntity(o)
+	resp.PopulateAudits(audits)
+
+	return resp, nil
+}
+
+// intervention describes a manual action of an admin on an order.
+type intervention struct {
+	orderID string
+	action  string
+	reason  string
+	proof   *string
+	// from is the status that the order must have for the action to be applied.
+	from string
+	// to is the status of the order after the action, empty keeps the current status.
+	to string
+	// event returns the event of the intervention, it is written to the outbox within the transaction and published
+	// once the transaction is committed.
+	event func(o Order, adminID int64, now time.Time) (topic string, e pubsub.Envelope)
+}
+
+// intervene locks the order, applies the intervention and writes it to the audit trail in a single transaction.
+func (u *orderUseCase) intervene(ctx context.Context, i intervention) (OrderResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return OrderResponse{}, err
+	}
+
+	tx, err := u.orderRepository.BeginTx(ctx)
+	if err != nil {
+		return OrderResponse{}, err
+	}
+
+	o, err := u.orderRepository.FindByIDForUpdate(ctx, i.orderID, tx)
+	if err != nil {
+		u.orderRepository.Rollback(ctx, tx)
+		return OrderResponse{}, err
+	}
+
+	if o.Status != i.from {
+		u.orderRepository.Rollback(ctx, tx)
+		return OrderResponse{}, errors.New(http.StatusConflict, status.CONFLICT, fmt.Sprintf("order with status '%s' can not be processed, the status must be '%s'", o.Status, i.from))
+	}
+
+	items, err := u.itemRepository.FindManyByOrderID(ctx, o.ID, tx)
+	if err != nil {
+		u.orderRepository.Rollback(ctx, tx)
+		return OrderResponse{}, err
+	}
+	o.Items = items
+
+	now := time.Now()
+	previousStatus := o.Status
+	if i.to != "" && i.to != o.Status {
+		o.Status = i.to
+		o.UpdatedAt = now
+
+		if err := u.orderRepository.Update(ctx, o.ID, o, tx); err != nil {
+			u.orderRepository.Rollback(ctx, tx)
+			return OrderResponse{}, err
+		}
+	}
+
+	if err := u.orderAuditRepository.Save(ctx, OrderAudit{
+		OrderID:        o.ID,
+		Action:         i.action,
+		Reason:         i.reason,
+		Proof:          i.proof,
+		PreviousStatus: previousStatus,
+		Status:         o.Status,
+		CreatedBy:      acc.ID,
+		CreatedAt:      now,
+	}, tx); err != nil {
+		u.orderRepository.Rollback(ctx, tx)
+		return OrderResponse{}, err
+	}
+
+	var outboxID int64
+	if i.event != nil {
+		messageHeader := pubsub.MessageHeaders{
+			"origin": u.appName,
+		}
+		topic, e := i.event(o, acc.ID, now)
+		outboxID, err = u.outbox.Add(ctx, topic, u.messageKey(o), messageHeader, e, tx)
+		if err != nil {
+			u.orderRepository.Rollback(ctx, tx)
+			return OrderResponse{}, err
+		}
+	}
+
+	if err := u.orderRepository.CommitTx(ctx, tx); err != nil {
+		return OrderResponse{}, err
+	}
+
+	// the intervention is already applied, a message that could not be published now, or that waits for an earlier
+	// message of the order, is published by the next relay.
+	if outboxID != 0 {
+		if err := u.outbox.Relay(ctx, outboxID); err != nil {
+			u.logger.WithContext(ctx).WithError(err).WithField("order_id", o.ID).Warn("order event is left for the next relay")
+		}
+	}
+
+	resp := OrderResponse{}
+	resp.PopulateFromEntity(o)
+
+	return resp, nil
+}
+
+func (u *orderUseCase) messageKey(o Order) string {
+	if o.TransactionID != nil {
+		return *o.TransactionID
+	}
+
+	return o.ID
+}
+
+// MarkOrderPaid implements OrderUseCase. It is used when the payment is settled outside of the payment gateway.
+func (u *orderUseCase) MarkOrderPaid(ctx context.Context, req MarkOrderPaidRequest) (OrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.intervene(ctx, intervention{
+		orderID: req.OrderID,
+		action:  AuditActionMarkPaid,
+		reason:  req.Reason,
+		proof:   &req.ProofURL,
+		from:    StatusWaitingForPayment,
+		to:      StatusPaid,
+		event: func(o Order, adminID int64, now time.Time) (string, pubsub.Envelope) {
+			return contract.TopicOrderPaid, pubsub.NewEnvelope(u.appName, contract.OrderPaidV1, o.ID, newOrderPaidEvent(o))
+		},
+	})
+}
+
+// ForceExpireOrder implements OrderUseCase.
+func (u *orderUseCase) ForceExpireOrder(ctx context.Context, req ForceExpireOrderRequest) (OrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.intervene(ctx, intervention{
+		orderID: req.OrderID,
+		action:  AuditActionForceExpire,
+		reason:  req.Reason,
+		from:    StatusWaitingForPayment,
+		to:      StatusExpired,
+	})
+}
+
+// ResendTicket implements OrderUseCase. The ticket email itself is sent by the consumer of the event.
+func (u *orderUseCase) ResendTicket(ctx context.Context, req ResendTicketRequest) (OrderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.intervene(ctx, intervention{
+		orderID: req.OrderID,
+		action:  AuditActionResendTicket,
+		reason:  req.Reason,
+		from:    StatusPaid,
+		event: func(o Order, adminID int64, now time.Time) (string, pubsub.Envelope) {
+			return contract.TopicOrderTicketResend, pubsub.NewEnvelope(u.appName, contract.OrderTicketResendV1, o.ID, newOrderTicketResendEvent(o, req.Reason, adminID, now))
+		},
+	})
+}
@@ -0,0 +1,266 @@
+package order_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+// ledger records an intervention on an order: the steps in the order they are taken, the orders once they are
+// committed, the audits and the messages of the outbox.
+type ledger struct {
+	steps    []string
+	orders   map[string]order.Order
+	pending  map[string]order.Order
+	audits   []order.OrderAudit
+	messages []outboxMessage
+	relayErr error
+}
+
+type outboxMessage struct {
+	topic string
+	key   string
+	e     pubsub.Envelope
+}
+
+type orderRepositoryStandIn struct {
+	order.OrderRepository
+	*ledger
+}
+
+func (r orderRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	r.pending = make(map[string]order.Order)
+
+	return nil, nil
+}
+
+func (r orderRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	for ID, o := range r.pending {
+		r.orders[ID] = o
+	}
+	r.steps = append(r.steps, "commit")
+
+	return nil
+}
+
+func (r orderRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	r.pending = nil
+	r.steps = append(r.steps, "rollback")
+
+	return nil
+}
+
+func (r orderRepositoryStandIn) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (order.Order, error) {
+	o, ok := r.orders[ID]
+	if !ok {
+		return order.Order{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "order is not found")
+	}
+
+	return o, nil
+}
+
+func (r orderRepositoryStandIn) Update(ctx context.Context, ID string, o order.Order, tx *sql.Tx) error {
+	r.pending[ID] = o
+
+	return nil
+}
+
+type itemRepositoryStandIn struct {
+	order.ItemRepository
+}
+
+func (r itemRepositoryStandIn) FindManyByOrderID(ctx context.Context, orderID string, tx *sql.Tx) ([]order.Item, error) {
+	return []order.Item{{OrderID: orderID, TicketStockID: "gold", Quantity: 2}}, nil
+}
+
+type orderAuditRepositoryStandIn struct {
+	order.OrderAuditRepository
+	*ledger
+}
+
+func (r orderAuditRepositoryStandIn) Save(ctx context.Context, a order.OrderAudit, tx *sql.Tx) error {
+	r.audits = append(r.audits, a)
+
+	return nil
+}
+
+type outboxStandIn struct {
+	*ledger
+}
+
+func (o outboxStandIn) Add(ctx context.Context, topic string, key string, headers pubsub.MessageHeaders, e pubsub.Envelope, tx *sql.Tx) (int64, error) {
+	o.messages = append(o.messages, outboxMessage{topic: topic, key: key, e: e})
+	o.steps = append(o.steps, "add "+topic)
+
+	return int64(len(o.messages)), nil
+}
+
+func (o outboxStandIn) Relay(ctx context.Context, IDs ...int64) error {
+	o.steps = append(o.steps, "relay")
+
+	return o.relayErr
+}
+
+func (o outboxStandIn) Run(ctx context.Context, interval time.Duration) {}
+
+// newOrderUseCase returns a use case over a single order of the given status, along with the ledger of its
+// interventions.
+func newOrderUseCase(orderStatus string) (order.OrderUseCase, *ledger) {
+	transactionID := "trx-1"
+	l := &ledger{orders: map[string]order.Order{
+		"order-1": {ID: "order-1", TransactionID: &transactionID, Status: orderStatus, CustomerID: 3, CustomerEmail: "customer@example.com"},
+	}}
+
+	return order.NewOrderUseCase(order.OrderUseCaseProperty{
+		AppName:              "tm-order",
+		Logger:               standin.Logger(),
+		Timeout:              5 * time.Second,
+		OrderRepository:      orderRepositoryStandIn{ledger: l},
+		ItemRepository:       itemRepositoryStandIn{},
+		OrderAuditRepository: orderAuditRepositoryStandIn{ledger: l},
+		Outbox:               outboxStandIn{ledger: l},
+	}), l
+}
+
+func TestOrderUseCaseIntervention(t *testing.T) {
+	t.Run("mark paid writes order paid to the outbox and relays it after the commit", func(t *testing.T) {
+		u, l := newOrderUseCase(order.StatusWaitingForPayment)
+
+		resp, err := u.MarkOrderPaid(standin.AdminContext(), order.MarkOrderPaidRequest{OrderID: "order-1", Reason: "bank transfer", ProofURL: "https://example.com/proof.png"})
+		assert.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, resp.Status)
+		assert.Equal(t, order.StatusPaid, l.orders["order-1"].Status)
+		assert.Equal(t, []string{"add " + contract.TopicOrderPaid, "commit", "relay"}, l.steps)
+
+		if assert.Len(t, l.messages, 1) {
+			assert.Equal(t, "trx-1", l.messages[0].key)
+			assert.Equal(t, contract.OrderPaidV1.Type, l.messages[0].e.Type)
+		}
+
+		if assert.Len(t, l.audits, 1) {
+			a := l.audits[0]
+			assert.Equal(t, order.AuditActionMarkPaid, a.Action)
+			assert.Equal(t, order.StatusWaitingForPayment, a.PreviousStatus)
+			assert.Equal(t, order.StatusPaid, a.Status)
+			assert.Equal(t, standin.AdminID, a.CreatedBy)
+			if assert.NotNil(t, a.Proof) {
+				assert.Equal(t, "https://example.com/proof.png", *a.Proof)
+			}
+		}
+	})
+
+	t.Run("a failed relay does not fail the intervention", func(t *testing.T) {
+		u, l := newOrderUseCase(order.StatusWaitingForPayment)
+		l.relayErr = errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "broker is down")
+
+		_, err := u.MarkOrderPaid(standin.AdminContext(), order.MarkOrderPaidRequest{OrderID: "order-1", Reason: "bank transfer", ProofURL: "https://example.com/proof.png"})
+		assert.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, l.orders["order-1"].Status)
+		assert.Len(t, l.messages, 1, "the message is left in the outbox for the next relay")
+	})
+
+	t.Run("force expire publishes nothing", func(t *testing.T) {
+		u, l := newOrderUseCase(order.StatusWaitingForPayment)
+
+		resp, err := u.ForceExpireOrder(standin.AdminContext(), order.ForceExpireOrderRequest{OrderID: "order-1", Reason: "fraud"})
+		assert.NoError(t, err)
+		assert.Equal(t, order.StatusExpired, resp.Status)
+		assert.Equal(t, []string{"commit"}, l.steps)
+		if assert.Len(t, l.audits, 1) {
+			assert.Equal(t, order.AuditActionForceExpire, l.audits[0].Action)
+		}
+	})
+
+	t.Run("resend ticket keeps the status and writes the resend event to the outbox", func(t *testing.T) {
+		u, l := newOrderUseCase(order.StatusPaid)
+
+		resp, err := u.ResendTicket(standin.AdminContext(), order.ResendTicketRequest{OrderID: "order-1", Reason: "email bounced"})
+		assert.NoError(t, err)
+		assert.Equal(t, order.StatusPaid, resp.Status)
+		assert.Equal(t, []string{"add " + contract.TopicOrderTicketResend, "commit", "relay"}, l.steps)
+
+		if assert.Len(t, l.messages, 1) {
+			assert.Equal(t, contract.OrderTicketResendV1.Type, l.messages[0].e.Type)
+			resend, ok := l.messages[0].e.Data.(contract.OrderTicketResend)
+			if assert.True(t, ok) {
+				assert.Equal(t, "email bounced", resend.Reason)
+				assert.Equal(t, standin.AdminID, resend.RequestedBy)
+			}
+		}
+
+		if assert.Len(t, l.audits, 1) {
+			assert.Equal(t, order.StatusPaid, l.audits[0].PreviousStatus)
+			assert.Equal(t, order.StatusPaid, l.audits[0].Status)
+		}
+	})
+
+	testCases := []struct {
+		name        string
+		orderStatus string
+		intervene   func(u order.OrderUseCase) error
+	}{
+		{
+			name:        "a paid order can not be marked as paid again",
+			orderStatus: order.StatusPaid,
+			intervene: func(u order.OrderUseCase) error {
+				_, err := u.MarkOrderPaid(standin.AdminContext(), order.MarkOrderPaidRequest{OrderID: "order-1", Reason: "bank transfer", ProofURL: "https://example.com/proof.png"})
+				return err
+			},
+		},
+		{
+			name:        "an expired order can not be marked as paid",
+			orderStatus: order.StatusExpired,
+			intervene: func(u order.OrderUseCase) error {
+				_, err := u.MarkOrderPaid(standin.AdminContext(), order.MarkOrderPaidRequest{OrderID: "order-1", Reason: "bank transfer", ProofURL: "https://example.com/proof.png"})
+				return err
+			},
+		},
+		{
+			name:        "a paid order can not be expired",
+			orderStatus: order.StatusPaid,
+			intervene: func(u order.OrderUseCase) error {
+				_, err := u.ForceExpireOrder(standin.AdminContext(), order.ForceExpireOrderRequest{OrderID: "order-1", Reason: "fraud"})
+				return err
+			},
+		},
+		{
+			name:        "the tickets of an unpaid order can not be resent",
+			orderStatus: order.StatusWaitingForPayment,
+			intervene: func(u order.OrderUseCase) error {
+				_, err := u.ResendTicket(standin.AdminContext(), order.ResendTicketRequest{OrderID: "order-1", Reason: "email bounced"})
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, l := newOrderUseCase(tc.orderStatus)
+
+			err := tc.intervene(u)
+			assert.Equal(t, http.StatusConflict, errors.Destruct(err).HTTPStatusCode)
+			assert.Equal(t, tc.orderStatus, l.orders["order-1"].Status)
+			assert.Equal(t, []string{"rollback"}, l.steps)
+			assert.Empty(t, l.audits)
+			assert.Empty(t, l.messages)
+		})
+	}
+
+	t.Run("an intervention needs a signed in admin", func(t *testing.T) {
+		u, l := newOrderUseCase(order.StatusWaitingForPayment)
+
+		_, err := u.ForceExpireOrder(context.Background(), order.ForceExpireOrderRequest{OrderID: "order-1", Reason: "fraud"})
+		assert.Error(t, err)
+		assert.Empty(t, l.steps)
+	})
+}
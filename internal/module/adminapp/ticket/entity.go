@@ -2,6 +2,14 @@ package ticket
 
 import "time"
 
+const (
+	JournalActionIncreaseAllocation string = "INCREASE_ALLOCATION"
+	JournalActionDecreaseAllocation string = "DECREASE_ALLOCATION"
+	JournalActionTransferIn         string = "TRANSFER_IN"
+	JournalActionTransferOut        string = "TRANSFER_OUT"
+	JournalActionChangePrice        string = "CHANGE_PRICE"
+)
+
 type TicketStock struct {
 	EventID         string
 	ShowID          string
@@ -14,12 +22,22 @@ type TicketStock struct {
 	LastStockUpdate time.Time
 }
 
+// Available returns the number of tickets that can still be acquired.
+func (ts TicketStock) Available() int64 {
+	return ts.Allocation - ts.Acquired
+}
+
+// TicketStockJournal records a change of a ticket stock. Stock is the allocation after the change and
+// Quantity is the amount that is moved by the change.
 type TicketStockJournal struct {
 	TicketStockID string
 	ID            int
 	Action        string
+	Quantity      int64
 	Stock         int64
+	Price         float64
 	Description   string
+	CreatedBy     int64
 	CreatedAt     time.Time
 }
 
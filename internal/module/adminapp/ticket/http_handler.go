@@ -0,0 +1,258 @@
+package ticket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	SessionMiddleware *middleware.AdminSession
+	Validate          *validator.Validate
+	TicketUseCase     TicketUseCase
+}
+
+func InitHTTPHandler(router *mux.Router, adminSession *middleware.AdminSession, validate *validator.Validate, ticketUseCase TicketUseCase) {
+	handler := &HTTPHandler{
+		Validate:      validate,
+		TicketUseCase: ticketUseCase,
+	}
+
+	router.HandleFunc("/tm-order/v1/adminapp/ticket-stocks/transfer", publicMiddleware.SetRouteChain(handler.TransferAllocation, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/ticket-stocks/{id}/allocation/increase", publicMiddleware.SetRouteChain(handler.IncreaseAllocation, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/ticket-stocks/{id}/allocation/decrease", publicMiddleware.SetRouteChain(handler.DecreaseAllocation, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/adminapp/ticket-stocks/{id}/price", publicMiddleware.SetRouteChain(handler.ChangePrice, adminSession.Verify, adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager))).Methods(http.MethodPatch)
+	router.HandleFunc("/tm-order/v1/adminapp/ticket-stocks/{id}/journals", publicMiddleware.SetRouteChain(handler.GetManyJournal, adminSession.Verify)).Methods(http.MethodGet)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+func (handler HTTPHandler) IncreaseAllocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := AdjustAllocationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.TicketStockID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.TicketUseCase.IncreaseAllocation(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket allocation has been successfully increased",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) DecreaseAllocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := AdjustAllocationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.TicketStockID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.TicketUseCase.DecreaseAllocation(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket allocation has been successfully decreased",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) TransferAllocation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := TransferAllocationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.TicketUseCase.TransferAllocation(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket allocation has been successfully transferred",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) ChangePrice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ChangePriceRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+	req.TicketStockID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.TicketUseCase.ChangePrice(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "ticket price has been successfully changed",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetManyJournal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := GetManyJournalRequest{}
+	req.TicketStockID = mux.Vars(r)["id"]
+	req.Page, _ = strconv.ParseInt(qs.Get("page"), 10, 64)
+	req.Size, _ = strconv.ParseInt(qs.Get("size"), 10, 64)
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.TicketUseCase.GetManyJournal(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of ticket stock journals",
+		Data:    resp,
+	})
+}
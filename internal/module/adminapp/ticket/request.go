@@ -0,0 +1,26 @@
+package ticket
+
+type AdjustAllocationRequest struct {
+	TicketStockID string `json:"-" validate:"required"`
+	Quantity      int64  `json:"quantity" validate:"required,gt=0"`
+	Description   string `json:"description" validate:"required"`
+}
+
+type TransferAllocationRequest struct {
+	FromTicketStockID string `json:"from_ticket_stock_id" validate:"required"`
+	ToTicketStockID   string `json:"to_ticket_stock_id" validate:"required,nefield=FromTicketStockID"`
+	Quantity          int64  `json:"quantity" validate:"required,gt=0"`
+	Description       string `json:"description" validate:"required"`
+}
+
+type ChangePriceRequest struct {
+	TicketStockID string  `json:"-" validate:"required"`
+	Price         float64 `json:"price" validate:"required,gt=0"`
+	Description   string  `json:"description" validate:"required"`
+}
+
+type GetManyJournalRequest struct {
+	TicketStockID string `validate:"required"`
+	Page          int64  `validate:"required,min=1"`
+	Size          int64  `validate:"required,min=1,max=100"`
+}
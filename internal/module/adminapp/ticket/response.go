@@ -0,0 +1,63 @@
+package ticket
+
+import "time"
+
+type TicketStockResponse struct {
+	ID              string    `json:"id"`
+	EventID         string    `json:"event_id"`
+	ShowID          string    `json:"show_id"`
+	OnlineFor       *string   `json:"online_for"`
+	Tier            string    `json:"tier"`
+	Allocation      int64     `json:"allocation"`
+	Price           float64   `json:"price"`
+	Acquired        int64     `json:"acquired"`
+	Available       int64     `json:"available"`
+	LastStockUpdate time.Time `json:"last_stock_update"`
+}
+
+func (r *TicketStockResponse) PopulateFromEntity(ts TicketStock) {
+	r.ID = ts.ID
+	r.EventID = ts.EventID
+	r.ShowID = ts.ShowID
+	r.OnlineFor = ts.OnlineFor
+	r.Tier = ts.Tier
+	r.Allocation = ts.Allocation
+	r.Price = ts.Price
+	r.Acquired = ts.Acquired
+	r.Available = ts.Available()
+	r.LastStockUpdate = ts.LastStockUpdate
+}
+
+type TransferAllocationResponse struct {
+	From TicketStockResponse `json:"from"`
+	To   TicketStockResponse `json:"to"`
+}
+
+type TicketStockJournalResponse struct {
+	ID            int       `json:"id"`
+	TicketStockID string    `json:"ticket_stock_id"`
+	Action        string    `json:"action"`
+	Quantity      int64     `json:"quantity"`
+	Stock         int64     `json:"stock"`
+	Price         float64   `json:"price"`
+	Description   string    `json:"description"`
+	CreatedBy     int64     `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (r *TicketStockJournalResponse) PopulateFromEntity(j TicketStockJournal) {
+	r.ID = j.ID
+	r.TicketStockID = j.TicketStockID
+	r.Action = j.Action
+	r.Quantity = j.Quantity
+	r.Stock = j.Stock
+	r.Price = j.Price
+	r.Description = j.Description
+	r.CreatedBy = j.CreatedBy
+	r.CreatedAt = j.CreatedAt
+}
+
+type GetManyJournalResponse struct {
+	Total    int64                        `json:"total"`
+	Journals []TicketStockJournalResponse `json:"journals"`
+}
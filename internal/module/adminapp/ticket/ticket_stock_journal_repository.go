@@ -0,0 +1,144 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type TicketStockJournalRepository interface {
+	Save(ctx context.Context, j TicketStockJournal, tx *sql.Tx) error
+	FindManyByTicketStockID(ctx context.Context, ticketStockID string, offset, limit int64, tx *sql.Tx) ([]TicketStockJournal, error)
+	CountByTicketStockID(ctx context.Context, ticketStockID string, tx *sql.Tx) (int64, error)
+}
+
+type ticketStockJournalRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewTicketStockJournalRepository(logger *logrus.Logger, db *sql.DB) TicketStockJournalRepository {
+	return &ticketStockJournalRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// CountByTicketStockID implements TicketStockJournalRepository.
+func (r *ticketStockJournalRepository) CountByTicketStockID(ctx context.Context, ticketStockID string, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			COUNT(id)
+		FROM ticket_stock_journal
+		WHERE
+			ticket_stock_id = $1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting ticket stock journal's prorperties")
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.QueryRowContext(ctx, ticketStockID).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting ticket stock journal's prorperties")
+	}
+
+	return count, nil
+}
+
+// FindManyByTicketStockID implements TicketStockJournalRepository.
+func (r *ticketStockJournalRepository) FindManyByTicketStockID(ctx context.Context, ticketStockID string, offset, limit int64, tx *sql.Tx) ([]TicketStockJournal, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			id, ticket_stock_id, action, quantity, stock, price, description, created_by, created_at
+		FROM ticket_stock_journal
+		WHERE
+			ticket_stock_id = $1
+		ORDER BY created_at DESC, id DESC
+		OFFSET $2
+		LIMIT $3
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of ticket stock journal's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, ticketStockID, offset, limit)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of ticket stock journal's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]TicketStockJournal, 0)
+	for rows.Next() {
+		var j TicketStockJournal
+		err := rows.Scan(&j.ID, &j.TicketStockID, &j.Action, &j.Quantity, &j.Stock, &j.Price, &j.Description, &j.CreatedBy, &j.CreatedAt)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of ticket stock journal's prorperties")
+		}
+
+		data = append(data, j)
+	}
+
+	return data, nil
+}
+
+// Save implements TicketStockJournalRepository.
+func (r *ticketStockJournalRepository) Save(ctx context.Context, j TicketStockJournal, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		INSERT INTO ticket_stock_journal
+		(
+			ticket_stock_id, action, quantity, stock, price, description, created_by, created_at
+		)
+		VALUES
+		(
+			$1, $2, $3, $4, $5, $6, $7, $8
+		)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving ticket stock journal's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, j.TicketStockID, j.Action, j.Quantity, j.Stock, j.Price, j.Description, j.CreatedBy, j.CreatedAt)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving ticket stock journal's prorperties")
+	}
+
+	return nil
+}
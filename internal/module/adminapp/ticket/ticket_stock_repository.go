@@ -3,6 +3,7 @@ package ticket
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
@@ -11,8 +12,14 @@ import (
 )
 
 type TicketStockRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CommitTx(ctx context.Context, tx *sql.Tx) error
+	Rollback(ctx context.Context, tx *sql.Tx) error
 	Save(ctx context.Context, ts TicketStock, tx *sql.Tx) error
+	FindByID(ctx context.Context, ID string, tx *sql.Tx) (TicketStock, error)
+	FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (TicketStock, error)
 	FindManyByShowID(ctx context.Context, showID string, tx *sql.Tx) ([]TicketStock, error)
+	Update(ctx context.Context, ID string, ts TicketStock, tx *sql.Tx) error
 }
 
 type sqlCommand interface {
@@ -34,6 +41,128 @@ func NewTicketStockRepository(logger *logrus.Logger, db *sql.DB) TicketStockRepo
 	}
 }
 
+// BeginTx implements TicketStockRepository.
+func (r *ticketStockRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to begin transaction")
+	}
+
+	return tx, nil
+}
+
+// CommitTx implements TicketStockRepository.
+func (r *ticketStockRepository) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to commit transaction")
+	}
+
+	return nil
+}
+
+// Rollback implements TicketStockRepository.
+func (r *ticketStockRepository) Rollback(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to rollback transaction")
+	}
+
+	return nil
+}
+
+func (r *ticketStockRepository) findByID(ctx context.Context, ID string, forUpdate bool, tx *sql.Tx) (TicketStock, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			id, tier, allocation, price, acquired, last_stock_update, online_for, show_id, event_id
+		FROM ticket_stock
+		WHERE
+			id = $1
+	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return TicketStock{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting ticket stock's prorperties")
+	}
+	defer stmt.Close()
+
+	row := stmt.QueryRowContext(ctx, ID)
+
+	var data TicketStock
+	var onlineFor sql.NullString
+
+	err = row.Scan(&data.ID, &data.Tier, &data.Allocation, &data.Price, &data.Acquired, &data.LastStockUpdate, &onlineFor, &data.ShowID, &data.EventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TicketStock{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("ticket stock's properties with id '%s' is not found", ID))
+		}
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return TicketStock{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting ticket stock's prorperties")
+	}
+
+	if onlineFor.Valid {
+		data.OnlineFor = &onlineFor.String
+	}
+
+	return data, nil
+}
+
+// FindByID implements TicketStockRepository.
+func (r *ticketStockRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (TicketStock, error) {
+	return r.findByID(ctx, ID, false, tx)
+}
+
+// FindByIDForUpdate implements TicketStockRepository.
+func (r *ticketStockRepository) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (TicketStock, error) {
+	return r.findByID(ctx, ID, true, tx)
+}
+
+// Update implements TicketStockRepository. The acquired number is owned by the order flow and is never
+// overwritten here.
+func (r *ticketStockRepository) Update(ctx context.Context, ID string, ts TicketStock, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE ticket_stock
+		SET
+			allocation = $1,
+			price = $2,
+			last_stock_update = $3
+		WHERE 
+			id = $4
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating ticket stock's prorperties")
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, ts.Allocation, ts.Price, ts.LastStockUpdate, ID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating ticket stock's prorperties")
+	}
+
+	return nil
+}
+
 // FindManyByShowID implements TicketStockRepository.
 func (r *ticketStockRepository) FindManyByShowID(ctx context.Context, showID string, tx *sql.Tx) ([]TicketStock, error) {
 	var cmd sqlCommand = r.db
@@ -47,7 +176,7 @@ func (r *ticketStockRepository) FindManyByShowID(ctx context.Context, showID str
 			id, tier, allocation, price, acquired, last_stock_update, online_for, show_id, event_id
 		FROM ticket_stock
 		WHERE
-			id = $1
+			show_id = $1
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
@@ -72,7 +201,7 @@ func (r *ticketStockRepository) FindManyByShowID(ctx context.Context, showID str
 		err := rows.Scan(&ts.ID, &ts.Tier, &ts.Allocation, &ts.Price, &ts.Acquired, &ts.LastStockUpdate, &onlineFor, &ts.ShowID, &ts.EventID)
 		if err != nil {
 			r.logger.WithContext(ctx).WithError(err).Error()
-			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule day's prorperties")
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of ticket stock's prorperties")
 		}
 
 		if onlineFor.Valid {
@@ -0,0 +1,309 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+	"golang.org/x/sync/errgroup"
+)
+
+type TicketUseCase interface {
+	IncreaseAllocation(ctx context.Context, req AdjustAllocationRequest) (TicketStockResponse, error)
+	DecreaseAllocation(ctx context.Context, req AdjustAllocationRequest) (TicketStockResponse, error)
+	TransferAllocation(ctx context.Context, req TransferAllocationRequest) (TransferAllocationResponse, error)
+	ChangePrice(ctx context.Context, req ChangePriceRequest) (TicketStockResponse, error)
+	GetManyJournal(ctx context.Context, req GetManyJournalRequest) (GetManyJournalResponse, error)
+}
+
+type TicketUseCaseProperty struct {
+	Logger                       *logrus.Logger
+	Timeout                      time.Duration
+	TicketStockRepository        TicketStockRepository
+	TicketStockJournalRepository TicketStockJournalRepository
+}
+
+type ticketUseCase struct {
+	logger                       *logrus.Logger
+	timeout                      time.Duration
+	ticketStockRepository        TicketStockRepository
+	ticketStockJournalRepository TicketStockJournalRepository
+}
+
+func NewTicketUseCase(props TicketUseCaseProperty) TicketUseCase {
+	return &ticketUseCase{
+		logger:                       props.Logger,
+		timeout:                      props.Timeout,
+		ticketStockRepository:        props.TicketStockRepository,
+		ticketStockJournalRepository: props.TicketStockJournalRepository,
+	}
+}
+
+// save updates the ticket stock and journals the change within the same transaction.
+func (u *ticketUseCase) save(ctx context.Context, ts TicketStock, j TicketStockJournal, tx *sql.Tx) error {
+	if err := u.ticketStockRepository.Update(ctx, ts.ID, ts, tx); err != nil {
+		return err
+	}
+
+	j.TicketStockID = ts.ID
+	j.Stock = ts.Allocation
+	j.Price = ts.Price
+	j.CreatedAt = ts.LastStockUpdate
+
+	return u.ticketStockJournalRepository.Save(ctx, j, tx)
+}
+
+// adjustAllocation locks the ticket stock, moves its allocation by delta and journals the change.
+func (u *ticketUseCase) adjustAllocation(ctx context.Context, req AdjustAllocationRequest, action string, delta int64) (TicketStockResponse, error) {
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return TicketStockResponse{}, err
+	}
+
+	tx, err := u.ticketStockRepository.BeginTx(ctx)
+	if err != nil {
+		return TicketStockResponse{}, err
+	}
+
+	ts, err := u.ticketStockRepository.FindByIDForUpdate(ctx, req.TicketStockID, tx)
+	if err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TicketStockResponse{}, err
+	}
+
+	if ts.Allocation+delta < ts.Acquired {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TicketStockResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("allocation can not be lower than the acquired tickets (%d), only %d tickets are available", ts.Acquired, ts.Available()))
+	}
+
+	ts.Allocation += delta
+	ts.LastStockUpdate = time.Now()
+
+	if err := u.save(ctx, ts, TicketStockJournal{
+		Action:      action,
+		Quantity:    req.Quantity,
+		Description: req.Description,
+		CreatedBy:   acc.ID,
+	}, tx); err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TicketStockResponse{}, err
+	}
+
+	if err := u.ticketStockRepository.CommitTx(ctx, tx); err != nil {
+		return TicketStockResponse{}, err
+	}
+
+	resp := TicketStockResponse{}
+	resp.PopulateFromEntity(ts)
+
+	return resp, nil
+}
+
+// IncreaseAllocation implements TicketUseCase.
+func (u *ticketUseCase) IncreaseAllocation(ctx context.Context, req AdjustAllocationRequest) (TicketStockResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.adjustAllocation(ctx, req, JournalActionIncreaseAllocation, req.Quantity)
+}
+
+// DecreaseAllocation implements TicketUseCase.
+func (u *ticketUseCase) DecreaseAllocation(ctx context.Context, req AdjustAllocationRequest) (TicketStockResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	return u.adjustAllocation(ctx, req, JournalActionDecreaseAllocation, -req.Quantity)
+}
+
+// lockPair locks both ticket stocks in the order of their id, so concurrent transfers can not deadlock.
+func (u *ticketUseCase) lockPair(ctx context.Context, fromID, toID string, tx *sql.Tx) (TicketStock, TicketStock, error) {
+	firstID, secondID := fromID, toID
+	if secondID < firstID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := u.ticketStockRepository.FindByIDForUpdate(ctx, firstID, tx)
+	if err != nil {
+		return TicketStock{}, TicketStock{}, err
+	}
+
+	second, err := u.ticketStockRepository.FindByIDForUpdate(ctx, secondID, tx)
+	if err != nil {
+		return TicketStock{}, TicketStock{}, err
+	}
+
+	if first.ID == fromID {
+		return first, second, nil
+	}
+
+	return second, first, nil
+}
+
+// TransferAllocation implements TicketUseCase.
+func (u *ticketUseCase) TransferAllocation(ctx context.Context, req TransferAllocationRequest) (TransferAllocationResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return TransferAllocationResponse{}, err
+	}
+
+	tx, err := u.ticketStockRepository.BeginTx(ctx)
+	if err != nil {
+		return TransferAllocationResponse{}, err
+	}
+
+	from, to, err := u.lockPair(ctx, req.FromTicketStockID, req.ToTicketStockID, tx)
+	if err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TransferAllocationResponse{}, err
+	}
+
+	if from.ShowID != to.ShowID {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TransferAllocationResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "allocation can only be transferred between ticket stocks of the same show")
+	}
+
+	if from.Available() < req.Quantity {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TransferAllocationResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, fmt.Sprintf("only %d tickets of '%s' are available to be transferred", from.Available(), from.ID))
+	}
+
+	now := time.Now()
+	from.Allocation -= req.Quantity
+	from.LastStockUpdate = now
+	to.Allocation += req.Quantity
+	to.LastStockUpdate = now
+
+	if err := u.save(ctx, from, TicketStockJournal{
+		Action:      JournalActionTransferOut,
+		Quantity:    req.Quantity,
+		Description: fmt.Sprintf("%s (to %s)", req.Description, to.ID),
+		CreatedBy:   acc.ID,
+	}, tx); err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TransferAllocationResponse{}, err
+	}
+
+	if err := u.save(ctx, to, TicketStockJournal{
+		Action:      JournalActionTransferIn,
+		Quantity:    req.Quantity,
+		Description: fmt.Sprintf("%s (from %s)", req.Description, from.ID),
+		CreatedBy:   acc.ID,
+	}, tx); err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TransferAllocationResponse{}, err
+	}
+
+	if err := u.ticketStockRepository.CommitTx(ctx, tx); err != nil {
+		return TransferAllocationResponse{}, err
+	}
+
+	resp := TransferAllocationResponse{}
+	resp.From.PopulateFromEntity(from)
+	resp.To.PopulateFromEntity(to)
+
+	return resp, nil
+}
+
+// ChangePrice implements TicketUseCase. Orders that are already placed keep the price they were placed with.
+func (u *ticketUseCase) ChangePrice(ctx context.Context, req ChangePriceRequest) (TicketStockResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return TicketStockResponse{}, err
+	}
+
+	tx, err := u.ticketStockRepository.BeginTx(ctx)
+	if err != nil {
+		return TicketStockResponse{}, err
+	}
+
+	ts, err := u.ticketStockRepository.FindByIDForUpdate(ctx, req.TicketStockID, tx)
+	if err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TicketStockResponse{}, err
+	}
+
+	previousPrice := ts.Price
+	ts.Price = req.Price
+	ts.LastStockUpdate = time.Now()
+
+	if err := u.save(ctx, ts, TicketStockJournal{
+		Action:      JournalActionChangePrice,
+		Quantity:    0,
+		Description: fmt.Sprintf("%s (from %.2f to %.2f)", req.Description, previousPrice, req.Price),
+		CreatedBy:   acc.ID,
+	}, tx); err != nil {
+		u.ticketStockRepository.Rollback(ctx, tx)
+		return TicketStockResponse{}, err
+	}
+
+	if err := u.ticketStockRepository.CommitTx(ctx, tx); err != nil {
+		return TicketStockResponse{}, err
+	}
+
+	resp := TicketStockResponse{}
+	resp.PopulateFromEntity(ts)
+
+	return resp, nil
+}
+
+// GetManyJournal implements TicketUseCase.
+func (u *ticketUseCase) GetManyJournal(ctx context.Context, req GetManyJournalRequest) (GetManyJournalResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if _, err := u.ticketStockRepository.FindByID(ctx, req.TicketStockID, nil); err != nil {
+		return GetManyJournalResponse{}, err
+	}
+
+	offset := (req.Page - 1) * req.Size
+	limit := req.Size
+
+	var journals []TicketStockJournal
+	var total int64
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		count, err := u.ticketStockJournalRepository.CountByTicketStockID(gctx, req.TicketStockID, nil)
+		if err != nil {
+			return err
+		}
+		total = count
+
+		return nil
+	})
+	g.Go(func() error {
+		bunchOfJournals, err := u.ticketStockJournalRepository.FindManyByTicketStockID(gctx, req.TicketStockID, offset, limit, nil)
+		if err != nil {
+			return err
+		}
+		journals = bunchOfJournals
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return GetManyJournalResponse{}, err
+	}
+
+	resp := GetManyJournalResponse{
+		Total:    total,
+		Journals: make([]TicketStockJournalResponse, len(journals)),
+	}
+	for k, v := range journals {
+		resp.Journals[k].PopulateFromEntity(v)
+	}
+
+	return resp, nil
+}
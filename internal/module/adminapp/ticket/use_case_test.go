@@ -0,0 +1,235 @@
+package ticket_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+// ticketStockRepositoryStandIn keeps the updates of a transaction apart until it is committed.
+type ticketStockRepositoryStandIn struct {
+	ticket.TicketStockRepository
+	ticketStocks map[string]ticket.TicketStock
+	pending      map[string]ticket.TicketStock
+	locked       []string
+}
+
+func newTicketStockRepositoryStandIn(ticketStocks ...ticket.TicketStock) *ticketStockRepositoryStandIn {
+	r := &ticketStockRepositoryStandIn{ticketStocks: make(map[string]ticket.TicketStock)}
+	for _, ts := range ticketStocks {
+		r.ticketStocks[ts.ID] = ts
+	}
+
+	return r
+}
+
+func (r *ticketStockRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	r.pending = make(map[string]ticket.TicketStock)
+
+	return nil, nil
+}
+
+func (r *ticketStockRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	for ID, ts := range r.pending {
+		r.ticketStocks[ID] = ts
+	}
+	r.pending = nil
+
+	return nil
+}
+
+func (r *ticketStockRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	r.pending = nil
+
+	return nil
+}
+
+func (r *ticketStockRepositoryStandIn) FindByID(ctx context.Context, ID string, tx *sql.Tx) (ticket.TicketStock, error) {
+	ts, ok := r.ticketStocks[ID]
+	if !ok {
+		return ticket.TicketStock{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "ticket stock is not found")
+	}
+
+	return ts, nil
+}
+
+func (r *ticketStockRepositoryStandIn) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (ticket.TicketStock, error) {
+	r.locked = append(r.locked, ID)
+
+	return r.FindByID(ctx, ID, tx)
+}
+
+func (r *ticketStockRepositoryStandIn) Update(ctx context.Context, ID string, ts ticket.TicketStock, tx *sql.Tx) error {
+	r.pending[ID] = ts
+
+	return nil
+}
+
+type ticketStockJournalRepositoryStandIn struct {
+	ticket.TicketStockJournalRepository
+	journals []ticket.TicketStockJournal
+}
+
+func (r *ticketStockJournalRepositoryStandIn) Save(ctx context.Context, j ticket.TicketStockJournal, tx *sql.Tx) error {
+	r.journals = append(r.journals, j)
+
+	return nil
+}
+
+// newTicketUseCase returns a use case over the given ticket stocks, along with the stand-ins that record its changes.
+func newTicketUseCase(ticketStocks ...ticket.TicketStock) (ticket.TicketUseCase, *ticketStockRepositoryStandIn, *ticketStockJournalRepositoryStandIn) {
+	stocks := newTicketStockRepositoryStandIn(ticketStocks...)
+	journals := &ticketStockJournalRepositoryStandIn{}
+
+	return ticket.NewTicketUseCase(ticket.TicketUseCaseProperty{
+		Logger:                       standin.Logger(),
+		Timeout:                      5 * time.Second,
+		TicketStockRepository:        stocks,
+		TicketStockJournalRepository: journals,
+	}), stocks, journals
+}
+
+func TestTicketUseCaseAdjustAllocation(t *testing.T) {
+	gold := ticket.TicketStock{EventID: "event-1", ShowID: "show-1", ID: "gold", Tier: "GOLD", Allocation: 100, Price: 1000, Acquired: 40}
+
+	t.Run("increase the allocation and journal it", func(t *testing.T) {
+		u, stocks, journals := newTicketUseCase(gold)
+
+		resp, err := u.IncreaseAllocation(standin.AdminContext(), ticket.AdjustAllocationRequest{TicketStockID: "gold", Quantity: 20, Description: "new block"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(120), resp.Allocation)
+		assert.Equal(t, int64(80), resp.Available)
+		assert.Equal(t, int64(120), stocks.ticketStocks["gold"].Allocation)
+
+		if assert.Len(t, journals.journals, 1) {
+			j := journals.journals[0]
+			assert.Equal(t, "gold", j.TicketStockID)
+			assert.Equal(t, ticket.JournalActionIncreaseAllocation, j.Action)
+			assert.Equal(t, int64(20), j.Quantity)
+			assert.Equal(t, int64(120), j.Stock, "the journal holds the allocation after the change")
+			assert.Equal(t, float64(1000), j.Price)
+			assert.Equal(t, standin.AdminID, j.CreatedBy)
+			assert.Equal(t, "new block", j.Description)
+		}
+	})
+
+	t.Run("decrease the allocation down to the acquired tickets", func(t *testing.T) {
+		u, _, journals := newTicketUseCase(gold)
+
+		resp, err := u.DecreaseAllocation(standin.AdminContext(), ticket.AdjustAllocationRequest{TicketStockID: "gold", Quantity: 60, Description: "closing the block"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(40), resp.Allocation)
+		assert.Equal(t, int64(0), resp.Available)
+
+		if assert.Len(t, journals.journals, 1) {
+			assert.Equal(t, ticket.JournalActionDecreaseAllocation, journals.journals[0].Action)
+			assert.Equal(t, int64(60), journals.journals[0].Quantity)
+			assert.Equal(t, int64(40), journals.journals[0].Stock)
+		}
+	})
+
+	t.Run("the allocation never drops below the acquired tickets", func(t *testing.T) {
+		u, stocks, journals := newTicketUseCase(gold)
+
+		_, err := u.DecreaseAllocation(standin.AdminContext(), ticket.AdjustAllocationRequest{TicketStockID: "gold", Quantity: 61, Description: "too much"})
+		assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+		assert.Equal(t, int64(100), stocks.ticketStocks["gold"].Allocation)
+		assert.Empty(t, journals.journals)
+	})
+
+	t.Run("an unknown ticket stock", func(t *testing.T) {
+		u, _, journals := newTicketUseCase(gold)
+
+		_, err := u.IncreaseAllocation(standin.AdminContext(), ticket.AdjustAllocationRequest{TicketStockID: "silver", Quantity: 1, Description: "new block"})
+		assert.Equal(t, http.StatusNotFound, errors.Destruct(err).HTTPStatusCode)
+		assert.Empty(t, journals.journals)
+	})
+
+	t.Run("an adjustment needs a signed in admin", func(t *testing.T) {
+		u, stocks, _ := newTicketUseCase(gold)
+
+		_, err := u.IncreaseAllocation(context.Background(), ticket.AdjustAllocationRequest{TicketStockID: "gold", Quantity: 1, Description: "new block"})
+		assert.Error(t, err)
+		assert.Equal(t, int64(100), stocks.ticketStocks["gold"].Allocation)
+	})
+}
+
+func TestTicketUseCaseTransferAllocation(t *testing.T) {
+	gold := ticket.TicketStock{EventID: "event-1", ShowID: "show-1", ID: "gold", Tier: "GOLD", Allocation: 100, Price: 1000, Acquired: 70}
+	silver := ticket.TicketStock{EventID: "event-1", ShowID: "show-1", ID: "silver", Tier: "SILVER", Allocation: 200, Price: 500, Acquired: 10}
+	otherShow := ticket.TicketStock{EventID: "event-1", ShowID: "show-2", ID: "bronze", Tier: "BRONZE", Allocation: 50, Price: 250}
+
+	t.Run("the total allocation is preserved and both sides are journaled", func(t *testing.T) {
+		u, stocks, journals := newTicketUseCase(gold, silver)
+
+		resp, err := u.TransferAllocation(standin.AdminContext(), ticket.TransferAllocationRequest{FromTicketStockID: "silver", ToTicketStockID: "gold", Quantity: 30, Description: "upgrade"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(170), resp.From.Allocation)
+		assert.Equal(t, int64(130), resp.To.Allocation)
+		assert.Equal(t, int64(300), stocks.ticketStocks["gold"].Allocation+stocks.ticketStocks["silver"].Allocation)
+		assert.Equal(t, []string{"gold", "silver"}, stocks.locked, "the ticket stocks are locked in the order of their id")
+
+		if assert.Len(t, journals.journals, 2) {
+			out, in := journals.journals[0], journals.journals[1]
+			assert.Equal(t, ticket.JournalActionTransferOut, out.Action)
+			assert.Equal(t, "silver", out.TicketStockID)
+			assert.Equal(t, int64(30), out.Quantity)
+			assert.Equal(t, int64(170), out.Stock)
+			assert.Equal(t, "upgrade (to gold)", out.Description)
+
+			assert.Equal(t, ticket.JournalActionTransferIn, in.Action)
+			assert.Equal(t, "gold", in.TicketStockID)
+			assert.Equal(t, int64(30), in.Quantity)
+			assert.Equal(t, int64(130), in.Stock)
+			assert.Equal(t, "upgrade (from silver)", in.Description)
+		}
+	})
+
+	testCases := []struct {
+		name         string
+		req          ticket.TransferAllocationRequest
+		expectedCode int
+	}{
+		{name: "only the available tickets are transferred", req: ticket.TransferAllocationRequest{FromTicketStockID: "gold", ToTicketStockID: "silver", Quantity: 31}, expectedCode: http.StatusBadRequest},
+		{name: "only between ticket stocks of the same show", req: ticket.TransferAllocationRequest{FromTicketStockID: "silver", ToTicketStockID: "bronze", Quantity: 1}, expectedCode: http.StatusBadRequest},
+		{name: "an unknown ticket stock", req: ticket.TransferAllocationRequest{FromTicketStockID: "silver", ToTicketStockID: "wood", Quantity: 1}, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, stocks, journals := newTicketUseCase(gold, silver, otherShow)
+
+			_, err := u.TransferAllocation(standin.AdminContext(), tc.req)
+			assert.Equal(t, tc.expectedCode, errors.Destruct(err).HTTPStatusCode)
+			assert.Equal(t, int64(100), stocks.ticketStocks["gold"].Allocation)
+			assert.Equal(t, int64(200), stocks.ticketStocks["silver"].Allocation)
+			assert.Equal(t, int64(50), stocks.ticketStocks["bronze"].Allocation)
+			assert.Empty(t, journals.journals)
+		})
+	}
+}
+
+func TestTicketUseCaseChangePrice(t *testing.T) {
+	u, _, journals := newTicketUseCase(ticket.TicketStock{EventID: "event-1", ShowID: "show-1", ID: "gold", Tier: "GOLD", Allocation: 100, Price: 1000, Acquired: 40})
+
+	resp, err := u.ChangePrice(standin.AdminContext(), ticket.ChangePriceRequest{TicketStockID: "gold", Price: 1250, Description: "early bird is over"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1250), resp.Price)
+	assert.Equal(t, int64(100), resp.Allocation)
+
+	if assert.Len(t, journals.journals, 1) {
+		j := journals.journals[0]
+		assert.Equal(t, ticket.JournalActionChangePrice, j.Action)
+		assert.Equal(t, int64(0), j.Quantity)
+		assert.Equal(t, float64(1250), j.Price)
+		assert.Equal(t, "early bird is over (from 1000.00 to 1250.00)", j.Description)
+	}
+}
@@ -0,0 +1,61 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+// AcquiredTicketRepository only touches the copy of the personal data of the customer in the acquired tickets, the
+// tickets themselves belong to the ticket module.
+type AcquiredTicketRepository interface {
+	AnonymizeByCustomerID(ctx context.Context, customerID int64, name, email string, tx *sql.Tx) error
+}
+
+type acquiredTicketRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewAcquiredTicketRepository(logger *logrus.Logger, db *sql.DB) AcquiredTicketRepository {
+	return &acquiredTicketRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// AnonymizeByCustomerID implements AcquiredTicketRepository.
+func (r *acquiredTicketRepository) AnonymizeByCustomerID(ctx context.Context, customerID int64, name, email string, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE acquired_ticket
+		SET
+			customer_name = $1,
+			customer_email = $2
+		WHERE
+			customer_id = $3
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while anonymizing customer's acquired tickets")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, name, email, customerID); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while anonymizing customer's acquired tickets")
+	}
+
+	return nil
+}
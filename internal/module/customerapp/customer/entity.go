@@ -4,16 +4,64 @@ import "time"
 
 const (
 	verificationKeyPrefix            = "user:verification:customer:token:%s"
+	verificationCustomerKeyPrefix    = "user:verification:customer:id:%d"
+	resendVerificationLimitKeyPrefix = "user:resend_verification:customer:email:%s"
 	changeEmailVerificationKeyPrefix = "user:change_email_verification:customer:token:%s"
+	resetPasswordKeyPrefix           = "user:reset_password:customer:token:%s"
+	resetPasswordLimitKeyPrefix      = "user:reset_password:customer:email:%s"
+	refreshTokenKeyPrefix            = "user:refresh_token:customer:token:%s"
+	refreshTokenRotatedKeyPrefix     = "user:refresh_token:customer:rotated:%s"
+	twoFactorEnrollmentKeyPrefix     = "user:two_factor_enrollment:customer:id:%d"
+	twoFactorChallengeKeyPrefix      = "user:two_factor_challenge:customer:token:%s"
+	twoFactorChallengeAttemptPrefix  = "user:two_factor_challenge_attempt:customer:token:%s"
+	twoFactorUsedCodeKeyPrefix       = "user:two_factor_used_code:customer:id:%d:%d"
+
+	// accessTokenExpiresIn is the lifetime of the token that is sent along every request, refreshTokenExpiresIn is
+	// the lifetime of the session of a device that is not refreshed.
+	accessTokenExpiresIn  = time.Hour
+	refreshTokenExpiresIn = 7 * 24 * time.Hour
+
+	// resetPasswordLimit is the number of reset password links that can be requested for an email within resetPasswordLimitWindow.
+	resetPasswordLimit       = 3
+	resetPasswordLimitWindow = time.Hour
+
+	// resendVerificationLimit is the number of verification links that can be resent to an email within resendVerificationLimitWindow.
+	resendVerificationLimit       = 3
+	resendVerificationLimitWindow = time.Hour
+
+	// twoFactorIssuer is the name of the account in the authenticator of the customer.
+	twoFactorIssuer = "ticket-master"
+	// twoFactorEnrollmentExpiresIn is the time that the customer has to confirm the enrollment with the first code,
+	// twoFactorChallengeExpiresIn is the time that the customer has to complete the sign in with a code.
+	twoFactorEnrollmentExpiresIn = 10 * time.Minute
+	twoFactorChallengeExpiresIn  = 5 * time.Minute
+	// twoFactorChallengeAttemptLimit is the number of codes that can be tried on a challenge.
+	twoFactorChallengeAttemptLimit = 5
 
 	VerificationURLPath            = "/v1/customerapp/customers/verify"
 	ChangeEmailVerificationURLPath = "/v1/customerapp/customers/verify-change-email"
+	ResetPasswordURLPath           = "/v1/customerapp/customers/reset-password"
 
 	VerficationStatusVerified    = "VERIFIED"
 	VerificationStatusUnverified = "UNVERIFIED"
 
 	MemberStatusActive   = "ACTIVE"
 	MemberStatusInactive = "INACTIVE"
+	// MemberStatusDeleted is the status of an anonymized customer.
+	MemberStatusDeleted = "DELETED"
+
+	// AnonymizedName replaces the name of a deleted customer, anonymizedEmailFormat its email so the email can be
+	// registered again.
+	AnonymizedName        = "Deleted Customer"
+	anonymizedEmailFormat = "deleted-%d@deleted.invalid"
+
+	// DeleteCustomerQueue is the cloud tasks queue of the deletions of the customers, its tasks are scheduled at the
+	// end of the grace period, which is why the grace period can not be longer than 30 days.
+	DeleteCustomerQueue = "delete-customer"
+	OnDeleteURLPath     = "/v1/customerapp/customers/on-delete"
+
+	// MemberTierRegular is the tier of a customer that has not been promoted to another tier.
+	MemberTierRegular = "REGULAR"
 )
 
 type Customer struct {
@@ -24,6 +72,33 @@ type Customer struct {
 	PasswordSalt       string
 	VerificationStatus string
 	MemberStatus       string
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	MemberTier         string
+	// TwoFactorSecret is sealed by twofactor.Seal, TwoFactorRecoveryCodes are hashed by twofactor.HashRecoveryCode.
+	TwoFactorSecret        string
+	TwoFactorEnabled       bool
+	TwoFactorRecoveryCodes []string
+	// DeletionRequestedAt is set while the deletion waits for the grace period, DeletedAt once it is anonymized.
+	DeletionRequestedAt *time.Time
+	DeletedAt           *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// RefreshToken is the session of a device that a refresh token belongs to.
+type RefreshToken struct {
+	CustomerID int64  `json:"customer_id"`
+	SessionID  string `json:"session_id"`
+}
+
+// TwoFactorChallenge is the sign in that waits for the code of the customer.
+type TwoFactorChallenge struct {
+	CustomerID int64  `json:"customer_id"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+}
+
+// DeleteCustomerEvent is the task that anonymizes the customer once the grace period has passed.
+type DeleteCustomerEvent struct {
+	CustomerID          int64     `json:"customer_id"`
+	DeletionRequestedAt time.Time `json:"deletion_requested_at"`
 }
@@ -19,3 +19,11 @@ type SignUpEvent struct {
 	VerificationLink   string    `json:"verification_link"`
 	CreatedAt          time.Time `json:"created_at"`
 }
+
+type ResetPasswordEvent struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	Email             string    `json:"email"`
+	ResetPasswordLink string    `json:"reset_password_link"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
 	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
 	"github.com/tsel-ticketmaster/tm-order/pkg/response"
@@ -20,33 +21,64 @@ type HTTPHandler struct {
 	SessionMiddleware *middleware.AdminSession
 	Validate          *validator.Validate
 	CustomerUseCase   CustomerUseCase
+	// TrustedProxyHops is the number of proxies in front of the service that append to X-Forwarded-For.
+	TrustedProxyHops int
 }
 
-func InitHTTPHandler(router *mux.Router, customerSession *middleware.CustomerSession, validate *validator.Validate, customerUseCase CustomerUseCase) {
+func InitHTTPHandler(router *mux.Router, customerSession *middleware.CustomerSession, validate *validator.Validate, customerUseCase CustomerUseCase, trustedProxyHops int) {
 	handler := &HTTPHandler{
-		Validate:        validate,
-		CustomerUseCase: customerUseCase,
+		Validate:         validate,
+		CustomerUseCase:  customerUseCase,
+		TrustedProxyHops: trustedProxyHops,
 	}
 
 	router.HandleFunc("/tm-order/v1/customerapp/customers/signin", publicMiddleware.SetRouteChain(handler.SignIn)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/signin/two-factor", publicMiddleware.SetRouteChain(handler.VerifyTwoFactor)).Methods(http.MethodPost)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/signup", publicMiddleware.SetRouteChain(handler.SignUp)).Methods(http.MethodPost)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/signout", publicMiddleware.SetRouteChain(handler.SignOut, customerSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/refresh", publicMiddleware.SetRouteChain(handler.Refresh)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/sessions", publicMiddleware.SetRouteChain(handler.GetManySession, customerSession.Verify)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/sessions", publicMiddleware.SetRouteChain(handler.RevokeAllSession, customerSession.Verify)).Methods(http.MethodDelete)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/sessions/{id}", publicMiddleware.SetRouteChain(handler.RevokeSession, customerSession.Verify)).Methods(http.MethodDelete)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/profile", publicMiddleware.SetRouteChain(handler.GetProfile, customerSession.Verify)).Methods(http.MethodGet)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/profile", publicMiddleware.SetRouteChain(handler.UpdateProfile, customerSession.Verify)).Methods(http.MethodPatch)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/profile", publicMiddleware.SetRouteChain(handler.DeleteProfile, customerSession.Verify)).Methods(http.MethodDelete)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/on-delete", publicMiddleware.SetRouteChain(handler.OnDeleteCustomer)).Methods(http.MethodPost)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/change-email", publicMiddleware.SetRouteChain(handler.ChangeEmail, customerSession.Verify)).Methods(http.MethodPatch)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/change-password", publicMiddleware.SetRouteChain(handler.ChangePassword, customerSession.Verify)).Methods(http.MethodPatch)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/two-factor/enroll", publicMiddleware.SetRouteChain(handler.EnrollTwoFactor, customerSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/two-factor/confirm", publicMiddleware.SetRouteChain(handler.ConfirmTwoFactor, customerSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/two-factor/disable", publicMiddleware.SetRouteChain(handler.DisableTwoFactor, customerSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/two-factor/recovery-codes", publicMiddleware.SetRouteChain(handler.RegenerateRecoveryCode, customerSession.Verify)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/forgot-password", publicMiddleware.SetRouteChain(handler.ForgotPassword)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/reset-password", publicMiddleware.SetRouteChain(handler.ResetPassword)).Methods(http.MethodPost)
+	router.HandleFunc("/tm-order/v1/customerapp/customers/resend-verification", publicMiddleware.SetRouteChain(handler.ResendVerification)).Methods(http.MethodPost)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/verify", publicMiddleware.SetRouteChain(handler.Verify)).Methods(http.MethodGet)
 	router.HandleFunc("/tm-order/v1/customerapp/customers/verify-change-email", publicMiddleware.SetRouteChain(handler.VerifyChangeEmail)).Methods(http.MethodGet)
 
 	// SignUp(ctx context.Context, req SignUpRequest) (SignUpResponse, error)
 	// SignIn(ctx context.Context, req SignInRequest) (SignInResponse, error)
 	// SignOut(ctx context.Context) error
+	// Refresh(ctx context.Context, req RefreshRequest) (SignInResponse, error)
 	// GetProfile(ctx context.Context) (GetProfileResponse, error)
+	// GetManySession(ctx context.Context) ([]SessionResponse, error)
+	// RevokeSession(ctx context.Context, req RevokeSessionRequest) error
+	// RevokeAllSession(ctx context.Context) error
 	// UpdateProfile(ctx context.Context, req UpdateProfileRequest) error
 	// ChangeEmail(ctx context.Context, req ChangeEmailRequest) (ChangeEmailResponse, error)
 	// ChangePassword(ctx context.Context, req ChangePasswordRequest) error
+	// ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error
+	// ResetPassword(ctx context.Context, req ResetPasswordRequest) error
+	// ResendVerification(ctx context.Context, req ResendVerificationRequest) error
 	// Verify(ctx context.Context, req VerifyRequest) error
 	// VerifyChangeEmail(ctx context.Context, req ChangeEmailVerificationRequest) error
+	// VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest) (SignInResponse, error)
+	// EnrollTwoFactor(ctx context.Context) (EnrollTwoFactorResponse, error)
+	// ConfirmTwoFactor(ctx context.Context, req ConfirmTwoFactorRequest) (RecoveryCodeResponse, error)
+	// DisableTwoFactor(ctx context.Context, req DisableTwoFactorRequest) error
+	// RegenerateRecoveryCode(ctx context.Context, req RegenerateRecoveryCodeRequest) (RecoveryCodeResponse, error)
+	// DeleteProfile(ctx context.Context, req DeleteProfileRequest) (DeleteProfileResponse, error)
+	// OnDeleteCustomer(ctx context.Context, e DeleteCustomerEvent) error
 }
 
 func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
@@ -131,6 +163,9 @@ func (handler HTTPHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	req.IPAddress = util.ClientIP(r, handler.TrustedProxyHops)
+	req.UserAgent = r.UserAgent()
+
 	resp, err := handler.CustomerUseCase.SignIn(ctx, req)
 	if err != nil {
 		ae := errors.Destruct(err)
@@ -142,6 +177,51 @@ func (handler HTTPHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	message := "customer has been successfully signed in"
+	if resp.TwoFactor != nil {
+		message = "a two-factor code is required to complete the sign in"
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: message,
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := VerifyTwoFactorRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.CustomerUseCase.VerifyTwoFactor(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
 	response.JSON(w, http.StatusOK, response.RESTEnvelope{
 		Status:  status.OK,
 		Message: "customer has been successfully signed in",
@@ -149,6 +229,145 @@ func (handler HTTPHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (handler HTTPHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.CustomerUseCase.EnrollTwoFactor(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "scan the provisioning uri with an authenticator and confirm it with the first code",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ConfirmTwoFactorRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.CustomerUseCase.ConfirmTwoFactor(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's two-factor authentication has been successfully enabled, keep the recovery codes safe",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := DisableTwoFactorRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.CustomerUseCase.DisableTwoFactor(ctx, req); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's two-factor authentication has been successfully disabled",
+	})
+}
+
+func (handler HTTPHandler) RegenerateRecoveryCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := RegenerateRecoveryCodeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.CustomerUseCase.RegenerateRecoveryCode(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's recovery codes have been successfully regenerated, the previous ones are no longer valid",
+		Data:    resp,
+	})
+}
+
 func (handler HTTPHandler) SignOut(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -169,6 +388,117 @@ func (handler HTTPHandler) SignOut(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (handler HTTPHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := RefreshRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.CustomerUseCase.Refresh(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's token has been successfully refreshed",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetManySession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.CustomerUseCase.GetManySession(ctx)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's sessions",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := RevokeSessionRequest{}
+	req.ID = mux.Vars(r)["id"]
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.CustomerUseCase.RevokeSession(ctx, req); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's session has been successfully revoked",
+	})
+}
+
+func (handler HTTPHandler) RevokeAllSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := handler.CustomerUseCase.RevokeAllSession(ctx); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's sessions have been successfully revoked",
+	})
+}
+
 func (handler HTTPHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -308,6 +638,123 @@ func (handler HTTPHandler) ChangePassword(w http.ResponseWriter, r *http.Request
 	})
 }
 
+func (handler HTTPHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ForgotPasswordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	err := handler.CustomerUseCase.ForgotPassword(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "if the email is registered, a reset password link has been sent to it",
+	})
+}
+
+func (handler HTTPHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ResetPasswordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	err := handler.CustomerUseCase.ResetPassword(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer has been successfully reset password",
+	})
+}
+
+func (handler HTTPHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := ResendVerificationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	err := handler.CustomerUseCase.ResendVerification(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "if the email is registered and not verified yet, a new verification link has been sent to it",
+	})
+}
+
 func (handler HTTPHandler) Verify(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -361,3 +808,72 @@ func (handler HTTPHandler) VerifyChangeEmail(w http.ResponseWriter, r *http.Requ
 		Message: "customer change email verification succeded",
 	})
 }
+
+func (handler HTTPHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := DeleteProfileRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.CustomerUseCase.DeleteProfile(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer's deletion has been successfully requested",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) OnDeleteCustomer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	e := DeleteCustomerEvent{}
+	if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+		response.JSON(w, http.StatusUnprocessableEntity, response.RESTEnvelope{
+			Status:  status.UNPROCESSABLE_ENTITY,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.CustomerUseCase.OnDeleteCustomer(ctx, e); err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "customer has been successfully deleted",
+	})
+}
@@ -0,0 +1,61 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+// OrderRepository only touches the copy of the personal data of the customer in the orders, the orders themselves
+// belong to the order module.
+type OrderRepository interface {
+	AnonymizeByCustomerID(ctx context.Context, customerID int64, name, email string, tx *sql.Tx) error
+}
+
+type orderRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewOrderRepository(logger *logrus.Logger, db *sql.DB) OrderRepository {
+	return &orderRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// AnonymizeByCustomerID implements OrderRepository.
+func (r *orderRepository) AnonymizeByCustomerID(ctx context.Context, customerID int64, name, email string, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE ticket_order
+		SET
+			customer_name = $1,
+			customer_email = $2
+		WHERE
+			customer_id = $3
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while anonymizing customer's orders")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, name, email, customerID); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while anonymizing customer's orders")
+	}
+
+	return nil
+}
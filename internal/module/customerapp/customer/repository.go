@@ -6,12 +6,17 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
 	"github.com/tsel-ticketmaster/tm-order/pkg/status"
 )
 
 type CustomerRepository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CommitTx(ctx context.Context, tx *sql.Tx) error
+	Rollback(ctx context.Context, tx *sql.Tx) error
+
 	Save(ctx context.Context, c Customer, tx *sql.Tx) (int64, error)
 	FindByID(ctx context.Context, ID int64, tx *sql.Tx) (Customer, error)
 	FindByEmail(ctx context.Context, email string, tx *sql.Tx) (Customer, error)
@@ -30,6 +35,37 @@ type customerRepository struct {
 	db     *sql.DB
 }
 
+// BeginTx implements CustomerRepository.
+func (r *customerRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to begin transaction")
+	}
+
+	return tx, nil
+}
+
+// CommitTx implements CustomerRepository.
+func (r *customerRepository) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to commit transaction")
+	}
+
+	return nil
+}
+
+// Rollback implements CustomerRepository.
+func (r *customerRepository) Rollback(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to rollback transaction")
+	}
+
+	return nil
+}
+
 // FindByEmail implements CustomerRepository.
 func (r *customerRepository) FindByEmail(ctx context.Context, email string, tx *sql.Tx) (Customer, error) {
 	var cmd sqlCommand = r.db
@@ -40,7 +76,7 @@ func (r *customerRepository) FindByEmail(ctx context.Context, email string, tx *
 
 	query := `
 		SELECT 
-			id, name, email, password, password_salt, verification_status, member_status, created_at, updated_at
+			id, name, email, password, password_salt, verification_status, member_status, member_tier, two_factor_secret, two_factor_enabled, two_factor_recovery_codes, deletion_requested_at, deleted_at, created_at, updated_at
 		FROM customer
 		WHERE
 			email = $1
@@ -59,7 +95,7 @@ func (r *customerRepository) FindByEmail(ctx context.Context, email string, tx *
 	var data Customer
 
 	err = row.Scan(
-		&data.ID, &data.Name, &data.Email, &data.Password, &data.PasswordSalt, &data.VerificationStatus, &data.MemberStatus, &data.CreatedAt, &data.UpdatedAt,
+		&data.ID, &data.Name, &data.Email, &data.Password, &data.PasswordSalt, &data.VerificationStatus, &data.MemberStatus, &data.MemberTier, &data.TwoFactorSecret, &data.TwoFactorEnabled, pq.Array(&data.TwoFactorRecoveryCodes), &data.DeletionRequestedAt, &data.DeletedAt, &data.CreatedAt, &data.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -82,7 +118,7 @@ func (r *customerRepository) FindByID(ctx context.Context, ID int64, tx *sql.Tx)
 
 	query := `
 		SELECT 
-			id, name, email, password, password_salt, verification_status, member_status, created_at, updated_at
+			id, name, email, password, password_salt, verification_status, member_status, member_tier, two_factor_secret, two_factor_enabled, two_factor_recovery_codes, deletion_requested_at, deleted_at, created_at, updated_at
 		FROM customer
 		WHERE
 			id = $1
@@ -101,7 +137,7 @@ func (r *customerRepository) FindByID(ctx context.Context, ID int64, tx *sql.Tx)
 	var data Customer
 
 	err = row.Scan(
-		&data.ID, &data.Name, &data.Email, &data.Password, &data.PasswordSalt, &data.VerificationStatus, &data.MemberStatus, &data.CreatedAt, &data.UpdatedAt,
+		&data.ID, &data.Name, &data.Email, &data.Password, &data.PasswordSalt, &data.VerificationStatus, &data.MemberStatus, &data.MemberTier, &data.TwoFactorSecret, &data.TwoFactorEnabled, pq.Array(&data.TwoFactorRecoveryCodes), &data.DeletionRequestedAt, &data.DeletedAt, &data.CreatedAt, &data.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -170,9 +206,14 @@ func (r *customerRepository) Update(ctx context.Context, ID int64, c Customer, t
 			password_salt = $4,
 			verification_status = $5,
 			member_status = $6,
-			updated_at = $7
+			two_factor_secret = $7,
+			two_factor_enabled = $8,
+			two_factor_recovery_codes = $9,
+			deletion_requested_at = $10,
+			deleted_at = $11,
+			updated_at = $12
 		WHERE
-			id = $8
+			id = $13
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
@@ -181,7 +222,7 @@ func (r *customerRepository) Update(ctx context.Context, ID int64, c Customer, t
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating customer's prorperties")
 	}
 
-	_, err = stmt.ExecContext(ctx, c.Name, c.Email, c.Password, c.PasswordSalt, c.VerificationStatus, c.MemberStatus, c.UpdatedAt, ID)
+	_, err = stmt.ExecContext(ctx, c.Name, c.Email, c.Password, c.PasswordSalt, c.VerificationStatus, c.MemberStatus, c.TwoFactorSecret, c.TwoFactorEnabled, pq.Array(c.TwoFactorRecoveryCodes), c.DeletionRequestedAt, c.DeletedAt, c.UpdatedAt, ID)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
 		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating customer's prorperties")
@@ -7,8 +7,22 @@ type SignUpRequest struct {
 }
 
 type SignInRequest struct {
-	Email    string `json:"email" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Email     string `json:"email" validate:"required"`
+	Password  string `json:"password" validate:"required"`
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RevokeSessionRequest struct {
+	ID string `validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"email"`
 }
 
 type VerifyRequest struct {
@@ -31,3 +45,38 @@ type ChangePasswordRequest struct {
 type ChangeEmailVerificationRequest struct {
 	Token string
 }
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Code is either the code of the authenticator or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type RegenerateRecoveryCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type DeleteProfileRequest struct {
+	Password string `json:"password" validate:"required"`
+	// Code is required when the two-factor authentication is enabled, either the code of the authenticator or a recovery
+	// code.
+	Code string `json:"code"`
+}
@@ -1,14 +1,53 @@
 package customer
 
-import "time"
+import (
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+)
 
 type SignUpResponse struct {
 	VerificationExpiresAt time.Time `json:"verification_expires_at"`
 }
 
 type SignInResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
+	Token                 string    `json:"token"`
+	ExpiresAt             time.Time `json:"expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	// TwoFactor is only set when the customer has to complete the sign in with VerifyTwoFactor, the tokens are then
+	// empty.
+	TwoFactor *TwoFactorChallengeResponse `json:"two_factor,omitempty"`
+}
+
+type TwoFactorChallengeResponse struct {
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret          string    `json:"secret"`
+	ProvisioningURI string    `json:"provisioning_uri"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+type RecoveryCodeResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type SessionResponse struct {
+	ID        string    `json:"id"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (r *SessionResponse) PopulateFromAccount(acc session.Account) {
+	r.ID = acc.SessionID
+	r.IPAddress = acc.IPAddress
+	r.UserAgent = acc.UserAgent
+	r.CreatedAt = acc.CreatedAt
 }
 
 type GetProfileResponse struct {
@@ -24,3 +63,7 @@ type GetProfileResponse struct {
 type ChangeEmailResponse struct {
 	VerificationExpiresAt time.Time `json:"verification_expires_at"`
 }
+
+type DeleteProfileResponse struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
@@ -5,14 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
+	"cloud.google.com/go/cloudtasks/apiv2/cloudtaskspb"
 	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/jwt"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/twofactor"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gctasks"
 	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
 	"github.com/tsel-ticketmaster/tm-order/pkg/status"
 )
@@ -21,38 +28,73 @@ type CustomerUseCase interface {
 	SignUp(ctx context.Context, req SignUpRequest) (SignUpResponse, error)
 	SignIn(ctx context.Context, req SignInRequest) (SignInResponse, error)
 	SignOut(ctx context.Context) error
+	Refresh(ctx context.Context, req RefreshRequest) (SignInResponse, error)
 	GetProfile(ctx context.Context) (GetProfileResponse, error)
+	GetManySession(ctx context.Context) ([]SessionResponse, error)
+	RevokeSession(ctx context.Context, req RevokeSessionRequest) error
+	RevokeAllSession(ctx context.Context) error
 	UpdateProfile(ctx context.Context, req UpdateProfileRequest) error
 	ChangeEmail(ctx context.Context, req ChangeEmailRequest) (ChangeEmailResponse, error)
 	ChangePassword(ctx context.Context, req ChangePasswordRequest) error
+	ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req ResetPasswordRequest) error
+	ResendVerification(ctx context.Context, req ResendVerificationRequest) error
 	Verify(ctx context.Context, req VerifyRequest) error
 	VerifyChangeEmail(ctx context.Context, req ChangeEmailVerificationRequest) error
+	VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest) (SignInResponse, error)
+	EnrollTwoFactor(ctx context.Context) (EnrollTwoFactorResponse, error)
+	ConfirmTwoFactor(ctx context.Context, req ConfirmTwoFactorRequest) (RecoveryCodeResponse, error)
+	DisableTwoFactor(ctx context.Context, req DisableTwoFactorRequest) error
+	RegenerateRecoveryCode(ctx context.Context, req RegenerateRecoveryCodeRequest) (RecoveryCodeResponse, error)
+	DeleteProfile(ctx context.Context, req DeleteProfileRequest) (DeleteProfileResponse, error)
+	OnDeleteCustomer(ctx context.Context, e DeleteCustomerEvent) error
 }
 
 type CustomerUseCaseProperty struct {
-	AppName            string
-	Logger             *logrus.Logger
-	Timeout            time.Duration
-	TMUserBaseURL      string
-	CryptoSecret       string
-	JSONWebToken       *jwt.JSONWebToken
-	Session            session.Session
-	Cache              redis.UniversalClient
-	Publisher          pubsub.Publisher
-	CustomerRepository CustomerRepository
+	AppName       string
+	Logger        *logrus.Logger
+	Timeout       time.Duration
+	TMUserBaseURL string
+	// BaseURL of this service that the cloud task of the deletion calls back.
+	BaseURL string
+	// VerificationExpiration is the lifetime of the verification link.
+	VerificationExpiration time.Duration
+	// DeletionGracePeriod is the time between the request to delete the account and its anonymization.
+	DeletionGracePeriod time.Duration
+	CryptoSecret        string
+	JSONWebToken        *jwt.JSONWebToken
+	Session             session.Session
+	Cache               redis.UniversalClient
+	Publisher           pubsub.Publisher
+	CustomerRepository  CustomerRepository
+	// OrderRepository and AcquiredTicketRepository anonymize the copies of the personal data of a deleted customer.
+	OrderRepository          OrderRepository
+	AcquiredTicketRepository AcquiredTicketRepository
+	CloudTask                gctasks.Client
+	// SignInEmailGuard and SignInIPGuard count the failed sign in per email and per ip address.
+	SignInEmailGuard lockout.Guard
+	SignInIPGuard    lockout.Guard
 }
 
 type customerUseCase struct {
-	appName            string
-	logger             *logrus.Logger
-	timeout            time.Duration
-	tmuserBaseURL      string
-	cryptoSecret       string
-	jsonWebToken       *jwt.JSONWebToken
-	session            session.Session
-	cache              redis.UniversalClient
-	publisher          pubsub.Publisher
-	customerRepository CustomerRepository
+	appName                  string
+	logger                   *logrus.Logger
+	timeout                  time.Duration
+	tmuserBaseURL            string
+	baseURL                  string
+	verificationExpiration   time.Duration
+	deletionGracePeriod      time.Duration
+	cryptoSecret             string
+	jsonWebToken             *jwt.JSONWebToken
+	session                  session.Session
+	cache                    redis.UniversalClient
+	publisher                pubsub.Publisher
+	customerRepository       CustomerRepository
+	orderRepository          OrderRepository
+	acquiredTicketRepository AcquiredTicketRepository
+	cloudTask                gctasks.Client
+	signInEmailGuard         lockout.Guard
+	signInIPGuard            lockout.Guard
 }
 
 // ChangeEmail implements CustomerUseCase.
@@ -98,9 +140,16 @@ func (u *customerUseCase) ChangeEmail(ctx context.Context, req ChangeEmailReques
 	messageHeader := pubsub.MessageHeaders{
 		"origin": u.appName,
 	}
-	u.publisher.Publish(ctx, "customer-change-email", fmt.Sprintf("customer:%d", c.ID), messageHeader, changeEmailEventBuff)
-
-	if err := u.session.Delete(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
+	changeEmailEnvelope := pubsub.NewEnvelope(u.appName, contract.CustomerChangeEmailV1, fmt.Sprintf("customer:%d", c.ID), contract.CustomerChangeEmail{
+		ID:               changeEmailEvent.ID,
+		Name:             changeEmailEvent.Name,
+		ExistingEmail:    changeEmailEvent.ExistingEmail,
+		NewEmail:         changeEmailEvent.NewEmail,
+		VerificationLink: changeEmailEvent.VerficationLink,
+	})
+	pubsub.PublishEvent(ctx, u.publisher, contract.TopicCustomerChangeEmail, fmt.Sprintf("customer:%d", c.ID), messageHeader, changeEmailEnvelope)
+
+	if err := u.session.DeleteDevices(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
 		return ChangeEmailResponse{}, err
 	}
 
@@ -126,28 +175,303 @@ func (u *customerUseCase) ChangePassword(ctx context.Context, req ChangePassword
 		return err
 	}
 
-	hashedExistingPassword := util.GenerateSecret(fmt.Sprintf("%s%s", u.cryptoSecret, req.ExistingPassword), c.PasswordSalt, 256)
-	if hashedExistingPassword != c.Password {
+	if match, _ := password.Verify(u.cryptoSecret, req.ExistingPassword, c.Password, c.PasswordSalt); !match {
 		return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid customer's existing password")
 	}
 
-	newPasswordSalt := util.GenerateRandomHEX(16)
-	newHashedPassword := util.GenerateSecret(fmt.Sprintf("%s%s", u.cryptoSecret, req.NewPassword), newPasswordSalt, 256)
+	if err := u.setPassword(ctx, &c, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := u.customerRepository.Update(ctx, c.ID, c, nil); err != nil {
+		return err
+	}
+
+	if err := u.session.DeleteDevices(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConfirmTwoFactor implements CustomerUseCase.
+func (u *customerUseCase) ConfirmTwoFactor(ctx context.Context, req ConfirmTwoFactorRequest) (RecoveryCodeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	c, err := u.customerRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	if c.TwoFactorEnabled {
+		return RecoveryCodeResponse{}, errors.New(http.StatusConflict, status.ALREADY_EXIST, "customer's two-factor authentication is already enabled")
+	}
+
+	sealedSecret, err := u.cache.Get(ctx, fmt.Sprintf(twoFactorEnrollmentKeyPrefix, c.ID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return RecoveryCodeResponse{}, errors.New(http.StatusForbidden, status.FORBIDDEN, "customer's two-factor enrollment is not found or has expired")
+		}
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return RecoveryCodeResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while confirming customer's two-factor authentication")
+	}
+
+	c.TwoFactorSecret = sealedSecret
+	if ok, err := u.checkTwoFactorTOTP(ctx, c, req.Code); err != nil {
+		return RecoveryCodeResponse{}, err
+	} else if !ok {
+		return RecoveryCodeResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+	}
+
+	recoveryCodes, err := u.setRecoveryCodes(ctx, &c)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+	c.TwoFactorEnabled = true
+	c.UpdatedAt = time.Now()
+
+	if err := u.customerRepository.Update(ctx, c.ID, c, nil); err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	if err := u.cache.Del(ctx, fmt.Sprintf(twoFactorEnrollmentKeyPrefix, c.ID)).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+	}
+
+	return RecoveryCodeResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DeleteProfile implements CustomerUseCase.
+func (u *customerUseCase) DeleteProfile(ctx context.Context, req DeleteProfileRequest) (DeleteProfileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return DeleteProfileResponse{}, err
+	}
+
+	c, err := u.customerRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return DeleteProfileResponse{}, err
+	}
+
+	if match, _ := password.Verify(u.cryptoSecret, req.Password, c.Password, c.PasswordSalt); !match {
+		return DeleteProfileResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid customer's password")
+	}
 
-	c.Password = newHashedPassword
-	c.PasswordSalt = newPasswordSalt
+	if c.TwoFactorEnabled {
+		if ok, err := u.checkTwoFactorCode(ctx, &c, req.Code); err != nil {
+			return DeleteProfileResponse{}, err
+		} else if !ok {
+			return DeleteProfileResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+		}
+	}
+
+	// the time is kept at the precision of the database, so the task can tell its own request apart from a later one.
+	now := time.Now().Truncate(time.Microsecond)
+	scheduledAt := now.Add(u.deletionGracePeriod)
+
+	// the task is created before the deletion is requested, so a requested deletion always has its task. a task whose
+	// request could not be saved does nothing, it does not match the deletion request of the customer.
+	deleteCustomerBuff, _ := json.Marshal(DeleteCustomerEvent{
+		CustomerID:          c.ID,
+		DeletionRequestedAt: now,
+	})
+	tasksRequest := gctasks.Request{
+		URL:    fmt.Sprintf("%s%s", u.baseURL, OnDeleteURLPath),
+		Method: cloudtaskspb.HttpMethod_POST,
+		Body:   deleteCustomerBuff,
+	}
+	if err := u.cloudTask.DeferCreateTaskInTime(DeleteCustomerQueue, tasksRequest, scheduledAt); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return DeleteProfileResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while scheduling customer's deletion")
+	}
+
+	c.DeletionRequestedAt = &now
+	c.UpdatedAt = now
 
 	if err := u.customerRepository.Update(ctx, c.ID, c, nil); err != nil {
+		return DeleteProfileResponse{}, err
+	}
+
+	if err := u.session.DeleteDevices(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
+		return DeleteProfileResponse{}, err
+	}
+
+	return DeleteProfileResponse{DeletionScheduledAt: scheduledAt}, nil
+}
+
+// DisableTwoFactor implements CustomerUseCase.
+func (u *customerUseCase) DisableTwoFactor(ctx context.Context, req DisableTwoFactorRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := u.customerRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	if !c.TwoFactorEnabled {
+		return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "customer's two-factor authentication is not enabled")
+	}
+
+	if match, _ := password.Verify(u.cryptoSecret, req.Password, c.Password, c.PasswordSalt); !match {
+		return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid customer's password")
+	}
+
+	if ok, err := u.checkTwoFactorCode(ctx, &c, req.Code); err != nil {
 		return err
+	} else if !ok {
+		return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+	}
+
+	c.TwoFactorSecret = ""
+	c.TwoFactorEnabled = false
+	c.TwoFactorRecoveryCodes = []string{}
+	c.UpdatedAt = time.Now()
+
+	return u.customerRepository.Update(ctx, c.ID, c, nil)
+}
+
+// EnrollTwoFactor implements CustomerUseCase.
+func (u *customerUseCase) EnrollTwoFactor(ctx context.Context) (EnrollTwoFactorResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return EnrollTwoFactorResponse{}, err
+	}
+
+	c, err := u.customerRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return EnrollTwoFactorResponse{}, err
 	}
 
-	if err := u.session.Delete(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
+	if c.TwoFactorEnabled {
+		return EnrollTwoFactorResponse{}, errors.New(http.StatusConflict, status.ALREADY_EXIST, "customer's two-factor authentication is already enabled")
+	}
+
+	secret, err := twofactor.GenerateSecret()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return EnrollTwoFactorResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while enrolling customer's two-factor authentication")
+	}
+
+	sealedSecret, err := twofactor.Seal(u.cryptoSecret, secret)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return EnrollTwoFactorResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while enrolling customer's two-factor authentication")
+	}
+
+	// the secret is only stored once the customer proves that the authenticator has it.
+	if err := u.cache.Set(ctx, fmt.Sprintf(twoFactorEnrollmentKeyPrefix, c.ID), sealedSecret, twoFactorEnrollmentExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return EnrollTwoFactorResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while enrolling customer's two-factor authentication")
+	}
+
+	resp := EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningURI: twofactor.ProvisioningURI(twoFactorIssuer, c.Email, secret),
+		ExpiresAt:       time.Now().Add(twoFactorEnrollmentExpiresIn),
+	}
+
+	return resp, nil
+}
+
+// ForgotPassword implements CustomerUseCase.
+func (u *customerUseCase) ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	// the limit is checked before the customer is looked up, so an unregistered email is throttled the same way.
+	if err := u.limit(ctx, fmt.Sprintf(resetPasswordLimitKeyPrefix, req.Email), resetPasswordLimit, resetPasswordLimitWindow); err != nil {
+		return err
+	}
+
+	c, err := u.customerRepository.FindByEmail(ctx, req.Email, nil)
+	if err != nil {
+		// an unregistered email is answered the same way as a registered one, so the emails can not be enumerated.
+		if errors.MatchStatus(err, status.NOT_FOUND) {
+			return nil
+		}
 		return err
 	}
 
+	now := time.Now()
+	linkExpiresIn := time.Minute * 15
+	resetPasswordToken := util.GenerateRandomHEX(32)
+	resetPasswordKey := fmt.Sprintf(resetPasswordKeyPrefix, resetPasswordToken)
+	resetPasswordEvent := ResetPasswordEvent{
+		ID:                c.ID,
+		Name:              c.Name,
+		Email:             c.Email,
+		ResetPasswordLink: fmt.Sprintf("%s%s?token=%s", u.tmuserBaseURL, ResetPasswordURLPath, resetPasswordToken),
+		ExpiresAt:         now.Add(linkExpiresIn),
+	}
+
+	resetPasswordEventBuff, _ := json.Marshal(resetPasswordEvent)
+
+	if err := u.cache.Set(ctx, resetPasswordKey, resetPasswordEventBuff, linkExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while requesting reset password")
+	}
+
+	messageHeader := pubsub.MessageHeaders{
+		"origin": u.appName,
+	}
+	resetPasswordEnvelope := pubsub.NewEnvelope(u.appName, contract.CustomerResetPasswordV1, fmt.Sprintf("customer:%d", c.ID), contract.CustomerResetPassword{
+		ID:                resetPasswordEvent.ID,
+		Name:              resetPasswordEvent.Name,
+		Email:             resetPasswordEvent.Email,
+		ResetPasswordLink: resetPasswordEvent.ResetPasswordLink,
+		ExpiresAt:         resetPasswordEvent.ExpiresAt,
+	})
+	pubsub.PublishEvent(ctx, u.publisher, contract.TopicCustomerResetPassword, fmt.Sprintf("customer:%d", c.ID), messageHeader, resetPasswordEnvelope)
+
 	return nil
 }
 
+// GetManySession implements CustomerUseCase.
+func (u *customerUseCase) GetManySession(ctx context.Context) ([]SessionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accs, err := u.session.ListDevices(ctx, fmt.Sprintf("customer:%d", acc.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(accs, func(i, j int) bool {
+		return accs[i].CreatedAt.After(accs[j].CreatedAt)
+	})
+
+	resp := make([]SessionResponse, len(accs))
+	for k, a := range accs {
+		resp[k].PopulateFromAccount(a)
+		resp[k].Current = a.SessionID == acc.SessionID
+	}
+
+	return resp, nil
+}
+
 // GetProfile implements CustomerUseCase.
 func (u *customerUseCase) GetProfile(ctx context.Context) (GetProfileResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
@@ -163,17 +487,315 @@ func (u *customerUseCase) GetProfile(ctx context.Context) (GetProfileResponse, e
 		return GetProfileResponse{}, err
 	}
 
-	resp := GetProfileResponse{
-		ID:                 c.ID,
-		Name:               c.Name,
-		Email:              c.Email,
-		VerificationStatus: c.VerificationStatus,
-		MemberStatus:       c.MemberStatus,
-		CreatedAt:          c.CreatedAt,
-		UpdatedAt:          c.UpdatedAt,
+	resp := GetProfileResponse{
+		ID:                 c.ID,
+		Name:               c.Name,
+		Email:              c.Email,
+		VerificationStatus: c.VerificationStatus,
+		MemberStatus:       c.MemberStatus,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+
+	return resp, nil
+}
+
+// Refresh implements CustomerUseCase.
+func (u *customerUseCase) Refresh(ctx context.Context, req RefreshRequest) (SignInResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	refreshTokenBuff, err := u.cache.Get(ctx, fmt.Sprintf(refreshTokenKeyPrefix, req.RefreshToken)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return SignInResponse{}, errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "invalid refresh token")
+		}
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while refreshing customer's token")
+	}
+
+	var refreshToken RefreshToken
+	json.Unmarshal(refreshTokenBuff, &refreshToken)
+
+	sessionKey := session.Key(fmt.Sprintf("customer:%d", refreshToken.CustomerID), refreshToken.SessionID)
+
+	// a refresh token is rotated only once. Using it again means that it has leaked, so the whole session is revoked.
+	rotated, err := u.cache.SetNX(ctx, fmt.Sprintf(refreshTokenRotatedKeyPrefix, req.RefreshToken), true, refreshTokenExpiresIn).Result()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while refreshing customer's token")
+	}
+
+	if !rotated {
+		u.logger.WithContext(ctx).WithField("session", sessionKey).Warn("a rotated refresh token has been reused")
+		if err := u.session.Delete(ctx, sessionKey); err != nil {
+			return SignInResponse{}, err
+		}
+		return SignInResponse{}, errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "refresh token has already been used, the session has been revoked")
+	}
+
+	acc, err := u.session.Get(ctx, sessionKey)
+	if err != nil {
+		if errors.MatchStatus(err, status.NOT_FOUND) {
+			return SignInResponse{}, errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "the session has been revoked")
+		}
+		return SignInResponse{}, err
+	}
+
+	c, err := u.customerRepository.FindByID(ctx, refreshToken.CustomerID, nil)
+	if err != nil {
+		return SignInResponse{}, err
+	}
+
+	acc.Name = c.Name
+	acc.Email = c.Email
+
+	return u.issueTokens(ctx, c, acc)
+}
+
+// OnDeleteCustomer implements CustomerUseCase.
+func (u *customerUseCase) OnDeleteCustomer(ctx context.Context, e DeleteCustomerEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	c, err := u.customerRepository.FindByID(ctx, e.CustomerID, nil)
+	if err != nil {
+		return err
+	}
+
+	// the deletion has been cancelled, or cancelled and requested again, which has a task of its own.
+	if c.DeletionRequestedAt == nil || !c.DeletionRequestedAt.Equal(e.DeletionRequestedAt) {
+		u.logger.WithContext(ctx).WithField("customer_id", c.ID).Info("the deletion of the customer is no longer requested")
+		return nil
+	}
+
+	if time.Now().Before(c.DeletionRequestedAt.Add(u.deletionGracePeriod)) {
+		return errors.New(http.StatusForbidden, status.FORBIDDEN, "the grace period of customer's deletion has not passed")
+	}
+
+	// a task that is retried after the customer has been anonymized only publishes the deletion again.
+	if c.DeletedAt == nil {
+		if c, err = u.anonymize(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	messageHeader := pubsub.MessageHeaders{
+		"origin": u.appName,
+	}
+	customerDeletedEnvelope := pubsub.NewEnvelope(u.appName, contract.CustomerDeletedV1, fmt.Sprintf("customer:%d", c.ID), contract.CustomerDeleted{
+		ID:                  c.ID,
+		DeletionRequestedAt: *c.DeletionRequestedAt,
+		DeletedAt:           *c.DeletedAt,
+	})
+	if err := pubsub.PublishEvent(ctx, u.publisher, contract.TopicCustomerDeleted, fmt.Sprintf("customer:%d", c.ID), messageHeader, customerDeletedEnvelope); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while publishing customer's deletion")
+	}
+
+	return nil
+}
+
+// anonymize erases the personal data of the customer and of the copies of it in the orders and the acquired tickets.
+func (u *customerUseCase) anonymize(ctx context.Context, c Customer) (Customer, error) {
+	now := time.Now()
+	anonymizedEmail := fmt.Sprintf(anonymizedEmailFormat, c.ID)
+
+	tx, err := u.customerRepository.BeginTx(ctx)
+	if err != nil {
+		return Customer{}, err
+	}
+
+	// the orders and the acquired tickets are financial records, only the personal data in them is anonymized.
+	if err := u.orderRepository.AnonymizeByCustomerID(ctx, c.ID, AnonymizedName, anonymizedEmail, tx); err != nil {
+		u.customerRepository.Rollback(ctx, tx)
+		return Customer{}, err
+	}
+
+	if err := u.acquiredTicketRepository.AnonymizeByCustomerID(ctx, c.ID, AnonymizedName, anonymizedEmail, tx); err != nil {
+		u.customerRepository.Rollback(ctx, tx)
+		return Customer{}, err
+	}
+
+	c.Name = AnonymizedName
+	c.Email = anonymizedEmail
+	c.Password = ""
+	c.PasswordSalt = ""
+	c.TwoFactorSecret = ""
+	c.TwoFactorEnabled = false
+	c.TwoFactorRecoveryCodes = []string{}
+	c.MemberStatus = MemberStatusDeleted
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+
+	if err := u.customerRepository.Update(ctx, c.ID, c, tx); err != nil {
+		u.customerRepository.Rollback(ctx, tx)
+		return Customer{}, err
+	}
+
+	if err := u.customerRepository.CommitTx(ctx, tx); err != nil {
+		u.customerRepository.Rollback(ctx, tx)
+		return Customer{}, err
+	}
+
+	if err := u.session.DeleteDevices(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+	}
+
+	return c, nil
+}
+
+// RegenerateRecoveryCode implements CustomerUseCase.
+func (u *customerUseCase) RegenerateRecoveryCode(ctx context.Context, req RegenerateRecoveryCodeRequest) (RecoveryCodeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	c, err := u.customerRepository.FindByID(ctx, acc.ID, nil)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	if !c.TwoFactorEnabled {
+		return RecoveryCodeResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "customer's two-factor authentication is not enabled")
+	}
+
+	if ok, err := u.checkTwoFactorTOTP(ctx, c, req.Code); err != nil {
+		return RecoveryCodeResponse{}, err
+	} else if !ok {
+		return RecoveryCodeResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+	}
+
+	recoveryCodes, err := u.setRecoveryCodes(ctx, &c)
+	if err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+	c.UpdatedAt = time.Now()
+
+	if err := u.customerRepository.Update(ctx, c.ID, c, nil); err != nil {
+		return RecoveryCodeResponse{}, err
+	}
+
+	return RecoveryCodeResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// ResendVerification implements CustomerUseCase.
+func (u *customerUseCase) ResendVerification(ctx context.Context, req ResendVerificationRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	if err := u.limit(ctx, fmt.Sprintf(resendVerificationLimitKeyPrefix, req.Email), resendVerificationLimit, resendVerificationLimitWindow); err != nil {
+		return err
+	}
+
+	c, err := u.customerRepository.FindByEmail(ctx, req.Email, nil)
+	if err != nil {
+		// an unregistered email is answered the same way as an unverified one, so the emails can not be enumerated.
+		if errors.MatchStatus(err, status.NOT_FOUND) {
+			return nil
+		}
+		return err
+	}
+
+	if c.VerificationStatus != VerificationStatusUnverified {
+		return nil
+	}
+
+	if _, err := u.sendVerificationLink(ctx, c, contract.CustomerVerificationResentV1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword implements CustomerUseCase.
+func (u *customerUseCase) ResetPassword(ctx context.Context, req ResetPasswordRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	// the token is taken out of the cache at once, so it can only be used a single time.
+	key := fmt.Sprintf(resetPasswordKeyPrefix, req.Token)
+	resetPasswordEventBuff, err := u.cache.GetDel(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return errors.New(http.StatusForbidden, status.FORBIDDEN, "invalid reset password token")
+		}
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while resetting customer's password")
+	}
+
+	var resetPasswordEvent ResetPasswordEvent
+	json.Unmarshal(resetPasswordEventBuff, &resetPasswordEvent)
+
+	c, err := u.customerRepository.FindByID(ctx, resetPasswordEvent.ID, nil)
+	if err != nil {
+		if errors.MatchStatus(err, status.NOT_FOUND) {
+			return errors.New(http.StatusForbidden, status.FORBIDDEN, "token is not match any customer data")
+		}
+		return err
+	}
+
+	if c.Email != resetPasswordEvent.Email {
+		return errors.New(http.StatusForbidden, status.FORBIDDEN, "token is not match any customer data")
+	}
+
+	if err := u.setPassword(ctx, &c, req.NewPassword); err != nil {
+		return err
+	}
+	c.UpdatedAt = time.Now()
+
+	if err := u.customerRepository.Update(ctx, c.ID, c, nil); err != nil {
+		return err
+	}
+
+	if err := u.session.DeleteDevices(ctx, fmt.Sprintf("customer:%d", c.ID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllSession implements CustomerUseCase.
+func (u *customerUseCase) RevokeAllSession(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := u.session.DeleteDevices(ctx, fmt.Sprintf("customer:%d", acc.ID)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeSession implements CustomerUseCase.
+func (u *customerUseCase) RevokeSession(ctx context.Context, req RevokeSessionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	acc, err := session.GetAccountFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	key := session.Key(fmt.Sprintf("customer:%d", acc.ID), req.ID)
+	if _, err := u.session.Get(ctx, key); err != nil {
+		return err
+	}
+
+	if err := u.session.Delete(ctx, key); err != nil {
+		return err
 	}
 
-	return resp, nil
+	return nil
 }
 
 // SignIn implements CustomerUseCase.
@@ -181,55 +803,68 @@ func (u *customerUseCase) SignIn(ctx context.Context, req SignInRequest) (SignIn
 	ctx, cancel := context.WithTimeout(ctx, u.timeout)
 	defer cancel()
 
+	if err := u.signInEmailGuard.Check(ctx, req.Email); err != nil {
+		return SignInResponse{}, err
+	}
+
+	if req.IPAddress != "" {
+		if err := u.signInIPGuard.Check(ctx, req.IPAddress); err != nil {
+			return SignInResponse{}, err
+		}
+	}
+
 	c, err := u.customerRepository.FindByEmail(ctx, req.Email, nil)
 	if err != nil {
-		return SignInResponse{}, err
+		if !errors.MatchStatus(err, status.NOT_FOUND) {
+			return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while signing in customer")
+		}
+		// the password is hashed anyway, so an unregistered email takes as long as a wrong password.
+		password.Hash(u.cryptoSecret, req.Password)
+		return SignInResponse{}, u.failSignIn(ctx, req, c)
 	}
 
-	if c.VerificationStatus == VerificationStatusUnverified {
-		return SignInResponse{}, errors.New(http.StatusForbidden, status.FORBIDDEN, "customer is not verified")
+	match, rehash := password.Verify(u.cryptoSecret, req.Password, c.Password, c.PasswordSalt)
+	if !match {
+		return SignInResponse{}, u.failSignIn(ctx, req, c)
 	}
 
-	hashedPassword := util.GenerateSecret(fmt.Sprintf("%s%s", u.cryptoSecret, req.Password), c.PasswordSalt, 256)
-	if c.Password != hashedPassword {
-		return SignInResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid customer's email or password")
+	// the hash is upgraded while the plain password is at hand, failing to do so must not fail the sign in.
+	if rehash {
+		if err := u.setPassword(ctx, &c, req.Password); err == nil {
+			u.customerRepository.Update(ctx, c.ID, c, nil)
+		}
 	}
 
-	now := time.Now()
-	expiresIn := time.Hour * 1
-	expiresAt := now.Add(expiresIn)
-	subject := fmt.Sprintf("customer:%d", c.ID)
-	userType := "CUSTOMER"
+	// the status is only told to the one that knows the password, so it does not reveal that the email is registered.
+	if c.VerificationStatus == VerificationStatusUnverified {
+		return SignInResponse{}, errors.New(http.StatusForbidden, status.FORBIDDEN, "customer is not verified")
+	}
 
-	claim := jwt.Claim{}
-	claim.Subject = subject
-	claim.IssuedAt = now.Unix()
-	claim.ExpiresAt = expiresAt.Unix()
-	claim.Name = c.Name
-	claim.Email = c.Email
-	claim.Type = userType
-	claim.Issuer = "ticket-master"
+	// the failed attempts are only forgotten once the second factor is verified as well.
+	if c.TwoFactorEnabled {
+		return u.challengeTwoFactor(ctx, c, req)
+	}
 
-	idToken, err := u.jsonWebToken.Sign(ctx, claim)
-	if err != nil {
-		u.logger.WithContext(ctx).WithError(err).Error()
+	if err := u.signInEmailGuard.Reset(ctx, req.Email); err != nil {
 		return SignInResponse{}, err
 	}
 
-	if err := u.session.Set(ctx, fmt.Sprintf("%s:%d", "customer", c.ID), session.Account{
-		ID:   c.ID,
-		Name: c.Name,
-		Type: userType,
-	}, expiresIn); err != nil {
+	if err := u.cancelDeletion(ctx, &c); err != nil {
 		return SignInResponse{}, err
 	}
 
-	resp := SignInResponse{
-		Token:     idToken,
-		ExpiresAt: expiresAt,
+	acc := session.Account{
+		ID:        c.ID,
+		Name:      c.Name,
+		Email:     c.Email,
+		Type:      "CUSTOMER",
+		SessionID: util.GenerateRandomHEX(16),
+		IPAddress: req.IPAddress,
+		UserAgent: req.UserAgent,
+		CreatedAt: time.Now(),
 	}
 
-	return resp, nil
+	return u.issueTokens(ctx, c, acc)
 }
 
 // SignOut implements CustomerUseCase.
@@ -247,8 +882,7 @@ func (u *customerUseCase) SignOut(ctx context.Context) error {
 		return err
 	}
 
-	key := fmt.Sprintf("customer:%d", acc.ID)
-	if err := u.session.Delete(ctx, key); err != nil {
+	if err := u.session.Delete(ctx, session.Key(fmt.Sprintf("customer:%d", acc.ID), acc.SessionID)); err != nil {
 		return err
 	}
 
@@ -270,15 +904,19 @@ func (u *customerUseCase) SignUp(ctx context.Context, req SignUpRequest) (SignUp
 	}
 
 	now := time.Now()
-	passwordSalt := util.GenerateRandomHEX(32)
-	hashedPassword := util.GenerateSecret(fmt.Sprintf("%s%s", u.cryptoSecret, req.Password), passwordSalt, 256)
+	hashedPassword, err := password.Hash(u.cryptoSecret, req.Password)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignUpResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while signing up customer")
+	}
+
 	c := Customer{
 		Name:               req.Name,
 		Email:              req.Email,
 		Password:           hashedPassword,
-		PasswordSalt:       passwordSalt,
 		VerificationStatus: VerificationStatusUnverified,
 		MemberStatus:       MemberStatusActive,
+		MemberTier:         MemberTierRegular,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}
@@ -290,32 +928,10 @@ func (u *customerUseCase) SignUp(ctx context.Context, req SignUpRequest) (SignUp
 
 	c.ID = ID
 
-	linkExpiresIn := time.Minute * 5
-	linkExpiresAt := now.Add(linkExpiresIn)
-	verificationToken := util.GenerateRandomHEX(32)
-	verificationKey := fmt.Sprintf(verificationKeyPrefix, verificationToken)
-	verificationLink := fmt.Sprintf("%s%s?token=%s", u.tmuserBaseURL, VerificationURLPath, verificationToken)
-	signUpEvent := SignUpEvent{
-		ID:                 c.ID,
-		Name:               c.Name,
-		Email:              c.Email,
-		VerificationStatus: c.VerificationStatus,
-		MemberStatus:       c.MemberStatus,
-		CreatedAt:          c.CreatedAt,
-		VerificationLink:   verificationLink,
-	}
-
-	signUpEventBuff, _ := json.Marshal(signUpEvent)
-
-	if err := u.cache.Set(ctx, verificationKey, signUpEventBuff, linkExpiresIn).Err(); err != nil {
-		u.logger.WithContext(ctx).WithError(err).Error()
-		return SignUpResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while signing up customer")
-	}
-
-	messageHeader := pubsub.MessageHeaders{
-		"origin": u.appName,
+	linkExpiresAt, err := u.sendVerificationLink(ctx, c, contract.CustomerSignUpV1)
+	if err != nil {
+		return SignUpResponse{}, err
 	}
-	u.publisher.Publish(ctx, "customer-sign-up", fmt.Sprintf("customer:%d", c.ID), messageHeader, signUpEventBuff)
 
 	resp := SignUpResponse{
 		VerificationExpiresAt: linkExpiresAt,
@@ -382,8 +998,11 @@ func (u *customerUseCase) Verify(ctx context.Context, req VerifyRequest) error {
 		return err
 	}
 
-	if err := u.cache.Del(ctx, key).Err(); err != nil {
-		u.logger.WithContext(ctx).WithError(err).Error()
+	// the keys are in different slots of a redis cluster, so they are deleted one by one.
+	for _, k := range []string{key, fmt.Sprintf(verificationCustomerKeyPrefix, c.ID)} {
+		if err := u.cache.Del(ctx, k).Err(); err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+		}
 	}
 
 	return nil
@@ -430,17 +1049,375 @@ func (u *customerUseCase) VerifyChangeEmail(ctx context.Context, req ChangeEmail
 	return nil
 }
 
+// VerifyTwoFactor implements CustomerUseCase.
+func (u *customerUseCase) VerifyTwoFactor(ctx context.Context, req VerifyTwoFactorRequest) (SignInResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	challengeKey := fmt.Sprintf(twoFactorChallengeKeyPrefix, req.ChallengeToken)
+	challengeBuff, err := u.cache.Get(ctx, challengeKey).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return SignInResponse{}, errors.New(http.StatusUnauthorized, status.UNAUTHORIZED, "two-factor challenge is not found or has expired")
+		}
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while verifying customer's two-factor code")
+	}
+
+	var challenge TwoFactorChallenge
+	json.Unmarshal(challengeBuff, &challenge)
+
+	c, err := u.customerRepository.FindByID(ctx, challenge.CustomerID, nil)
+	if err != nil {
+		return SignInResponse{}, err
+	}
+
+	if err := u.signInEmailGuard.Check(ctx, c.Email); err != nil {
+		return SignInResponse{}, err
+	}
+
+	if err := u.limit(ctx, fmt.Sprintf(twoFactorChallengeAttemptPrefix, req.ChallengeToken), twoFactorChallengeAttemptLimit, twoFactorChallengeExpiresIn); err != nil {
+		u.cache.Del(ctx, challengeKey)
+		return SignInResponse{}, err
+	}
+
+	ok, err := u.checkTwoFactorCode(ctx, &c, req.Code)
+	if err != nil {
+		return SignInResponse{}, err
+	}
+
+	// a wrong code counts as a failed sign in, so the code can not be guessed over many challenges.
+	if !ok {
+		if err := u.failSignIn(ctx, SignInRequest{Email: c.Email, IPAddress: challenge.IPAddress}, c); !errors.MatchStatus(err, status.BAD_REQUEST) {
+			return SignInResponse{}, err
+		}
+		return SignInResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid two-factor code")
+	}
+
+	if err := u.cache.Del(ctx, challengeKey).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while verifying customer's two-factor code")
+	}
+
+	if err := u.signInEmailGuard.Reset(ctx, c.Email); err != nil {
+		return SignInResponse{}, err
+	}
+
+	if err := u.cancelDeletion(ctx, &c); err != nil {
+		return SignInResponse{}, err
+	}
+
+	acc := session.Account{
+		ID:        c.ID,
+		Name:      c.Name,
+		Email:     c.Email,
+		Type:      "CUSTOMER",
+		SessionID: util.GenerateRandomHEX(16),
+		IPAddress: challenge.IPAddress,
+		UserAgent: challenge.UserAgent,
+		CreatedAt: time.Now(),
+	}
+
+	return u.issueTokens(ctx, c, acc)
+}
+
+// limit counts the requests of the key within the window and rejects the ones above the limit.
+func (u *customerUseCase) limit(ctx context.Context, key string, limit int64, window time.Duration) error {
+	count, err := u.cache.Incr(ctx, key).Result()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while limiting the requests")
+	}
+
+	if count == 1 {
+		if err := u.cache.Expire(ctx, key, window).Err(); err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+		}
+	}
+
+	if count > limit {
+		return errors.New(http.StatusTooManyRequests, status.TOO_MANY_REQUESTS, "too many requests, please try again later")
+	}
+
+	return nil
+}
+
+// issueTokens signs a new access token for the session of the device and rotates its refresh token. The session lives
+// as long as its latest refresh token.
+func (u *customerUseCase) issueTokens(ctx context.Context, c Customer, acc session.Account) (SignInResponse, error) {
+	now := time.Now()
+	expiresAt := now.Add(accessTokenExpiresIn)
+	refreshTokenExpiresAt := now.Add(refreshTokenExpiresIn)
+	subject := fmt.Sprintf("customer:%d", c.ID)
+
+	claim := jwt.Claim{}
+	claim.Id = acc.SessionID
+	claim.Subject = subject
+	claim.IssuedAt = now.Unix()
+	claim.ExpiresAt = expiresAt.Unix()
+	claim.Name = c.Name
+	claim.Email = c.Email
+	claim.Type = acc.Type
+	claim.Issuer = "ticket-master"
+
+	idToken, err := u.jsonWebToken.Sign(ctx, claim)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, err
+	}
+
+	if err := u.session.SetDevice(ctx, subject, acc, refreshTokenExpiresIn); err != nil {
+		return SignInResponse{}, err
+	}
+
+	refreshToken := util.GenerateRandomHEX(32)
+	refreshTokenBuff, _ := json.Marshal(RefreshToken{
+		CustomerID: c.ID,
+		SessionID:  acc.SessionID,
+	})
+
+	if err := u.cache.Set(ctx, fmt.Sprintf(refreshTokenKeyPrefix, refreshToken), refreshTokenBuff, refreshTokenExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while signing in customer")
+	}
+
+	resp := SignInResponse{
+		Token:                 idToken,
+		ExpiresAt:             expiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+	}
+
+	return resp, nil
+}
+
+// failSignIn records the failed sign in and returns the same error whether the email is registered or not. The customer
+// is notified when the email gets locked.
+func (u *customerUseCase) failSignIn(ctx context.Context, req SignInRequest, c Customer) error {
+	failure, err := u.signInEmailGuard.Fail(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+
+	if req.IPAddress != "" {
+		if _, err := u.signInIPGuard.Fail(ctx, req.IPAddress); err != nil {
+			return err
+		}
+	}
+
+	if failure.Locked && c.ID != 0 {
+		messageHeader := pubsub.MessageHeaders{
+			"origin": u.appName,
+		}
+		signInLockedEnvelope := pubsub.NewEnvelope(u.appName, contract.CustomerSignInLockedV1, fmt.Sprintf("customer:%d", c.ID), contract.CustomerSignInLocked{
+			ID:             c.ID,
+			Name:           c.Name,
+			Email:          c.Email,
+			FailedAttempts: failure.Attempts,
+			LockedUntil:    failure.LockedUntil,
+		})
+		pubsub.PublishEvent(ctx, u.publisher, contract.TopicCustomerSignInLocked, fmt.Sprintf("customer:%d", c.ID), messageHeader, signInLockedEnvelope)
+	}
+
+	return errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid customer's email or password")
+}
+
+// cancelDeletion cancels the requested deletion of the customer that has signed in within the grace period, its task
+// then finds nothing to delete.
+func (u *customerUseCase) cancelDeletion(ctx context.Context, c *Customer) error {
+	if c.DeletionRequestedAt == nil {
+		return nil
+	}
+
+	c.DeletionRequestedAt = nil
+	c.UpdatedAt = time.Now()
+
+	return u.customerRepository.Update(ctx, c.ID, *c, nil)
+}
+
+// challengeTwoFactor holds the sign in of a customer that has enabled the two-factor authentication until a code
+// completes it by VerifyTwoFactor.
+func (u *customerUseCase) challengeTwoFactor(ctx context.Context, c Customer, req SignInRequest) (SignInResponse, error) {
+	challengeToken := util.GenerateRandomHEX(32)
+	challengeBuff, _ := json.Marshal(TwoFactorChallenge{
+		CustomerID: c.ID,
+		IPAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+	})
+
+	if err := u.cache.Set(ctx, fmt.Sprintf(twoFactorChallengeKeyPrefix, challengeToken), challengeBuff, twoFactorChallengeExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return SignInResponse{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while signing in customer")
+	}
+
+	resp := SignInResponse{
+		TwoFactor: &TwoFactorChallengeResponse{
+			ChallengeToken: challengeToken,
+			ExpiresAt:      time.Now().Add(twoFactorChallengeExpiresIn),
+		},
+	}
+
+	return resp, nil
+}
+
+// checkTwoFactorCode tells whether the code is the current code of the authenticator of the customer or one of its
+// recovery codes. A recovery code is used up.
+func (u *customerUseCase) checkTwoFactorCode(ctx context.Context, c *Customer, code string) (bool, error) {
+	if ok, err := u.checkTwoFactorTOTP(ctx, *c, code); ok || err != nil {
+		return ok, err
+	}
+
+	i := twofactor.MatchRecoveryCode(u.cryptoSecret, c.TwoFactorRecoveryCodes, code)
+	if i < 0 {
+		return false, nil
+	}
+
+	recoveryCodes := make([]string, 0, len(c.TwoFactorRecoveryCodes)-1)
+	recoveryCodes = append(recoveryCodes, c.TwoFactorRecoveryCodes[:i]...)
+	c.TwoFactorRecoveryCodes = append(recoveryCodes, c.TwoFactorRecoveryCodes[i+1:]...)
+	c.UpdatedAt = time.Now()
+
+	if err := u.customerRepository.Update(ctx, c.ID, *c, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkTwoFactorTOTP tells whether the code is the current code of the authenticator of the customer. A code is
+// accepted only once, so an observed code can not be replayed.
+func (u *customerUseCase) checkTwoFactorTOTP(ctx context.Context, c Customer, code string) (bool, error) {
+	secret, err := twofactor.Open(u.cryptoSecret, c.TwoFactorSecret)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while checking customer's two-factor code")
+	}
+
+	step, ok := twofactor.Validate(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	fresh, err := u.cache.SetNX(ctx, fmt.Sprintf(twoFactorUsedCodeKeyPrefix, c.ID, step), true, (2*twofactor.Skew+1)*twofactor.Period).Result()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return false, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while checking customer's two-factor code")
+	}
+
+	return fresh, nil
+}
+
+// setRecoveryCodes replaces the recovery codes of the customer and returns the new ones, only their hashes are kept.
+func (u *customerUseCase) setRecoveryCodes(ctx context.Context, c *Customer) ([]string, error) {
+	recoveryCodes, err := twofactor.GenerateRecoveryCodes()
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while generating customer's recovery codes")
+	}
+
+	c.TwoFactorRecoveryCodes = make([]string, len(recoveryCodes))
+	for i, recoveryCode := range recoveryCodes {
+		c.TwoFactorRecoveryCodes[i] = twofactor.HashRecoveryCode(u.cryptoSecret, recoveryCode)
+	}
+
+	return recoveryCodes, nil
+}
+
+// setPassword hashes the plain password into the customer, the salt is kept in the hash so the legacy salt is cleared.
+func (u *customerUseCase) setPassword(ctx context.Context, c *Customer, plain string) error {
+	hashedPassword, err := password.Hash(u.cryptoSecret, plain)
+	if err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while hashing customer's password")
+	}
+
+	c.Password = hashedPassword
+	c.PasswordSalt = ""
+	c.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// sendVerificationLink stores a new verification token of the customer and publishes its link. The previous token of
+// the customer is revoked, so only the latest link can verify the customer.
+func (u *customerUseCase) sendVerificationLink(ctx context.Context, c Customer, schema pubsub.Schema) (time.Time, error) {
+	customerKey := fmt.Sprintf(verificationCustomerKeyPrefix, c.ID)
+	previousToken, err := u.cache.GetDel(ctx, customerKey).Result()
+	if err != nil && err != redis.Nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return time.Time{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while sending verification link")
+	}
+
+	if previousToken != "" {
+		if err := u.cache.Del(ctx, fmt.Sprintf(verificationKeyPrefix, previousToken)).Err(); err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return time.Time{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while sending verification link")
+		}
+	}
+
+	linkExpiresIn := u.verificationExpiration
+	linkExpiresAt := time.Now().Add(linkExpiresIn)
+	verificationToken := util.GenerateRandomHEX(32)
+	verificationKey := fmt.Sprintf(verificationKeyPrefix, verificationToken)
+	verificationLink := fmt.Sprintf("%s%s?token=%s", u.tmuserBaseURL, VerificationURLPath, verificationToken)
+	signUpEvent := SignUpEvent{
+		ID:                 c.ID,
+		Name:               c.Name,
+		Email:              c.Email,
+		VerificationStatus: c.VerificationStatus,
+		MemberStatus:       c.MemberStatus,
+		CreatedAt:          c.CreatedAt,
+		VerificationLink:   verificationLink,
+	}
+
+	signUpEventBuff, _ := json.Marshal(signUpEvent)
+
+	if err := u.cache.Set(ctx, verificationKey, signUpEventBuff, linkExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return time.Time{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while sending verification link")
+	}
+
+	if err := u.cache.Set(ctx, customerKey, verificationToken, linkExpiresIn).Err(); err != nil {
+		u.logger.WithContext(ctx).WithError(err).Error()
+		return time.Time{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occured while sending verification link")
+	}
+
+	messageHeader := pubsub.MessageHeaders{
+		"origin": u.appName,
+	}
+	signUpEnvelope := pubsub.NewEnvelope(u.appName, schema, fmt.Sprintf("customer:%d", c.ID), contract.CustomerSignUp{
+		ID:                 signUpEvent.ID,
+		Name:               signUpEvent.Name,
+		Email:              signUpEvent.Email,
+		VerificationStatus: signUpEvent.VerificationStatus,
+		MemberStatus:       signUpEvent.MemberStatus,
+		VerificationLink:   signUpEvent.VerificationLink,
+		CreatedAt:          signUpEvent.CreatedAt,
+	})
+	pubsub.PublishEvent(ctx, u.publisher, contract.TopicCustomerSignUp, fmt.Sprintf("customer:%d", c.ID), messageHeader, signUpEnvelope)
+
+	return linkExpiresAt, nil
+}
+
 func NewCustomerUseCase(props CustomerUseCaseProperty) CustomerUseCase {
 	return &customerUseCase{
-		appName:            props.AppName,
-		logger:             props.Logger,
-		timeout:            props.Timeout,
-		tmuserBaseURL:      props.TMUserBaseURL,
-		cryptoSecret:       props.CryptoSecret,
-		jsonWebToken:       props.JSONWebToken,
-		session:            props.Session,
-		cache:              props.Cache,
-		publisher:          props.Publisher,
-		customerRepository: props.CustomerRepository,
+		appName:                  props.AppName,
+		logger:                   props.Logger,
+		timeout:                  props.Timeout,
+		tmuserBaseURL:            props.TMUserBaseURL,
+		baseURL:                  props.BaseURL,
+		verificationExpiration:   props.VerificationExpiration,
+		deletionGracePeriod:      props.DeletionGracePeriod,
+		cryptoSecret:             props.CryptoSecret,
+		jsonWebToken:             props.JSONWebToken,
+		session:                  props.Session,
+		cache:                    props.Cache,
+		publisher:                props.Publisher,
+		customerRepository:       props.CustomerRepository,
+		orderRepository:          props.OrderRepository,
+		acquiredTicketRepository: props.AcquiredTicketRepository,
+		cloudTask:                props.CloudTask,
+		signInEmailGuard:         props.SignInEmailGuard,
+		signInIPGuard:            props.SignInIPGuard,
 	}
 }
@@ -0,0 +1,323 @@
+package customer_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/customer"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/twofactor"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+const (
+	cryptoSecret     = "secret"
+	customerID       = int64(1)
+	customerEmail    = "budi@example.com"
+	customerPassword = "rahasia"
+)
+
+type customerRepositoryStandIn struct {
+	customer.CustomerRepository
+	customers map[int64]customer.Customer
+}
+
+func (r *customerRepositoryStandIn) FindByID(ctx context.Context, ID int64, tx *sql.Tx) (customer.Customer, error) {
+	c, ok := r.customers[ID]
+	if !ok {
+		return customer.Customer{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "customer is not found")
+	}
+
+	return c, nil
+}
+
+func (r *customerRepositoryStandIn) FindByEmail(ctx context.Context, email string, tx *sql.Tx) (customer.Customer, error) {
+	for _, c := range r.customers {
+		if c.Email == email {
+			return c, nil
+		}
+	}
+
+	return customer.Customer{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "customer is not found")
+}
+
+func (r *customerRepositoryStandIn) Update(ctx context.Context, ID int64, update customer.Customer, tx *sql.Tx) error {
+	r.customers[ID] = update
+
+	return nil
+}
+
+// customerFixture holds what the use case of newCustomerUseCase is built on.
+type customerFixture struct {
+	customers *customerRepositoryStandIn
+	broker    *pubsub.InMemoryBroker
+	redis     *standin.Redis
+	session   session.Session
+}
+
+// newCustomerUseCase returns the use case of a verified customer that has not enabled the two-factor authentication,
+// its deletion waits for a grace period of an hour.
+func newCustomerUseCase(t *testing.T) (customer.CustomerUseCase, customerFixture) {
+	hashedPassword, err := password.Hash(cryptoSecret, customerPassword)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	logger := standin.Logger()
+	rc := standin.NewRedis()
+	broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Logger: logger})
+	f := customerFixture{
+		customers: &customerRepositoryStandIn{customers: map[int64]customer.Customer{
+			customerID: {
+				ID:                 customerID,
+				Name:               "Budi",
+				Email:              customerEmail,
+				Password:           hashedPassword,
+				VerificationStatus: customer.VerficationStatusVerified,
+				MemberStatus:       customer.MemberStatusActive,
+			},
+		}},
+		broker:  broker,
+		redis:   rc,
+		session: session.NewRedisSessionStore(logger, rc),
+	}
+
+	u := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+		AppName:             "tm-order",
+		Logger:              logger,
+		Timeout:             5 * time.Second,
+		TMUserBaseURL:       "http://localhost/tm-user",
+		BaseURL:             "http://localhost/tm-order",
+		DeletionGracePeriod: time.Hour,
+		CryptoSecret:        cryptoSecret,
+		JSONWebToken:        standin.JSONWebToken(),
+		Session:             f.session,
+		Cache:               rc,
+		Publisher:           pubsub.PublisherWithSerializer(broker.Publisher(), pubsub.JSONSerializer{}),
+		CustomerRepository:  f.customers,
+		SignInEmailGuard:    lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByEmail),
+		SignInIPGuard:       lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByIP),
+	})
+
+	return u, f
+}
+
+// enableTwoFactor enrolls the customer in the two-factor authentication and returns the secret of its authenticator.
+func enableTwoFactor(t *testing.T, f customerFixture) string {
+	secret, err := twofactor.GenerateSecret()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	sealedSecret, err := twofactor.Seal(cryptoSecret, secret)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	c := f.customers.customers[customerID]
+	c.TwoFactorSecret = sealedSecret
+	c.TwoFactorEnabled = true
+	f.customers.customers[customerID] = c
+
+	return secret
+}
+
+// resetPasswordToken requests a reset password link and returns the token of the published link.
+func resetPasswordToken(t *testing.T, u customer.CustomerUseCase, f customerFixture) string {
+	if !assert.NoError(t, u.ForgotPassword(context.Background(), customer.ForgotPasswordRequest{Email: customerEmail})) {
+		t.FailNow()
+	}
+
+	messages := f.broker.Messages(contract.TopicCustomerResetPassword)
+	if !assert.NotEmpty(t, messages) {
+		t.FailNow()
+	}
+
+	var resetPassword struct {
+		Data contract.CustomerResetPassword `json:"data"`
+	}
+	if !assert.NoError(t, json.Unmarshal(messages[len(messages)-1].Value, &resetPassword)) {
+		t.FailNow()
+	}
+
+	link, err := url.Parse(resetPassword.Data.ResetPasswordLink)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return link.Query().Get("token")
+}
+
+func TestCustomerUseCaseResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("an expired token is rejected", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+		token := resetPasswordToken(t, u, f)
+
+		f.redis.Advance(16 * time.Minute)
+
+		err := u.ResetPassword(ctx, customer.ResetPasswordRequest{Token: token, NewPassword: "rahasia-baru"})
+		assert.Equal(t, http.StatusForbidden, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("a token can only be used once", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+		token := resetPasswordToken(t, u, f)
+
+		assert.NoError(t, u.ResetPassword(ctx, customer.ResetPasswordRequest{Token: token, NewPassword: "rahasia-baru"}))
+
+		err := u.ResetPassword(ctx, customer.ResetPasswordRequest{Token: token, NewPassword: "rahasia-lain"})
+		assert.Equal(t, http.StatusForbidden, errors.Destruct(err).HTTPStatusCode)
+
+		_, err = u.SignIn(ctx, customer.SignInRequest{Email: customerEmail, Password: "rahasia-baru"})
+		assert.NoError(t, err, "the reused token does not change the password")
+	})
+}
+
+func TestCustomerUseCaseRefresh(t *testing.T) {
+	ctx := context.Background()
+	u, f := newCustomerUseCase(t)
+
+	signIn, err := u.SignIn(ctx, customer.SignInRequest{Email: customerEmail, Password: customerPassword})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	refreshed, err := u.Refresh(ctx, customer.RefreshRequest{RefreshToken: signIn.RefreshToken})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	_, err = u.Refresh(ctx, customer.RefreshRequest{RefreshToken: signIn.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, errors.Destruct(err).HTTPStatusCode, "a rotated refresh token can not be reused")
+
+	sessions, err := f.session.ListDevices(ctx, fmt.Sprintf("customer:%d", customerID))
+	assert.NoError(t, err)
+	assert.Empty(t, sessions, "reusing a rotated refresh token revokes the session")
+
+	_, err = u.Refresh(ctx, customer.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, errors.Destruct(err).HTTPStatusCode, "the latest refresh token of the revoked session is rejected as well")
+}
+
+func TestCustomerUseCaseSignIn(t *testing.T) {
+	ctx := context.Background()
+	policy := lockout.CustomerSignInByEmail
+
+	t.Run("repeated failures lock the email until the lock is lifted", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+
+		for i := int64(0); i < policy.LockAfter; i++ {
+			_, err := u.SignIn(ctx, customer.SignInRequest{Email: customerEmail, Password: "salah"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+			f.redis.Advance(policy.MaxDelay)
+		}
+
+		assert.Len(t, f.broker.Messages(contract.TopicCustomerSignInLocked), 1, "the customer is told about the lock")
+
+		_, err := u.SignIn(ctx, customer.SignInRequest{Email: customerEmail, Password: customerPassword})
+		assert.Equal(t, http.StatusTooManyRequests, errors.Destruct(err).HTTPStatusCode, "the right password is locked out as well")
+
+		f.redis.Advance(policy.LockDuration)
+
+		_, err = u.SignIn(ctx, customer.SignInRequest{Email: customerEmail, Password: customerPassword})
+		assert.NoError(t, err)
+	})
+
+	t.Run("an unknown email is answered as a wrong password and never reported as locked", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+
+		for i := int64(0); i < policy.LockAfter; i++ {
+			_, err := u.SignIn(ctx, customer.SignInRequest{Email: "nobody@example.com", Password: "salah"})
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+			assert.Equal(t, "invalid customer's email or password", errors.Destruct(err).Message)
+			f.redis.Advance(policy.MaxDelay)
+		}
+
+		assert.Empty(t, f.broker.Messages(contract.TopicCustomerSignInLocked))
+	})
+}
+
+func TestCustomerUseCaseVerifyTwoFactor(t *testing.T) {
+	ctx := context.Background()
+
+	challenge := func(t *testing.T, u customer.CustomerUseCase) string {
+		resp, err := u.SignIn(ctx, customer.SignInRequest{Email: customerEmail, Password: customerPassword})
+		if !assert.NoError(t, err) || !assert.NotNil(t, resp.TwoFactor) {
+			t.FailNow()
+		}
+		assert.Empty(t, resp.Token, "the token is only issued once the code is verified")
+
+		return resp.TwoFactor.ChallengeToken
+	}
+
+	t.Run("a wrong code is rejected", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+		enableTwoFactor(t, f)
+
+		_, err := u.VerifyTwoFactor(ctx, customer.VerifyTwoFactorRequest{ChallengeToken: challenge(t, u), Code: "abcdef"})
+		assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("a code can not be replayed", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+		secret := enableTwoFactor(t, f)
+
+		code, err := twofactor.Code(secret, time.Now())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		_, err = u.VerifyTwoFactor(ctx, customer.VerifyTwoFactorRequest{ChallengeToken: challenge(t, u), Code: code})
+		assert.NoError(t, err)
+
+		_, err = u.VerifyTwoFactor(ctx, customer.VerifyTwoFactorRequest{ChallengeToken: challenge(t, u), Code: code})
+		assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+	})
+
+	t.Run("an expired challenge is rejected", func(t *testing.T) {
+		u, f := newCustomerUseCase(t)
+		secret := enableTwoFactor(t, f)
+		challengeToken := challenge(t, u)
+
+		f.redis.Advance(6 * time.Minute)
+
+		code, err := twofactor.Code(secret, time.Now())
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		_, err = u.VerifyTwoFactor(ctx, customer.VerifyTwoFactorRequest{ChallengeToken: challengeToken, Code: code})
+		assert.Equal(t, http.StatusUnauthorized, errors.Destruct(err).HTTPStatusCode)
+	})
+}
+
+func TestCustomerUseCaseOnDeleteCustomer(t *testing.T) {
+	u, f := newCustomerUseCase(t)
+
+	deletionRequestedAt := time.Now()
+	c := f.customers.customers[customerID]
+	c.DeletionRequestedAt = &deletionRequestedAt
+	f.customers.customers[customerID] = c
+
+	err := u.OnDeleteCustomer(context.Background(), customer.DeleteCustomerEvent{CustomerID: customerID, DeletionRequestedAt: deletionRequestedAt})
+	assert.Equal(t, http.StatusForbidden, errors.Destruct(err).HTTPStatusCode, "the grace period has not passed")
+
+	c = f.customers.customers[customerID]
+	assert.Equal(t, customerEmail, c.Email, "the customer is not anonymized")
+	assert.Nil(t, c.DeletedAt)
+	assert.Empty(t, f.broker.Messages(contract.TopicCustomerDeleted))
+}
@@ -0,0 +1,74 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type ArtistRepository interface {
+	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]Artist, error)
+}
+
+type artistRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewArtistRepository(logger *logrus.Logger, db *sql.DB) ArtistRepository {
+	return &artistRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// FindManyByEventID implements ArtistRepository.
+func (r *artistRepository) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]Artist, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			event_id, name
+		FROM event_artist
+		WHERE
+			event_id = $1
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event artist's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event artist's prorperties")
+	}
+
+	defer rows.Close()
+
+	var data = make([]Artist, 0)
+	for rows.Next() {
+		var a Artist
+
+		err := rows.Scan(&a.EventID, &a.Name)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event artist's prorperties")
+		}
+
+		data = append(data, a)
+	}
+
+	return data, nil
+}
@@ -1,10 +1,12 @@
 package event
 
 import (
+	"math"
 	"time"
 
 	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
-	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
 )
 
 const (
@@ -17,7 +19,19 @@ const (
 	TicketTierBronze       string = "BRONZE"
 	TicketTierSilver       string = "SILVER"
 	TicketTierGold         string = "GOLD"
-	TypeOrderRuleRangeDate string = "ORDER_RULE_RANGE_DATE"
+	TypeOrderRuleRangeDate string = orderrule.TypeRangeDate
+	StatusActive           string = "ACTIVE"
+
+	SaleWindowNotYetOpen  string = "NOT_YET_OPEN"
+	SaleWindowOpen        string = "OPEN"
+	SaleWindowClosedToday string = "CLOSED_TODAY"
+	SaleWindowClosed      string = "CLOSED"
+
+	SearchSortDate     string = "date"
+	SearchSortDistance string = "distance"
+
+	// EarthRadius is the mean radius of the earth in kilometers.
+	EarthRadius float64 = 6371
 )
 
 type Location struct {
@@ -61,14 +75,160 @@ type Event struct {
 	Shows       []Show
 	Description string
 	Status      string
-	OrderRules  OrderRuleAggregation
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Timezone is the IANA name of the zone that the times of the event are written and shown in.
+	Timezone   string
+	OrderRules OrderRuleAggregation
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Location returns the zone of the event, UTC if the timezone of the event can not be loaded.
+func (e Event) Location() *time.Location {
+	location, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+type EventFilter struct {
+	Status string
+}
+
+// GeoRadius is the area within the radius in kilometers around a point.
+type GeoRadius struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+}
+
+// BoundingBox is the range of the coordinates of an area.
+type BoundingBox struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// BoundingBox returns the smallest box that holds the area, it narrows the search down before the distance is computed.
+// The box spans every longitude when the area reaches a pole or crosses the antimeridian.
+func (g GeoRadius) BoundingBox() BoundingBox {
+	angularRadius := g.Radius / EarthRadius
+	latitude := g.Latitude * math.Pi / 180
+	latitudeDelta := angularRadius * 180 / math.Pi
+
+	box := BoundingBox{
+		MinLatitude:  math.Max(g.Latitude-latitudeDelta, -90),
+		MaxLatitude:  math.Min(g.Latitude+latitudeDelta, 90),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	if box.MinLatitude == -90 || box.MaxLatitude == 90 {
+		return box
+	}
+
+	longitudeDelta := math.Asin(math.Sin(angularRadius)/math.Cos(latitude)) * 180 / math.Pi
+	if g.Longitude-longitudeDelta < -180 || g.Longitude+longitudeDelta > 180 {
+		return box
+	}
+
+	box.MinLongitude = g.Longitude - longitudeDelta
+	box.MaxLongitude = g.Longitude + longitudeDelta
+
+	return box
 }
 
+// SearchFilter matches the active events by their shows. From is required, so only the upcoming shows are searched by
+// default.
+type SearchFilter struct {
+	// Query is a full-text search over the name, the description and the artists of the event.
+	Query   string
+	City    string
+	Country string
+	From    time.Time
+	To      *time.Time
+	Near    *GeoRadius
+	Sort    string
+}
+
+// SearchResult is an event that matches the search.
+type SearchResult struct {
+	Event Event
+	// ShowTime is the time of the first show of the event that matches the search.
+	ShowTime time.Time
+	// Distance in kilometers to the nearest show of the event that matches the search, it is only set by the search
+	// near a point.
+	Distance *float64
+}
+
+// OrderRuleAggregation holds the order rules of the event, the range date and the days of the whole event come with
+// the ones that are scoped to its shows and ticket stocks.
 type OrderRuleAggregation struct {
-	OrderRuleRangeDate order.OrderRuleRangeDate
-	OrderRuleDay       []order.OrderRuleDay
+	OrderRuleRangeDate       order.OrderRuleRangeDate
+	OrderRuleDay             []order.OrderRuleDay
+	ScopedOrderRuleRangeDate []order.OrderRuleRangeDate
+	ScopedOrderRuleDay       []order.OrderRuleDay
+	OrderRules               []order.OrderRule
+}
+
+// scoped returns the range dates and the days of the event as the order rule engine resolves them, the ones of the
+// whole event come first.
+func (a OrderRuleAggregation) scoped() ([]orderrule.ScopedRangeDate, []orderrule.ScopedDay) {
+	orderRuleRangeDates := a.ScopedOrderRuleRangeDate
+	if !a.OrderRuleRangeDate.StartDate.IsZero() {
+		orderRuleRangeDates = append([]order.OrderRuleRangeDate{a.OrderRuleRangeDate}, orderRuleRangeDates...)
+	}
+
+	rangeDates := make([]orderrule.ScopedRangeDate, 0, len(orderRuleRangeDates))
+	for _, rd := range orderRuleRangeDates {
+		rangeDates = append(rangeDates, orderrule.ScopedRangeDate{ShowID: rd.ShowID, TicketStockID: rd.TicketStockID, StartDate: rd.StartDate, EndDate: rd.EndDate})
+	}
+
+	days := make([]orderrule.ScopedDay, 0, len(a.OrderRuleDay)+len(a.ScopedOrderRuleDay))
+	for _, d := range append(append([]order.OrderRuleDay{}, a.OrderRuleDay...), a.ScopedOrderRuleDay...) {
+		days = append(days, orderrule.ScopedDay{ShowID: d.ShowID, TicketStockID: d.TicketStockID, Day: d.Day})
+	}
+
+	return rangeDates, days
+}
+
+// SaleWindow returns the status of the sale window of the ticket stock of a show at now, which has to be in the
+// timezone of the event. The attached rules are the built OrderRules. Only the rules about the time are taken into
+// account, the ones about the customer are left to the order.
+func (a OrderRuleAggregation) SaleWindow(attached []orderrule.Rule, showID, ticketStockID string, now time.Time) string {
+	rangeDates, days := a.scoped()
+
+	rules, ok := orderrule.EffectiveTimeRules(rangeDates, days, showID, ticketStockID)
+	if !ok {
+		return SaleWindowNotYetOpen
+	}
+	rules = append(rules, attached...)
+
+	in := orderrule.Input{
+		Now:           now,
+		EventID:       a.OrderRuleRangeDate.EventID,
+		ShowID:        showID,
+		TicketStockID: ticketStockID,
+	}
+	for _, rule := range rules {
+		denial := rule.Evaluate(in)
+		if denial == nil {
+			continue
+		}
+
+		switch denial.Reason {
+		case orderrule.ReasonSalesNotOpen:
+			return SaleWindowNotYetOpen
+		case orderrule.ReasonSalesClosed:
+			return SaleWindowClosed
+		case orderrule.ReasonDayNotAllowed, orderrule.ReasonOutsideSalesHours:
+			return SaleWindowClosedToday
+		}
+	}
+
+	return SaleWindowOpen
 }
 
 type OrderRuleRangeDate struct {
@@ -0,0 +1,130 @@
+package event_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+)
+
+func TestOrderRuleAggregationSaleWindow(t *testing.T) {
+	show, ticketStock := "show-1", "stock-1"
+	// a wednesday.
+	now := time.Date(2026, 1, 7, 10, 0, 0, 0, time.UTC)
+
+	eventRangeDate := order.OrderRuleRangeDate{EventID: "event-1", StartDate: now.AddDate(0, 0, -1), EndDate: now.AddDate(0, 0, 1)}
+	registry := orderrule.NewDefaultRegistry()
+	build := func(ruleType, config string) orderrule.Rule {
+		rule, err := registry.Build(ruleType, json.RawMessage(config))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		return rule
+	}
+
+	testCases := []struct {
+		name       string
+		rules      event.OrderRuleAggregation
+		attached   []orderrule.Rule
+		saleWindow string
+	}{
+		{
+			name:       "without a range date",
+			rules:      event.OrderRuleAggregation{},
+			saleWindow: event.SaleWindowNotYetOpen,
+		},
+		{
+			name:       "within the range date",
+			rules:      event.OrderRuleAggregation{OrderRuleRangeDate: eventRangeDate},
+			saleWindow: event.SaleWindowOpen,
+		},
+		{
+			name: "before the range date of the ticket stock",
+			rules: event.OrderRuleAggregation{
+				OrderRuleRangeDate: eventRangeDate,
+				ScopedOrderRuleRangeDate: []order.OrderRuleRangeDate{
+					{EventID: "event-1", ShowID: &show, TicketStockID: &ticketStock, StartDate: now.Add(time.Hour), EndDate: now.AddDate(0, 0, 1)},
+				},
+			},
+			saleWindow: event.SaleWindowNotYetOpen,
+		},
+		{
+			name: "after the range date of the show",
+			rules: event.OrderRuleAggregation{
+				OrderRuleRangeDate: eventRangeDate,
+				ScopedOrderRuleRangeDate: []order.OrderRuleRangeDate{
+					{EventID: "event-1", ShowID: &show, StartDate: now.AddDate(0, 0, -2), EndDate: now.Add(-time.Hour)},
+				},
+			},
+			saleWindow: event.SaleWindowClosed,
+		},
+		{
+			name: "on a day that is not allowed",
+			rules: event.OrderRuleAggregation{
+				OrderRuleRangeDate: eventRangeDate,
+				OrderRuleDay:       []order.OrderRuleDay{{EventID: "event-1", Day: 6}, {EventID: "event-1", Day: 7}},
+			},
+			saleWindow: event.SaleWindowClosedToday,
+		},
+		{
+			name: "on a day that is allowed for the show",
+			rules: event.OrderRuleAggregation{
+				OrderRuleRangeDate: eventRangeDate,
+				OrderRuleDay:       []order.OrderRuleDay{{EventID: "event-1", Day: 6}},
+				ScopedOrderRuleDay: []order.OrderRuleDay{{EventID: "event-1", ShowID: &show, Day: 3}},
+			},
+			saleWindow: event.SaleWindowOpen,
+		},
+		{
+			name:       "outside the sales hours",
+			rules:      event.OrderRuleAggregation{OrderRuleRangeDate: eventRangeDate},
+			attached:   []orderrule.Rule{build(orderrule.TypeTimeOfDay, `{"start_time":"19:00","end_time":"21:00"}`)},
+			saleWindow: event.SaleWindowClosedToday,
+		},
+		{
+			name:       "the rules about the customer are left to the order",
+			rules:      event.OrderRuleAggregation{OrderRuleRangeDate: eventRangeDate},
+			attached:   []orderrule.Rule{build(orderrule.TypeVerifiedEmail, `{}`)},
+			saleWindow: event.SaleWindowOpen,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.saleWindow, tc.rules.SaleWindow(tc.attached, show, ticketStock, now))
+		})
+	}
+}
+
+func TestGeoRadiusBoundingBox(t *testing.T) {
+	t.Run("the box holds the radius", func(t *testing.T) {
+		// monas, jakarta.
+		near := event.GeoRadius{Latitude: -6.1754, Longitude: 106.8272, Radius: 10}
+		box := near.BoundingBox()
+
+		latitudeDelta := near.Radius / event.EarthRadius * 180 / math.Pi
+		assert.InDelta(t, near.Latitude-latitudeDelta, box.MinLatitude, 1e-9)
+		assert.InDelta(t, near.Latitude+latitudeDelta, box.MaxLatitude, 1e-9)
+		assert.Greater(t, box.MaxLongitude-near.Longitude, latitudeDelta, "a degree of longitude is shorter away from the equator")
+		assert.InDelta(t, near.Longitude-box.MinLongitude, box.MaxLongitude-near.Longitude, 1e-9)
+	})
+
+	t.Run("the box spans every longitude near a pole", func(t *testing.T) {
+		box := event.GeoRadius{Latitude: 89.95, Longitude: 10, Radius: 50}.BoundingBox()
+		assert.Equal(t, float64(90), box.MaxLatitude)
+		assert.Equal(t, float64(-180), box.MinLongitude)
+		assert.Equal(t, float64(180), box.MaxLongitude)
+	})
+
+	t.Run("the box spans every longitude across the antimeridian", func(t *testing.T) {
+		box := event.GeoRadius{Latitude: -17.7134, Longitude: 179.9, Radius: 50}.BoundingBox()
+		assert.Equal(t, float64(-180), box.MinLongitude)
+		assert.Equal(t, float64(180), box.MaxLongitude)
+	})
+}
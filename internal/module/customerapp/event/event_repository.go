@@ -6,6 +6,7 @@ import (
 
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
@@ -14,6 +15,10 @@ import (
 
 type EventRepository interface {
 	FindByID(ctx context.Context, ID string, tx *sql.Tx) (Event, error)
+	FindMany(ctx context.Context, filter EventFilter, offset, limit int64, tx *sql.Tx) ([]Event, error)
+	Count(ctx context.Context, filter EventFilter, tx *sql.Tx) (int64, error)
+	Search(ctx context.Context, filter SearchFilter, offset, limit int64, tx *sql.Tx) ([]SearchResult, error)
+	CountSearch(ctx context.Context, filter SearchFilter, tx *sql.Tx) (int64, error)
 }
 
 type sqlCommand interface {
@@ -45,10 +50,10 @@ func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 
 	query := `
 		SELECT 
-			id, name, description, status, created_at, updated_at
+			id, name, description, status, timezone, created_at, updated_at
 		FROM event
 		WHERE
-			id = $1
+			id = $1 AND deleted_at IS NULL
 		LIMIT 1
 	`
 
@@ -63,7 +68,7 @@ func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 
 	var data Event
 	err = row.Scan(
-		&data.ID, &data.Name, &data.Description, &data.Status, &data.CreatedAt, &data.UpdatedAt,
+		&data.ID, &data.Name, &data.Description, &data.Status, &data.Timezone, &data.CreatedAt, &data.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -75,3 +80,273 @@ func (r *eventRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 
 	return data, nil
 }
+
+// filterCondition returns the where clause and its arguments of the given filter.
+func (r *eventRepository) filterCondition(filter EventFilter) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := make([]interface{}, 0)
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// FindMany implements EventRepository.
+func (r *eventRepository) FindMany(ctx context.Context, filter EventFilter, offset, limit int64, tx *sql.Tx) ([]Event, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	condition, args := r.filterCondition(filter)
+	args = append(args, offset, limit)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			id, name, description, status, timezone, created_at, updated_at
+		FROM event
+		WHERE
+			%s
+		ORDER BY created_at DESC
+		OFFSET $%d
+		LIMIT $%d
+	`, condition, len(args)-1, len(args))
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]Event, 0)
+	for rows.Next() {
+		var e Event
+		err := rows.Scan(&e.ID, &e.Name, &e.Description, &e.Status, &e.Timezone, &e.CreatedAt, &e.UpdatedAt)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of event's prorperties")
+		}
+
+		data = append(data, e)
+	}
+
+	return data, nil
+}
+
+// Count implements EventRepository.
+func (r *eventRepository) Count(ctx context.Context, filter EventFilter, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	condition, args := r.filterCondition(filter)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			COUNT(id)
+		FROM event
+		WHERE
+			%s
+	`, condition)
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting event's prorperties")
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.QueryRowContext(ctx, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting event's prorperties")
+	}
+
+	return count, nil
+}
+
+// searchQuery returns the query of the events that match the search and its arguments, one row per event. The
+// expressions of the full-text search match the indexes of the event and of its artists.
+func (r *eventRepository) searchQuery(filter SearchFilter) (string, []interface{}) {
+	args := make([]interface{}, 0)
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	showConditions := []string{
+		fmt.Sprintf("s.status = %s", arg(StatusActive)),
+		fmt.Sprintf("s.time >= %s", arg(filter.From)),
+	}
+
+	if filter.To != nil {
+		showConditions = append(showConditions, fmt.Sprintf("s.time < %s", arg(*filter.To)))
+	}
+
+	if filter.City != "" {
+		showConditions = append(showConditions, fmt.Sprintf("LOWER(l.city) = LOWER(%s)", arg(filter.City)))
+	}
+
+	if filter.Country != "" {
+		showConditions = append(showConditions, fmt.Sprintf("LOWER(l.country) = LOWER(%s)", arg(filter.Country)))
+	}
+
+	// the haversine distance in kilometers, the bounding box comes first so the index of the coordinates is used.
+	distance := "NULL::FLOAT8"
+	if filter.Near != nil {
+		box := filter.Near.BoundingBox()
+		latitude, longitude := arg(filter.Near.Latitude), arg(filter.Near.Longitude)
+		distance = fmt.Sprintf(
+			"%s::FLOAT8 * 2 * ASIN(SQRT(POWER(SIN(RADIANS(l.latitude - %s::FLOAT8) / 2), 2) + COS(RADIANS(%s::FLOAT8)) * COS(RADIANS(l.latitude)) * POWER(SIN(RADIANS(l.longitude - %s::FLOAT8) / 2), 2)))",
+			arg(EarthRadius), latitude, latitude, longitude,
+		)
+		showConditions = append(showConditions,
+			fmt.Sprintf("l.latitude BETWEEN %s AND %s", arg(box.MinLatitude), arg(box.MaxLatitude)),
+			fmt.Sprintf("l.longitude BETWEEN %s AND %s", arg(box.MinLongitude), arg(box.MaxLongitude)),
+			fmt.Sprintf("%s <= %s", distance, arg(filter.Near.Radius)),
+		)
+	}
+
+	eventConditions := []string{
+		"e.deleted_at IS NULL",
+		fmt.Sprintf("e.status = %s", arg(StatusActive)),
+	}
+
+	if filter.Query != "" {
+		query := arg(filter.Query)
+		eventConditions = append(eventConditions, fmt.Sprintf(`(
+				to_tsvector('simple', e.name || ' ' || e.description) @@ websearch_to_tsquery('simple', %s)
+				OR EXISTS (
+					SELECT 1 FROM event_artist a
+					WHERE a.event_id = e.id AND to_tsvector('simple', a.name) @@ websearch_to_tsquery('simple', %s)
+				)
+			)`, query, query))
+	}
+
+	query := fmt.Sprintf(`
+		WITH matching_show AS (
+			SELECT
+				s.event_id, s.time, %s AS distance
+			FROM event_show s
+			LEFT JOIN event_show_location l ON l.show_id = s.id
+			WHERE
+				%s
+		)
+		SELECT 
+			e.id, e.name, e.description, e.status, e.timezone, e.created_at, e.updated_at,
+			MIN(ms.time) AS show_time, MIN(ms.distance) AS distance
+		FROM event e
+		JOIN matching_show ms ON ms.event_id = e.id
+		WHERE
+			%s
+		GROUP BY e.id
+	`, distance, strings.Join(showConditions, " AND "), strings.Join(eventConditions, " AND "))
+
+	return query, args
+}
+
+// Search implements EventRepository.
+func (r *eventRepository) Search(ctx context.Context, filter SearchFilter, offset, limit int64, tx *sql.Tx) ([]SearchResult, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	searchQuery, args := r.searchQuery(filter)
+	args = append(args, offset, limit)
+
+	orderBy := "show_time ASC, e.id ASC"
+	if filter.Sort == SearchSortDistance {
+		orderBy = "distance ASC, show_time ASC, e.id ASC"
+	}
+
+	query := fmt.Sprintf(`
+		%s
+		ORDER BY %s
+		OFFSET $%d
+		LIMIT $%d
+	`, searchQuery, orderBy, len(args)-1, len(args))
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while searching bunch of event's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while searching bunch of event's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]SearchResult, 0)
+	for rows.Next() {
+		var sr SearchResult
+		var distance sql.NullFloat64
+		err := rows.Scan(
+			&sr.Event.ID, &sr.Event.Name, &sr.Event.Description, &sr.Event.Status, &sr.Event.Timezone, &sr.Event.CreatedAt, &sr.Event.UpdatedAt,
+			&sr.ShowTime, &distance,
+		)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while searching bunch of event's prorperties")
+		}
+
+		if distance.Valid {
+			sr.Distance = &distance.Float64
+		}
+
+		data = append(data, sr)
+	}
+
+	return data, nil
+}
+
+// CountSearch implements EventRepository.
+func (r *eventRepository) CountSearch(ctx context.Context, filter SearchFilter, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	searchQuery, args := r.searchQuery(filter)
+
+	query := fmt.Sprintf(`
+		SELECT 
+			COUNT(*)
+		FROM (%s) searched_event
+	`, searchQuery)
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting searched event's prorperties")
+	}
+	defer stmt.Close()
+
+	var count int64
+	if err := stmt.QueryRowContext(ctx, args...).Scan(&count); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while counting searched event's prorperties")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,170 @@
+package event_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+)
+
+type recordedQuery struct {
+	query string
+	args  []driver.Value
+}
+
+// databaseStandIn records the statements it is given, a count is answered with zero and a search with no rows.
+type databaseStandIn struct {
+	queries []recordedQuery
+}
+
+func (d *databaseStandIn) Connect(ctx context.Context) (driver.Conn, error) {
+	return connStandIn{database: d}, nil
+}
+
+func (d *databaseStandIn) Driver() driver.Driver {
+	return nil
+}
+
+type connStandIn struct {
+	database *databaseStandIn
+}
+
+func (c connStandIn) Prepare(query string) (driver.Stmt, error) {
+	return stmtStandIn{database: c.database, query: strings.Join(strings.Fields(query), " ")}, nil
+}
+
+func (c connStandIn) Close() error {
+	return nil
+}
+
+func (c connStandIn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type stmtStandIn struct {
+	database *databaseStandIn
+	query    string
+}
+
+func (s stmtStandIn) Close() error {
+	return nil
+}
+
+func (s stmtStandIn) NumInput() int {
+	return -1
+}
+
+func (s stmtStandIn) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+
+func (s stmtStandIn) Query(args []driver.Value) (driver.Rows, error) {
+	s.database.queries = append(s.database.queries, recordedQuery{query: s.query, args: args})
+
+	if strings.Contains(s.query, "COUNT(*)") {
+		return &rowsStandIn{columns: []string{"count"}, values: [][]driver.Value{{int64(0)}}}, nil
+	}
+
+	return &rowsStandIn{columns: []string{"id", "name", "description", "status", "timezone", "created_at", "updated_at", "show_time", "distance"}}, nil
+}
+
+type rowsStandIn struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *rowsStandIn) Columns() []string {
+	return r.columns
+}
+
+func (r *rowsStandIn) Close() error {
+	return nil
+}
+
+func (r *rowsStandIn) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+
+	return nil
+}
+
+func newEventRepository() (event.EventRepository, *databaseStandIn) {
+	database := &databaseStandIn{}
+
+	return event.NewEventRepository(standin.Logger(), sql.OpenDB(database)), database
+}
+
+func TestEventRepositorySearch(t *testing.T) {
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("only the given filters are bound", func(t *testing.T) {
+		repository, database := newEventRepository()
+
+		_, err := repository.Search(context.Background(), event.SearchFilter{From: from, Sort: event.SearchSortDate}, 20, 10, nil)
+		assert.NoError(t, err)
+
+		if assert.Len(t, database.queries, 1) {
+			q := database.queries[0]
+			assert.Equal(t, []driver.Value{event.StatusActive, from, event.StatusActive, int64(20), int64(10)}, q.args)
+			assert.Contains(t, q.query, "ORDER BY show_time ASC, e.id ASC OFFSET $4 LIMIT $5")
+			assert.Contains(t, q.query, "NULL::FLOAT8 AS distance")
+			assert.NotContains(t, q.query, "websearch_to_tsquery")
+		}
+	})
+
+	t.Run("every filter is bound in the order of its placeholder", func(t *testing.T) {
+		repository, database := newEventRepository()
+
+		near := event.GeoRadius{Latitude: -6.2, Longitude: 106.8, Radius: 25}
+		box := near.BoundingBox()
+		filter := event.SearchFilter{Query: "coldplay", City: "Jakarta", Country: "Indonesia", From: from, To: &to, Near: &near, Sort: event.SearchSortDistance}
+
+		_, err := repository.Search(context.Background(), filter, 0, 10, nil)
+		assert.NoError(t, err)
+
+		if assert.Len(t, database.queries, 1) {
+			q := database.queries[0]
+			assert.Equal(t, []driver.Value{
+				event.StatusActive, from, to, "Jakarta", "Indonesia",
+				near.Latitude, near.Longitude, event.EarthRadius,
+				box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude, near.Radius,
+				event.StatusActive, "coldplay",
+				int64(0), int64(10),
+			}, q.args)
+			assert.Contains(t, q.query, "s.time < $3")
+			assert.Contains(t, q.query, "LOWER(l.city) = LOWER($4)")
+			assert.Contains(t, q.query, "LOWER(l.country) = LOWER($5)")
+			assert.Contains(t, q.query, "l.latitude BETWEEN $9 AND $10")
+			assert.Contains(t, q.query, "l.longitude BETWEEN $11 AND $12")
+			assert.Contains(t, q.query, "<= $13")
+			assert.Contains(t, q.query, "websearch_to_tsquery('simple', $15)")
+			assert.Contains(t, q.query, "ORDER BY distance ASC, show_time ASC, e.id ASC OFFSET $16 LIMIT $17")
+		}
+	})
+
+	t.Run("the count binds the same filters without the page", func(t *testing.T) {
+		repository, database := newEventRepository()
+
+		count, err := repository.CountSearch(context.Background(), event.SearchFilter{From: from, To: &to, City: "Jakarta"}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		if assert.Len(t, database.queries, 1) {
+			q := database.queries[0]
+			assert.Equal(t, []driver.Value{event.StatusActive, from, to, "Jakarta", event.StatusActive}, q.args)
+			assert.NotContains(t, q.query, "OFFSET")
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/pkg/response"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type HTTPHandler struct {
+	Validate     *validator.Validate
+	EventUseCase EventUseCase
+}
+
+// InitHTTPHandler registers the catalog of the events, it is public so the customers can browse it before they sign in.
+func InitHTTPHandler(router *mux.Router, validate *validator.Validate, eventUseCase EventUseCase) {
+	handler := &HTTPHandler{
+		Validate:     validate,
+		EventUseCase: eventUseCase,
+	}
+
+	router.HandleFunc("/tm-order/v1/customerapp/events", publicMiddleware.SetRouteChain(handler.GetManyEvent)).Methods(http.MethodGet)
+	// the search is registered before the detail, otherwise its path is taken as the id of an event.
+	router.HandleFunc("/tm-order/v1/customerapp/events/search", publicMiddleware.SetRouteChain(handler.SearchEvent)).Methods(http.MethodGet)
+	router.HandleFunc("/tm-order/v1/customerapp/events/{id}", publicMiddleware.SetRouteChain(handler.GetEvent)).Methods(http.MethodGet)
+}
+
+func (handler HTTPHandler) validate(ctx context.Context, payload interface{}) error {
+	err := handler.Validate.StructCtx(ctx, payload)
+	if err == nil {
+		return nil
+	}
+
+	errorFields := err.(validator.ValidationErrors)
+
+	errMessages := make([]string, len(errorFields))
+
+	for k, errorField := range errorFields {
+		errMessages[k] = fmt.Sprintf("invalid '%s' with value '%v'", errorField.Field(), errorField.Value())
+	}
+
+	errorMessage := strings.Join(errMessages, ", ")
+
+	return fmt.Errorf(errorMessage)
+
+}
+
+func (handler HTTPHandler) GetManyEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	qs := r.URL.Query()
+
+	req := GetManyEventRequest{}
+	req.Page, _ = strconv.ParseInt(qs.Get("page"), 10, 64)
+	req.Size, _ = strconv.ParseInt(qs.Get("size"), 10, 64)
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.GetManyEvent(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of events",
+		Data:    resp,
+	})
+}
+
+func (handler HTTPHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := handler.EventUseCase.GetEvent(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "event",
+		Data:    resp,
+	})
+}
+
+// parseSearchEventRequest reads the search from the query string, the times are in RFC 3339 and the coordinates must
+// be finite.
+func parseSearchEventRequest(qs url.Values) (SearchEventRequest, error) {
+	req := SearchEventRequest{}
+	req.Page, _ = strconv.ParseInt(qs.Get("page"), 10, 64)
+	req.Size, _ = strconv.ParseInt(qs.Get("size"), 10, 64)
+	req.Query = qs.Get("q")
+	req.City = qs.Get("city")
+	req.Country = qs.Get("country")
+	req.Sort = qs.Get("sort")
+
+	for key, target := range map[string]**time.Time{"from": &req.From, "to": &req.To} {
+		value := qs.Get(key)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return SearchEventRequest{}, fmt.Errorf("invalid '%s' with value '%s'", key, value)
+		}
+		*target = &t
+	}
+
+	for key, target := range map[string]**float64{"lat": &req.Latitude, "lng": &req.Longitude, "radius": &req.Radius} {
+		value := qs.Get(key)
+		if value == "" {
+			continue
+		}
+
+		// ParseFloat takes NaN and Inf as numbers, they are refused here rather than left to the bounds of the validation.
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return SearchEventRequest{}, fmt.Errorf("invalid '%s' with value '%s'", key, value)
+		}
+		*target = &f
+	}
+
+	return req, nil
+}
+
+func (handler HTTPHandler) SearchEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := parseSearchEventRequest(r.URL.Query())
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	if err := handler.validate(ctx, req); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.RESTEnvelope{
+			Status:  status.BAD_REQUEST,
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := handler.EventUseCase.SearchEvent(ctx, req)
+	if err != nil {
+		ae := errors.Destruct(err)
+		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
+			Status:  ae.Status,
+			Message: ae.Message,
+		})
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.RESTEnvelope{
+		Status:  status.OK,
+		Message: "list of searched events",
+		Data:    resp,
+	})
+}
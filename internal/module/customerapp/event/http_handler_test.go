@@ -0,0 +1,90 @@
+package event_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
+	"github.com/tsel-ticketmaster/tm-order/pkg/validator"
+)
+
+type eventUseCaseStandIn struct {
+	event.EventUseCase
+	searched *event.SearchEventRequest
+}
+
+func (u *eventUseCaseStandIn) SearchEvent(ctx context.Context, req event.SearchEventRequest) (event.SearchEventResponse, error) {
+	u.searched = &req
+
+	return event.SearchEventResponse{Events: []event.SearchedEventResponse{}}, nil
+}
+
+func TestHTTPHandlerSearchEvent(t *testing.T) {
+	search := func(query string) (*httptest.ResponseRecorder, *eventUseCaseStandIn) {
+		useCase := &eventUseCaseStandIn{}
+		router := mux.NewRouter()
+		event.InitHTTPHandler(router, validator.Get(), useCase)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tm-order/v1/customerapp/events/search?"+query, nil))
+
+		return w, useCase
+	}
+
+	t.Run("the query string is parsed into the search", func(t *testing.T) {
+		w, useCase := search("page=2&size=100&q=coldplay&city=Jakarta&country=Indonesia&from=2026-07-01T00:00:00%2B07:00&to=2026-08-01T00:00:00Z&lat=-6.2&lng=106.8&radius=25&sort=distance")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		if assert.NotNil(t, useCase.searched) {
+			req := *useCase.searched
+			assert.Equal(t, int64(2), req.Page)
+			assert.Equal(t, int64(100), req.Size)
+			assert.Equal(t, "coldplay", req.Query)
+			assert.Equal(t, "Jakarta", req.City)
+			assert.Equal(t, "Indonesia", req.Country)
+			assert.Equal(t, "distance", req.Sort)
+			if assert.NotNil(t, req.From) && assert.NotNil(t, req.To) {
+				assert.Equal(t, time.Date(2026, 6, 30, 17, 0, 0, 0, time.UTC), req.From.UTC())
+				assert.Equal(t, time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), req.To.UTC())
+			}
+			if assert.NotNil(t, req.Latitude) && assert.NotNil(t, req.Longitude) && assert.NotNil(t, req.Radius) {
+				assert.Equal(t, -6.2, *req.Latitude)
+				assert.Equal(t, 106.8, *req.Longitude)
+				assert.Equal(t, 25.0, *req.Radius)
+			}
+		}
+	})
+
+	testCases := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{name: "page is required", query: "size=10"},
+		{name: "page starts at one", query: "page=-1&size=10"},
+		{name: "size is bounded", query: "page=1&size=101"},
+		{name: "times are in RFC 3339", query: "page=1&size=10&from=2026-07-01"},
+		{name: "a coordinate is a number", query: "page=1&size=10&lat=north&lng=106.8&radius=25"},
+		{name: "a coordinate is not NaN", query: "page=1&size=10&lat=NaN&lng=106.8&radius=25", message: "invalid 'lat' with value 'NaN'"},
+		{name: "a radius is not infinite", query: "page=1&size=10&lat=-6.2&lng=106.8&radius=Inf", message: "invalid 'radius' with value 'Inf'"},
+		{name: "a coordinate is within its bounds", query: "page=1&size=10&lat=-91&lng=106.8&radius=25"},
+		{name: "a point needs all of its coordinates", query: "page=1&size=10&lat=-6.2&lng=106.8"},
+		{name: "sort is either date or distance", query: "page=1&size=10&sort=name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, useCase := search(tc.query)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Nil(t, useCase.searched)
+			if tc.message != "" {
+				assert.Contains(t, w.Body.String(), tc.message)
+			}
+		})
+	}
+}
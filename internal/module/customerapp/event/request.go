@@ -0,0 +1,24 @@
+package event
+
+import "time"
+
+type GetManyEventRequest struct {
+	Page int64 `validate:"required,min=1"`
+	Size int64 `validate:"required,min=1,max=100"`
+}
+
+type SearchEventRequest struct {
+	Page    int64  `validate:"required,min=1"`
+	Size    int64  `validate:"required,min=1,max=100"`
+	Query   string `validate:"-"`
+	City    string `validate:"-"`
+	Country string `validate:"-"`
+	// From and To bound the time of the shows, From is now when it is not given.
+	From *time.Time `validate:"-"`
+	To   *time.Time `validate:"-"`
+	// Latitude, Longitude and Radius in kilometers search the events near a point.
+	Latitude  *float64 `validate:"required_with=Longitude Radius,omitempty,min=-90,max=90"`
+	Longitude *float64 `validate:"required_with=Latitude Radius,omitempty,min=-180,max=180"`
+	Radius    *float64 `validate:"required_with=Latitude Longitude,omitempty,gt=0,max=1000"`
+	Sort      string   `validate:"omitempty,oneof=date distance"`
+}
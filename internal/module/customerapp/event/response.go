@@ -0,0 +1,137 @@
+package event
+
+import "time"
+
+type EventSummaryResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Timezone    string    `json:"timezone"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (r *EventSummaryResponse) PopulateFromEntity(e Event) {
+	r.ID = e.ID
+	r.Name = e.Name
+	r.Description = e.Description
+	r.Timezone = e.Timezone
+	r.CreatedAt = e.CreatedAt
+}
+
+type GetManyEventResponse struct {
+	Total  int64                  `json:"total"`
+	Events []EventSummaryResponse `json:"events"`
+}
+
+type LocationResponse struct {
+	Country          string  `json:"country"`
+	City             string  `json:"city"`
+	FormattedAddress string  `json:"formatted_address"`
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+}
+
+type TicketStockResponse struct {
+	ID         string  `json:"id"`
+	OnlineFor  *string `json:"online_for"`
+	Tier       string  `json:"tier"`
+	Price      float64 `json:"price"`
+	Remaining  int64   `json:"remaining"`
+	SaleWindow string  `json:"sale_window"`
+}
+
+type ShowResponse struct {
+	ID          string                `json:"id"`
+	Venue       string                `json:"venue"`
+	Type        string                `json:"type"`
+	Location    *LocationResponse     `json:"location"`
+	Time        time.Time             `json:"time"`
+	TicketStock []TicketStockResponse `json:"ticket_stock"`
+}
+
+type EventResponse struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Timezone    string         `json:"timezone"`
+	Artists     []string       `json:"artists"`
+	Shows       []ShowResponse `json:"shows"`
+}
+
+// PopulateFromEntity fills the response with the event, the sale windows are given by the ticket stock id.
+func (r *EventResponse) PopulateFromEntity(e Event, saleWindows map[string]string) {
+	location := e.Location()
+
+	r.ID = e.ID
+	r.Name = e.Name
+	r.Description = e.Description
+	r.Timezone = e.Timezone
+
+	r.Artists = make([]string, len(e.Artists))
+	for k, v := range e.Artists {
+		r.Artists[k] = v.Name
+	}
+
+	r.Shows = make([]ShowResponse, len(e.Shows))
+	for k, v := range e.Shows {
+		var showLocation *LocationResponse
+		if v.Location != nil {
+			showLocation = &LocationResponse{
+				Country:          v.Location.Country,
+				City:             v.Location.City,
+				FormattedAddress: v.Location.FormattedAddress,
+				Latitude:         v.Location.Latitude,
+				Longitude:        v.Location.Longitude,
+			}
+		}
+
+		ticketStock := make([]TicketStockResponse, len(v.TicketStock))
+		for tk, tv := range v.TicketStock {
+			remaining := tv.Allocation - tv.Acquired
+			if remaining < 0 {
+				remaining = 0
+			}
+
+			ticketStock[tk] = TicketStockResponse{
+				ID:         tv.ID,
+				OnlineFor:  tv.OnlineFor,
+				Tier:       tv.Tier,
+				Price:      tv.Price,
+				Remaining:  remaining,
+				SaleWindow: saleWindows[tv.ID],
+			}
+		}
+
+		r.Shows[k] = ShowResponse{
+			ID:          v.ID,
+			Venue:       v.Venue,
+			Type:        v.Type,
+			Location:    showLocation,
+			Time:        v.Time.In(location),
+			TicketStock: ticketStock,
+		}
+	}
+}
+
+type SearchedEventResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Timezone    string    `json:"timezone"`
+	ShowTime    time.Time `json:"show_time"`
+	Distance    *float64  `json:"distance"`
+}
+
+func (r *SearchedEventResponse) PopulateFromEntity(sr SearchResult) {
+	r.ID = sr.Event.ID
+	r.Name = sr.Event.Name
+	r.Description = sr.Event.Description
+	r.Timezone = sr.Event.Timezone
+	r.ShowTime = sr.ShowTime.In(sr.Event.Location())
+	r.Distance = sr.Distance
+}
+
+type SearchEventResponse struct {
+	Total  int64                   `json:"total"`
+	Events []SearchedEventResponse `json:"events"`
+}
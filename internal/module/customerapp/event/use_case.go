@@ -0,0 +1,326 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+	"golang.org/x/sync/errgroup"
+)
+
+type EventUseCase interface {
+	GetManyEvent(ctx context.Context, req GetManyEventRequest) (GetManyEventResponse, error)
+	GetEvent(ctx context.Context, ID string) (EventResponse, error)
+	SearchEvent(ctx context.Context, req SearchEventRequest) (SearchEventResponse, error)
+}
+
+type eventUseCase struct {
+	logger                       *logrus.Logger
+	timeout                      time.Duration
+	eventRepository              EventRepository
+	artistRepository             ArtistRepository
+	showRepository               ShowRepository
+	locationRepository           LocationRepository
+	ticketStockRepository        ticket.TicketStockRepository
+	orderRuleRangeDateRepository order.OrderRuleRangeDateRepository
+	orderRuleDayRepository       order.OrderRuleDayRepository
+	orderRuleRepository          order.OrderRuleRepository
+	orderRuleRegistry            *orderrule.Registry
+}
+
+type EventUseCaseProperty struct {
+	Logger                       *logrus.Logger
+	Timeout                      time.Duration
+	EventRepository              EventRepository
+	ArtistRepository             ArtistRepository
+	ShowRepository               ShowRepository
+	LocationRepository           LocationRepository
+	TicketStockRepository        ticket.TicketStockRepository
+	OrderRuleRangeDateRepository order.OrderRuleRangeDateRepository
+	OrderRuleDayRepository       order.OrderRuleDayRepository
+	OrderRuleRepository          order.OrderRuleRepository
+	OrderRuleRegistry            *orderrule.Registry
+}
+
+func NewEventUseCase(props EventUseCaseProperty) EventUseCase {
+	return &eventUseCase{
+		logger:                       props.Logger,
+		timeout:                      props.Timeout,
+		eventRepository:              props.EventRepository,
+		artistRepository:             props.ArtistRepository,
+		showRepository:               props.ShowRepository,
+		locationRepository:           props.LocationRepository,
+		ticketStockRepository:        props.TicketStockRepository,
+		orderRuleRangeDateRepository: props.OrderRuleRangeDateRepository,
+		orderRuleDayRepository:       props.OrderRuleDayRepository,
+		orderRuleRepository:          props.OrderRuleRepository,
+		orderRuleRegistry:            props.OrderRuleRegistry,
+	}
+}
+
+// GetManyEvent implements EventUseCase.
+func (u *eventUseCase) GetManyEvent(ctx context.Context, req GetManyEventRequest) (GetManyEventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	offset := (req.Page - 1) * req.Size
+	limit := req.Size
+	filter := EventFilter{
+		Status: StatusActive,
+	}
+
+	var events []Event
+	var total int64
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		count, err := u.eventRepository.Count(gctx, filter, nil)
+		if err != nil {
+			return err
+		}
+		total = count
+
+		return nil
+	})
+	g.Go(func() error {
+		bunchOfEvents, err := u.eventRepository.FindMany(gctx, filter, offset, limit, nil)
+		if err != nil {
+			return err
+		}
+		events = bunchOfEvents
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return GetManyEventResponse{}, err
+	}
+
+	resp := GetManyEventResponse{
+		Total:  total,
+		Events: make([]EventSummaryResponse, len(events)),
+	}
+	for k, v := range events {
+		resp.Events[k].PopulateFromEntity(v)
+	}
+
+	return resp, nil
+}
+
+// loadShows fills the event with its active shows, their location and ticket stocks.
+func (u *eventUseCase) loadShows(ctx context.Context, e *Event) error {
+	shows, err := u.showRepository.FindManyByEventID(ctx, e.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	e.Shows = make([]Show, 0, len(shows))
+	for _, v := range shows {
+		if v.Status != StatusActive {
+			continue
+		}
+
+		location, err := u.locationRepository.FindByShowID(ctx, v.ID, nil)
+		if err != nil && !errors.MatchStatus(err, status.NOT_FOUND) {
+			return err
+		}
+		if err == nil {
+			v.Location = &location
+		}
+
+		ticketStock, err := u.ticketStockRepository.FindManyByShowID(ctx, v.ID, nil)
+		if err != nil {
+			return err
+		}
+		v.TicketStock = ticketStock
+
+		e.Shows = append(e.Shows, v)
+	}
+
+	return nil
+}
+
+// loadOrderRules fills the event with the order rules that decide its sale windows.
+func (u *eventUseCase) loadOrderRules(ctx context.Context, e *Event) error {
+	rangeDate, err := u.orderRuleRangeDateRepository.FindByEventID(ctx, e.ID, nil)
+	if err != nil && !errors.MatchStatus(err, status.NOT_FOUND) {
+		return err
+	}
+	e.OrderRules.OrderRuleRangeDate = rangeDate
+
+	scopedRangeDates, err := u.orderRuleRangeDateRepository.FindManyScopedByEventID(ctx, e.ID, nil)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.ScopedOrderRuleRangeDate = scopedRangeDates
+
+	days, err := u.orderRuleDayRepository.FindManyByEventID(ctx, e.ID, nil)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.OrderRuleDay = days
+
+	scopedDays, err := u.orderRuleDayRepository.FindManyScopedByEventID(ctx, e.ID, nil)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.ScopedOrderRuleDay = scopedDays
+
+	rules, err := u.orderRuleRepository.FindManyByEventID(ctx, e.ID, nil)
+	if err != nil {
+		return err
+	}
+	e.OrderRules.OrderRules = rules
+
+	return nil
+}
+
+// saleWindows returns the status of the sale window of every ticket stock of the event by its id.
+func (u *eventUseCase) saleWindows(ctx context.Context, e Event, now time.Time) (map[string]string, error) {
+	attached := make([]orderrule.Rule, 0, len(e.OrderRules.OrderRules))
+	for _, a := range e.OrderRules.OrderRules {
+		rule, err := u.orderRuleRegistry.Build(a.Type, a.Config)
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while loading order rule with id '%d'", a.ID))
+		}
+		attached = append(attached, rule)
+	}
+
+	now = now.In(e.Location())
+	saleWindows := make(map[string]string)
+	for _, s := range e.Shows {
+		for _, ts := range s.TicketStock {
+			saleWindows[ts.ID] = e.OrderRules.SaleWindow(attached, s.ID, ts.ID, now)
+		}
+	}
+
+	return saleWindows, nil
+}
+
+// GetEvent implements EventUseCase.
+func (u *eventUseCase) GetEvent(ctx context.Context, ID string) (EventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	e, err := u.eventRepository.FindByID(ctx, ID, nil)
+	if err != nil {
+		return EventResponse{}, err
+	}
+
+	// an event that is not active is not shown to the customers at all.
+	if e.Status != StatusActive {
+		return EventResponse{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("event's properties with id '%s' is not found", ID))
+	}
+
+	artists, err := u.artistRepository.FindManyByEventID(ctx, e.ID, nil)
+	if err != nil {
+		return EventResponse{}, err
+	}
+	e.Artists = artists
+
+	if err := u.loadShows(ctx, &e); err != nil {
+		return EventResponse{}, err
+	}
+
+	if err := u.loadOrderRules(ctx, &e); err != nil {
+		return EventResponse{}, err
+	}
+
+	saleWindows, err := u.saleWindows(ctx, e, time.Now())
+	if err != nil {
+		return EventResponse{}, err
+	}
+
+	resp := EventResponse{}
+	resp.PopulateFromEntity(e, saleWindows)
+
+	return resp, nil
+}
+
+// SearchEvent implements EventUseCase.
+func (u *eventUseCase) SearchEvent(ctx context.Context, req SearchEventRequest) (SearchEventResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	filter := SearchFilter{
+		Query:   strings.TrimSpace(req.Query),
+		City:    strings.TrimSpace(req.City),
+		Country: strings.TrimSpace(req.Country),
+		From:    time.Now(),
+		To:      req.To,
+		Sort:    req.Sort,
+	}
+
+	if req.From != nil {
+		filter.From = *req.From
+	}
+
+	if filter.To != nil && !filter.To.After(filter.From) {
+		return SearchEventResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "'to' must be after 'from'")
+	}
+
+	if req.Latitude != nil {
+		filter.Near = &GeoRadius{
+			Latitude:  *req.Latitude,
+			Longitude: *req.Longitude,
+			Radius:    *req.Radius,
+		}
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = SearchSortDate
+	}
+
+	if filter.Sort == SearchSortDistance && filter.Near == nil {
+		return SearchEventResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "sorting by distance requires 'lat', 'lng' and 'radius'")
+	}
+
+	offset := (req.Page - 1) * req.Size
+	limit := req.Size
+
+	var results []SearchResult
+	var total int64
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		count, err := u.eventRepository.CountSearch(gctx, filter, nil)
+		if err != nil {
+			return err
+		}
+		total = count
+
+		return nil
+	})
+	g.Go(func() error {
+		bunchOfResults, err := u.eventRepository.Search(gctx, filter, offset, limit, nil)
+		if err != nil {
+			return err
+		}
+		results = bunchOfResults
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return SearchEventResponse{}, err
+	}
+
+	resp := SearchEventResponse{
+		Total:  total,
+		Events: make([]SearchedEventResponse, len(results)),
+	}
+	for k, v := range results {
+		resp.Events[k].PopulateFromEntity(v)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,129 @@
+package event_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+)
+
+// eventRepositoryStandIn records the search it is given, the count and the page are searched at the same time so each
+// keeps its own filter.
+type eventRepositoryStandIn struct {
+	event.EventRepository
+	results      []event.SearchResult
+	searchFilter *event.SearchFilter
+	countFilter  *event.SearchFilter
+	offset       int64
+	limit        int64
+}
+
+func (r *eventRepositoryStandIn) Search(ctx context.Context, filter event.SearchFilter, offset, limit int64, tx *sql.Tx) ([]event.SearchResult, error) {
+	r.searchFilter = &filter
+	r.offset = offset
+	r.limit = limit
+
+	return r.results, nil
+}
+
+func (r *eventRepositoryStandIn) CountSearch(ctx context.Context, filter event.SearchFilter, tx *sql.Tx) (int64, error) {
+	r.countFilter = &filter
+
+	return int64(len(r.results)), nil
+}
+
+func newEventUseCase(repository *eventRepositoryStandIn) event.EventUseCase {
+	return event.NewEventUseCase(event.EventUseCaseProperty{
+		Logger:          standin.Logger(),
+		Timeout:         5 * time.Second,
+		EventRepository: repository,
+	})
+}
+
+func TestEventUseCaseSearchEvent(t *testing.T) {
+	from := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	latitude, longitude, radius := -6.2, 106.8, 25.0
+
+	t.Run("the search is sorted by date and paged by default", func(t *testing.T) {
+		repository := &eventRepositoryStandIn{results: []event.SearchResult{
+			{Event: event.Event{ID: "event-1", Timezone: "Asia/Jakarta"}, ShowTime: from},
+		}}
+
+		resp, err := newEventUseCase(repository).SearchEvent(context.Background(), event.SearchEventRequest{
+			Page: 3, Size: 20, Query: " coldplay ", City: " Jakarta ", Country: "Indonesia",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Total)
+		if assert.Len(t, resp.Events, 1) {
+			assert.Equal(t, "event-1", resp.Events[0].ID)
+			assert.Nil(t, resp.Events[0].Distance)
+		}
+
+		assert.Equal(t, int64(40), repository.offset)
+		assert.Equal(t, int64(20), repository.limit)
+		if assert.NotNil(t, repository.searchFilter) {
+			filter := *repository.searchFilter
+			assert.Equal(t, event.SearchSortDate, filter.Sort)
+			assert.Equal(t, "coldplay", filter.Query)
+			assert.Equal(t, "Jakarta", filter.City)
+			assert.Equal(t, "Indonesia", filter.Country)
+			assert.WithinDuration(t, time.Now(), filter.From, time.Minute, "only the upcoming shows are searched")
+			assert.Nil(t, filter.To)
+			assert.Nil(t, filter.Near)
+			assert.Equal(t, filter, *repository.countFilter, "the total counts the same search")
+		}
+	})
+
+	t.Run("the search near a point is sorted by distance", func(t *testing.T) {
+		repository := &eventRepositoryStandIn{}
+
+		_, err := newEventUseCase(repository).SearchEvent(context.Background(), event.SearchEventRequest{
+			Page: 1, Size: 10, From: &from, To: &to, Latitude: &latitude, Longitude: &longitude, Radius: &radius, Sort: event.SearchSortDistance,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), repository.offset)
+		if assert.NotNil(t, repository.searchFilter) {
+			filter := *repository.searchFilter
+			assert.Equal(t, event.SearchSortDistance, filter.Sort)
+			assert.Equal(t, from, filter.From)
+			assert.Equal(t, &to, filter.To)
+			assert.Equal(t, &event.GeoRadius{Latitude: latitude, Longitude: longitude, Radius: radius}, filter.Near)
+		}
+	})
+
+	testCases := []struct {
+		name string
+		req  event.SearchEventRequest
+	}{
+		{
+			name: "'to' must be after 'from'",
+			req:  event.SearchEventRequest{Page: 1, Size: 10, From: &from, To: &from},
+		},
+		{
+			name: "'to' must be after now when 'from' is not given",
+			req:  event.SearchEventRequest{Page: 1, Size: 10, To: &from},
+		},
+		{
+			name: "sorting by distance requires a point",
+			req:  event.SearchEventRequest{Page: 1, Size: 10, Sort: event.SearchSortDistance},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &eventRepositoryStandIn{}
+
+			_, err := newEventUseCase(repository).SearchEvent(context.Background(), tc.req)
+			assert.Equal(t, http.StatusBadRequest, errors.Destruct(err).HTTPStatusCode)
+			assert.Nil(t, repository.searchFilter)
+			assert.Nil(t, repository.countFilter)
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package order
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+)
 
 type Order struct {
 	ID                      string
@@ -37,13 +42,64 @@ type Item struct {
 	Quantity      int64
 }
 
+// OrderRuleRangeDate applies to the whole event, unless it is scoped to a show or to a ticket stock of a show.
 type OrderRuleRangeDate struct {
-	EventID   string
-	StartDate time.Time
-	EndDate   time.Time
+	EventID       string
+	ShowID        *string
+	TicketStockID *string
+	StartDate     time.Time
+	EndDate       time.Time
 }
 
+// OrderRuleDay applies to the whole event, unless it is scoped to a show or to a ticket stock of a show.
 type OrderRuleDay struct {
-	EventID string
-	Day     int64
+	EventID       string
+	ShowID        *string
+	TicketStockID *string
+	Day           int64
+}
+
+// OrderRule is a rule of the order rule engine attached to an event, the rules of an event are evaluated by their
+// position.
+type OrderRule struct {
+	ID       int64
+	EventID  string
+	Type     string
+	Config   json.RawMessage
+	Position int64
+}
+
+// Scoped returns the range date as the order rule engine resolves it.
+func (r OrderRuleRangeDate) Scoped() orderrule.ScopedRangeDate {
+	return orderrule.ScopedRangeDate{
+		ShowID:        r.ShowID,
+		TicketStockID: r.TicketStockID,
+		StartDate:     r.StartDate,
+		EndDate:       r.EndDate,
+	}
+}
+
+// Scoped returns the day as the order rule engine resolves it.
+func (r OrderRuleDay) Scoped() orderrule.ScopedDay {
+	return orderrule.ScopedDay{
+		ShowID:        r.ShowID,
+		TicketStockID: r.TicketStockID,
+		Day:           r.Day,
+	}
+}
+
+// EffectiveTimeRules returns the range date and the days of the event that apply to the ticket stock of a show, see
+// orderrule.EffectiveTimeRules.
+func EffectiveTimeRules(rangeDates []OrderRuleRangeDate, days []OrderRuleDay, showID, ticketStockID string) ([]orderrule.Rule, bool) {
+	scopedRangeDates := make([]orderrule.ScopedRangeDate, len(rangeDates))
+	for k, rd := range rangeDates {
+		scopedRangeDates[k] = rd.Scoped()
+	}
+
+	scopedDays := make([]orderrule.ScopedDay, len(days))
+	for k, d := range days {
+		scopedDays[k] = d.Scoped()
+	}
+
+	return orderrule.EffectiveTimeRules(scopedRangeDates, scopedDays, showID, ticketStockID)
 }
@@ -0,0 +1,47 @@
+package order_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+)
+
+func TestEffectiveTimeRules(t *testing.T) {
+	show, ticketStock := "show-1", "stock-1"
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rangeDates := []order.OrderRuleRangeDate{
+		{EventID: "event-1", StartDate: start, EndDate: start.AddDate(0, 1, 0)},
+		{EventID: "event-1", ShowID: &show, TicketStockID: &ticketStock, StartDate: start.AddDate(0, 0, 3), EndDate: start.AddDate(0, 1, 0)},
+	}
+	days := []order.OrderRuleDay{
+		{EventID: "event-1", Day: 1},
+		{EventID: "event-1", ShowID: &show, Day: 6},
+	}
+
+	t.Run("the most specific range date and days of the ticket stock", func(t *testing.T) {
+		rules, ok := order.EffectiveTimeRules(rangeDates, days, show, ticketStock)
+		assert.True(t, ok)
+		assert.Equal(t, []orderrule.Rule{
+			orderrule.RangeDate{StartDate: start.AddDate(0, 0, 3), EndDate: start.AddDate(0, 1, 0)},
+			orderrule.Day{Days: []int64{6}},
+		}, rules)
+	})
+
+	t.Run("the rules of the event apply to other shows", func(t *testing.T) {
+		rules, ok := order.EffectiveTimeRules(rangeDates, days, "show-2", "stock-2")
+		assert.True(t, ok)
+		assert.Equal(t, []orderrule.Rule{
+			orderrule.RangeDate{StartDate: start, EndDate: start.AddDate(0, 1, 0)},
+			orderrule.Day{Days: []int64{1}},
+		}, rules)
+	})
+
+	t.Run("no range date applies", func(t *testing.T) {
+		_, ok := order.EffectiveTimeRules(nil, days, show, ticketStock)
+		assert.False(t, ok)
+	})
+}
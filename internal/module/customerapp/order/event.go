@@ -1,5 +1,7 @@
 package order
 
+import "github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+
 type ExpireOrderEvent struct {
 	ID            string
 	TransactionID string
@@ -10,3 +12,41 @@ type PaymentNotificationEvent struct {
 	TransactionStatus string `json:"transaction_status"`
 	OrderID           string `json:"order_id"`
 }
+
+func newOrderPaidEvent(o Order) contract.OrderPaid {
+	items := make([]contract.OrderItem, len(o.Items))
+	for k, v := range o.Items {
+		items[k] = contract.OrderItem{
+			TicketStockID: v.TicketStockID,
+			ShowID:        v.ShowID,
+			EventID:       v.EventID,
+			EventName:     v.EventName,
+			ShowVenue:     v.ShowVenue,
+			Tier:          v.Tier,
+			Price:         v.Price,
+			Quantity:      v.Quantity,
+		}
+	}
+
+	return contract.OrderPaid{
+		ID:                      o.ID,
+		PaymentMethod:           o.PaymentMethod,
+		VirtualAccount:          o.VirtualAccount,
+		TransactionID:           o.TransactionID,
+		Status:                  o.Status,
+		CustomerID:              o.CustomerID,
+		CustomerName:            o.CustomerName,
+		CustomerEmail:           o.CustomerEmail,
+		TaxPercentage:           o.TaxPercentage,
+		ServiceChargePercentage: o.ServiceChargePercentage,
+		DiscountPercentage:      o.DiscountPercentage,
+		ServiceCharge:           o.ServiceCharge,
+		Tax:                     o.Tax,
+		Discount:                o.Discount,
+		Items:                   items,
+		Subtotal:                o.Subtotal,
+		TotalAmount:             o.TotalAmount,
+		CreatedAt:               o.CreatedAt,
+		UpdatedAt:               o.UpdatedAt,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
 	publicMiddleware "github.com/tsel-ticketmaster/tm-order/pkg/middleware"
 	"github.com/tsel-ticketmaster/tm-order/pkg/response"
@@ -116,6 +117,16 @@ func (handler HTTPHandler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := handler.OrderUseCase.PlaceOrder(ctx, req)
 	if err != nil {
+		if denial, ok := err.(*orderrule.Denial); ok {
+			response.JSON(w, http.StatusForbidden, response.RESTEnvelope{
+				Status:  status.FORBIDDEN,
+				Message: denial.Message,
+				Data:    denial,
+			})
+
+			return
+		}
+
 		ae := errors.Destruct(err)
 		response.JSON(w, ae.HTTPStatusCode, response.RESTEnvelope{
 			Status:  ae.Status,
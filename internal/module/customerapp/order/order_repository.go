@@ -18,6 +18,8 @@ type OrderRepository interface {
 
 	Save(ctx context.Context, o Order, tx *sql.Tx) error
 	FindByID(ctx context.Context, ID string, tx *sql.Tx) (Order, error)
+	// FindByIDForUpdate locks the order until the transaction ends, so concurrent status changes are serialized.
+	FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (Order, error)
 	FindMany(ctx context.Context, customerID int64, offset, limit int64, tx *sql.Tx) ([]Order, error)
 	Count(ctx context.Context, customerID int64, tx *sql.Tx) (int64, error)
 	Update(ctx context.Context, ID string, o Order, tx *sql.Tx) error
@@ -76,6 +78,15 @@ func (r *orderRepository) Rollback(ctx context.Context, tx *sql.Tx) error {
 
 // FindByID implements OrderRepository.
 func (r *orderRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (Order, error) {
+	return r.findByID(ctx, ID, false, tx)
+}
+
+// FindByIDForUpdate implements OrderRepository.
+func (r *orderRepository) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (Order, error) {
+	return r.findByID(ctx, ID, true, tx)
+}
+
+func (r *orderRepository) findByID(ctx context.Context, ID string, forUpdate bool, tx *sql.Tx) (Order, error) {
 	var cmd sqlCommand = r.db
 
 	if tx != nil {
@@ -92,6 +103,9 @@ func (r *orderRepository) FindByID(ctx context.Context, ID string, tx *sql.Tx) (
 			id = $1
 		LIMIT 1
 	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
 
 	stmt, err := cmd.PrepareContext(ctx, query)
 	if err != nil {
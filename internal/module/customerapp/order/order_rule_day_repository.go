@@ -11,6 +11,7 @@ import (
 )
 
 type OrderRuleDayRepository interface {
+	// FindManyByEventID returns the days of the event including the ones of its shows and ticket stocks.
 	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleDay, error)
 }
 
@@ -29,7 +30,7 @@ func (r *orderRuleDayRepository) FindManyByEventID(ctx context.Context, eventID
 
 	query := `
 		SELECT 
-			event_id, day
+			event_id, show_id, ticket_stock_id, day
 		FROM order_rule_day
 		WHERE
 			event_id = $1
@@ -54,7 +55,7 @@ func (r *orderRuleDayRepository) FindManyByEventID(ctx context.Context, eventID
 	for rows.Next() {
 		var rule OrderRuleDay
 
-		err := rows.Scan(&rule.EventID, &rule.Day)
+		err := rows.Scan(&rule.EventID, &rule.ShowID, &rule.TicketStockID, &rule.Day)
 		if err != nil {
 			r.logger.WithContext(ctx).WithError(err).Error()
 			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule day's prorperties")
@@ -3,7 +3,6 @@ package order
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
@@ -12,7 +11,8 @@ import (
 )
 
 type OrderRuleRangeDateRepository interface {
-	FindByEventID(ctx context.Context, eventID string, tx *sql.Tx) (OrderRuleRangeDate, error)
+	// FindManyByEventID returns the range dates of the event including the ones of its shows and ticket stocks.
+	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleRangeDate, error)
 }
 
 type orderRuleRangeDateRepository struct {
@@ -27,8 +27,8 @@ func NewOrderRuleRangeDateRepository(logger *logrus.Logger, db *sql.DB) OrderRul
 	}
 }
 
-// FindByEventID implements OrderRuleRangeDateRepository.
-func (r *orderRuleRangeDateRepository) FindByEventID(ctx context.Context, eventID string, tx *sql.Tx) (OrderRuleRangeDate, error) {
+// FindManyByEventID implements OrderRuleRangeDateRepository.
+func (r *orderRuleRangeDateRepository) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRuleRangeDate, error) {
 	var cmd sqlCommand = r.db
 
 	if tx != nil {
@@ -37,32 +37,38 @@ func (r *orderRuleRangeDateRepository) FindByEventID(ctx context.Context, eventI
 
 	query := `
 		SELECT 
-			event_id, start_date, end_date
+			event_id, show_id, ticket_stock_id, start_date, end_date
 		FROM order_rule_range_date
 		WHERE
 			event_id = $1
-		LIMIT 1
 	`
 
 	stmt, err := cmd.PrepareContext(ctx, query)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error()
-		return OrderRuleRangeDate{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting order rule range date's prorperties")
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule range date's prorperties")
 	}
 	defer stmt.Close()
 
-	row := stmt.QueryRowContext(ctx, eventID)
-
-	var data OrderRuleRangeDate
-	err = row.Scan(
-		&data.EventID, &data.StartDate, &data.EndDate,
-	)
+	rows, err := stmt.QueryContext(ctx, eventID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return OrderRuleRangeDate{}, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("order rule range date's properties with id '%s' is not found", eventID))
-		}
 		r.logger.WithContext(ctx).WithError(err).Error()
-		return OrderRuleRangeDate{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting order rule range date's prorperties")
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule range date's prorperties")
+	}
+
+	defer rows.Close()
+
+	var data = make([]OrderRuleRangeDate, 0)
+	for rows.Next() {
+		var rule OrderRuleRangeDate
+
+		err := rows.Scan(&rule.EventID, &rule.ShowID, &rule.TicketStockID, &rule.StartDate, &rule.EndDate)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule range date's prorperties")
+		}
+
+		data = append(data, rule)
 	}
 
 	return data, nil
@@ -0,0 +1,75 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type OrderRuleRepository interface {
+	FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRule, error)
+}
+
+type orderRuleRepository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewOrderRuleRepository(logger *logrus.Logger, db *sql.DB) OrderRuleRepository {
+	return &orderRuleRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// FindManyByEventID implements OrderRuleRepository.
+func (r *orderRuleRepository) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]OrderRule, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT 
+			id, event_id, type, config, position
+		FROM order_rule
+		WHERE
+			event_id = $1
+		ORDER BY position ASC
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule's prorperties")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, eventID)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule's prorperties")
+	}
+	defer rows.Close()
+
+	var data = make([]OrderRule, 0)
+	for rows.Next() {
+		var rule OrderRule
+		var config []byte
+
+		if err := rows.Scan(&rule.ID, &rule.EventID, &rule.Type, &config, &rule.Position); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of order rule's prorperties")
+		}
+		rule.Config = config
+
+		data = append(data, rule)
+	}
+
+	return data, nil
+}
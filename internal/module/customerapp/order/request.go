@@ -15,6 +15,6 @@ type PlaceOrderRequest struct {
 }
 
 type GetManyOrderRequest struct {
-	Page int64 `validate:"required"`
-	Size int64 `validate:"required"`
+	Page int64 `validate:"required,min=1"`
+	Size int64 `validate:"required,min=1,max=100"`
 }
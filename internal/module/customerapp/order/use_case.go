@@ -11,9 +11,13 @@ import (
 
 	"cloud.google.com/go/cloudtasks/apiv2/cloudtaskspb"
 	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/customer"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/midtrans"
 	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/outbox"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
 	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
 	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
@@ -31,6 +35,7 @@ type OrderUseCase interface {
 }
 
 type orderUseCase struct {
+	appName                      string
 	logger                       *logrus.Logger
 	timeout                      time.Duration
 	baseURL                      string
@@ -42,15 +47,20 @@ type orderUseCase struct {
 	ticketStockRepository        ticket.TicketStockRepository
 	orderRuleRangeDateRepository OrderRuleRangeDateRepository
 	orderRuleDayRepository       OrderRuleDayRepository
+	orderRuleRepository          OrderRuleRepository
+	orderRuleRegistry            *orderrule.Registry
+	customerRepository           customer.CustomerRepository
 	orderRepository              OrderRepository
 	itemRepository               ItemRepository
 	publisher                    pubsub.Publisher
+	outbox                       outbox.Outbox
 	midtransRepository           midtrans.MidtransRepository
 	cloudTask                    gctasks.Client
 	acquiredTicketRepository     ticket.AcquiredTicketRepository
 }
 
 type OrderUseCaseProperty struct {
+	AppName                      string
 	Logger                       *logrus.Logger
 	Timeout                      time.Duration
 	BaseURL                      string
@@ -62,9 +72,13 @@ type OrderUseCaseProperty struct {
 	TicketStockRepository        ticket.TicketStockRepository
 	OrderRuleRangeDateRepository OrderRuleRangeDateRepository
 	OrderRuleDay                 OrderRuleDayRepository
+	OrderRuleRepository          OrderRuleRepository
+	OrderRuleRegistry            *orderrule.Registry
+	CustomerRepository           customer.CustomerRepository
 	OrderRepository              OrderRepository
 	ItemRepository               ItemRepository
 	Publisher                    pubsub.Publisher
+	Outbox                       outbox.Outbox
 	MidtransRepository           midtrans.MidtransRepository
 	CloudTask                    gctasks.Client
 	AcquiredTicketRepository     ticket.AcquiredTicketRepository
@@ -72,6 +86,7 @@ type OrderUseCaseProperty struct {
 
 func NewOrderUseCase(props OrderUseCaseProperty) OrderUseCase {
 	return &orderUseCase{
+		appName:                      props.AppName,
 		logger:                       props.Logger,
 		timeout:                      props.Timeout,
 		baseURL:                      props.BaseURL,
@@ -83,9 +98,13 @@ func NewOrderUseCase(props OrderUseCaseProperty) OrderUseCase {
 		ticketStockRepository:        props.TicketStockRepository,
 		orderRuleRangeDateRepository: props.OrderRuleRangeDateRepository,
 		orderRuleDayRepository:       props.OrderRuleDay,
+		orderRuleRepository:          props.OrderRuleRepository,
+		orderRuleRegistry:            props.OrderRuleRegistry,
+		customerRepository:           props.CustomerRepository,
 		orderRepository:              props.OrderRepository,
 		itemRepository:               props.ItemRepository,
 		publisher:                    props.Publisher,
+		outbox:                       props.Outbox,
 		midtransRepository:           props.MidtransRepository,
 		cloudTask:                    props.CloudTask,
 		acquiredTicketRepository:     props.AcquiredTicketRepository,
@@ -167,7 +186,7 @@ func (u *orderUseCase) OnPaymentNotification(ctx context.Context, e PaymentNotif
 		return err
 	}
 
-	order, err := u.orderRepository.FindByID(ctx, e.OrderID, tx)
+	order, err := u.orderRepository.FindByIDForUpdate(ctx, e.OrderID, tx)
 	if err != nil {
 		u.orderRepository.Rollback(ctx, tx)
 		return err
@@ -191,12 +210,28 @@ func (u *orderUseCase) OnPaymentNotification(ctx context.Context, e PaymentNotif
 		return err
 	}
 
+	// order-paid is critical, it is written to the outbox within the transaction so it is published if and only if
+	// the order is marked as paid.
+	messageHeader := pubsub.MessageHeaders{
+		"origin": u.appName,
+	}
+	orderPaidEvent := pubsub.NewEnvelope(u.appName, contract.OrderPaidV1, order.ID, newOrderPaidEvent(order))
+	outboxID, err := u.outbox.Add(ctx, contract.TopicOrderPaid, *order.TransactionID, messageHeader, orderPaidEvent, tx)
+	if err != nil {
+		u.orderRepository.Rollback(ctx, tx)
+		return err
+	}
+
 	if err := u.orderRepository.CommitTx(ctx, tx); err != nil {
 		return err
 	}
 
-	orderBuff, _ := json.Marshal(order)
-	u.publisher.Publish(ctx, "order-paid", *order.TransactionID, nil, orderBuff)
+	// the order is already paid, a message that could not be published now, or that waits for an earlier message of the
+	// order, is published by the next relay. Returning an error would only make the notification to be retried, which is
+	// a no-op for a paid order.
+	if err := u.outbox.Relay(ctx, outboxID); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithField("order_id", order.ID).Warn("order paid event is left for the next relay")
+	}
 
 	return nil
 }
@@ -212,7 +247,7 @@ func (u *orderUseCase) OnExpireOrder(ctx context.Context, e ExpireOrderEvent) er
 		return err
 	}
 
-	order, err := u.orderRepository.FindByID(ctx, e.ID, tx)
+	order, err := u.orderRepository.FindByIDForUpdate(ctx, e.ID, tx)
 	if err != nil {
 		u.orderRepository.Rollback(ctx, tx)
 		return err
@@ -239,63 +274,76 @@ func (u *orderUseCase) OnExpireOrder(ctx context.Context, e ExpireOrderEvent) er
 	return nil
 }
 
-func (u *orderUseCase) checkRuleRangeDate(ctx context.Context, now time.Time, req PlaceOrderRequest, tx *sql.Tx) error {
-	rangeDate, err := u.orderRuleRangeDateRepository.FindByEventID(ctx, req.EventID, tx)
+// loadRules returns the rules of the order. The range date and the days that apply to the ticket stock come first,
+// followed by the rules attached to the event.
+func (u *orderUseCase) loadRules(ctx context.Context, req PlaceOrderRequest, tx *sql.Tx) ([]orderrule.Rule, error) {
+	rangeDates, err := u.orderRuleRangeDateRepository.FindManyByEventID(ctx, req.EventID, tx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if now.Before(rangeDate.StartDate) {
-		return errors.New(http.StatusForbidden, status.FORBIDDEN, "ticket sales are not yet open")
+	days, err := u.orderRuleDayRepository.FindManyByEventID(ctx, req.EventID, tx)
+	if err != nil {
+		return nil, err
 	}
 
-	if now.After(rangeDate.EndDate) {
-		return errors.New(http.StatusForbidden, status.FORBIDDEN, "ticket sales are already closed")
+	rules, ok := EffectiveTimeRules(rangeDates, days, req.ShowID, req.TicketStockID)
+	if !ok {
+		return nil, errors.New(http.StatusNotFound, status.NOT_FOUND, fmt.Sprintf("order rule range date's properties with id '%s' is not found", req.EventID))
 	}
 
-	return nil
-}
-
-func (u *orderUseCase) checkRuleDay(ctx context.Context, now time.Time, req PlaceOrderRequest, tx *sql.Tx) error {
-	days, err := u.orderRuleDayRepository.FindManyByEventID(ctx, req.EventID, tx)
+	attached, err := u.orderRuleRepository.FindManyByEventID(ctx, req.EventID, tx)
 	if err != nil {
-		return err
-	}
-	weekDayMatch := false
-	for _, weekday := range days {
-		if now.Weekday() == time.Weekday(weekday.Day) {
-			weekDayMatch = true
-			break
-		}
+		return nil, err
 	}
 
-	if !weekDayMatch {
-		return errors.New(http.StatusForbidden, status.FORBIDDEN, "ticket sales are temporary closed for today")
+	for _, a := range attached {
+		rule, err := u.orderRuleRegistry.Build(a.Type, a.Config)
+		if err != nil {
+			u.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, fmt.Sprintf("an error occurred while loading order rule with id '%d'", a.ID))
+		}
+		rules = append(rules, rule)
 	}
 
-	return nil
+	return orderrule.WithDefaultMaximumTicket(rules), nil
 }
 
-func (u *orderUseCase) checkRule(ctx context.Context, now time.Time, req PlaceOrderRequest, tx *sql.Tx) error {
-
-	if err := u.checkRuleRangeDate(ctx, now, req, tx); err != nil {
+// checkRule evaluates the rules of the order at now, which is in the timezone of the event. A rule that does not
+// allow the order is returned as *orderrule.Denial.
+func (u *orderUseCase) checkRule(ctx context.Context, now time.Time, customerID int64, req PlaceOrderRequest, tx *sql.Tx) error {
+	rules, err := u.loadRules(ctx, req, tx)
+	if err != nil {
 		return err
 	}
 
-	if err := u.checkRuleDay(ctx, now, req, tx); err != nil {
+	c, err := u.customerRepository.FindByID(ctx, customerID, tx)
+	if err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func (u *orderUseCase) checkIfAlreadyAcquired(ctx context.Context, customerID int64, req PlaceOrderRequest, tx *sql.Tx) error {
-	totalAqcuired, err := u.acquiredTicketRepository.CountByEventIDAndCustomerID(ctx, req.EventID, customerID, tx)
+	acquired, err := u.acquiredTicketRepository.CountByEventIDAndCustomerID(ctx, req.EventID, customerID, tx)
 	if err != nil {
 		return err
 	}
-	if totalAqcuired >= 1 {
-		return errors.New(http.StatusForbidden, status.FORBIDDEN, "you are already acquired a ticket for this event")
+
+	in := orderrule.Input{
+		Now:           now,
+		EventID:       req.EventID,
+		ShowID:        req.ShowID,
+		TicketStockID: req.TicketStockID,
+		Quantity:      req.Quantity,
+		Customer: orderrule.Customer{
+			ID:            c.ID,
+			Email:         c.Email,
+			EmailVerified: c.VerificationStatus == customer.VerficationStatusVerified,
+			MemberTier:    c.MemberTier,
+		},
+		AcquiredTickets: acquired,
+	}
+
+	if denial := orderrule.Evaluate(rules, in); denial != nil {
+		return denial
 	}
 
 	return nil
@@ -331,15 +379,19 @@ func (u *orderUseCase) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (P
 
 	now := time.Now()
 
-	if err := u.checkRule(ctx, now, req, tx); err != nil {
+	e, err := u.eventRepository.FindByID(ctx, req.EventID, tx)
+	if err != nil {
+		u.orderRepository.Rollback(ctx, tx)
 		return PlaceOrderResponse{}, err
 	}
 
-	if err := u.checkIfAlreadyAcquired(ctx, acc.ID, req, tx); err != nil {
+	if err := u.checkRule(ctx, now.In(e.Location()), acc.ID, req, tx); err != nil {
+		u.orderRepository.Rollback(ctx, tx)
 		return PlaceOrderResponse{}, err
 	}
 
 	if err := u.checkIfActiveOrderExists(ctx, acc.ID, tx); err != nil {
+		u.orderRepository.Rollback(ctx, tx)
 		return PlaceOrderResponse{}, err
 	}
 
@@ -366,12 +418,6 @@ func (u *orderUseCase) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (P
 
 	var subtotal float64
 
-	e, err := u.eventRepository.FindByID(ctx, req.EventID, tx)
-	if err != nil {
-		u.orderRepository.Rollback(ctx, tx)
-		return PlaceOrderResponse{}, err
-	}
-
 	s, err := u.showRepository.FindByID(ctx, req.ShowID, tx)
 	if err != nil {
 		u.orderRepository.Rollback(ctx, tx)
@@ -383,6 +429,11 @@ func (u *orderUseCase) PlaceOrder(ctx context.Context, req PlaceOrderRequest) (P
 		return PlaceOrderResponse{}, errors.New(http.StatusBadRequest, status.BAD_REQUEST, "invalid show id")
 	}
 
+	if e.Status != event.StatusActive || s.Status != event.StatusActive {
+		u.orderRepository.Rollback(ctx, tx)
+		return PlaceOrderResponse{}, errors.New(http.StatusForbidden, status.FORBIDDEN, "event is not open for order")
+	}
+
 	ts, err := u.ticketStockRepository.FindByIDForUpdate(ctx, req.TicketStockID, tx)
 	if err != nil {
 		u.orderRepository.Rollback(ctx, tx)
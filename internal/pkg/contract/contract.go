@@ -0,0 +1,61 @@
+// Package contract holds the stable data transfer objects of every event that is published by this service.
+// The objects are versioned and must only be changed in a backward compatible way. A breaking change requires a new version.
+package contract
+
+import "github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+
+const (
+	TopicOrderPaid             = "order-paid"
+	TopicOrderTicketResend     = "order-ticket-resend"
+	TopicCustomerSignUp        = "customer-sign-up"
+	TopicCustomerChangeEmail   = "customer-change-email"
+	TopicCustomerResetPassword = "customer-reset-password"
+	TopicCustomerSignInLocked  = "customer-sign-in-locked"
+	TopicCustomerDeleted       = "customer-deleted"
+)
+
+var (
+	OrderPaidV1 = pubsub.Schema{
+		Type:    "tm.order.paid",
+		Name:    TopicOrderPaid,
+		Version: "v1",
+	}
+	OrderTicketResendV1 = pubsub.Schema{
+		Type:    "tm.order.ticket_resend_requested",
+		Name:    TopicOrderTicketResend,
+		Version: "v1",
+	}
+	CustomerSignUpV1 = pubsub.Schema{
+		Type:    "tm.customer.signed_up",
+		Name:    TopicCustomerSignUp,
+		Version: "v1",
+	}
+	// CustomerVerificationResentV1 shares the topic and the data of CustomerSignUpV1, so the verification email is sent
+	// by the same consumer. The type tells a resent link apart from a new sign up.
+	CustomerVerificationResentV1 = pubsub.Schema{
+		Type:    "tm.customer.verification_resent",
+		Name:    TopicCustomerSignUp,
+		Version: "v1",
+	}
+	CustomerChangeEmailV1 = pubsub.Schema{
+		Type:    "tm.customer.email_change_requested",
+		Name:    TopicCustomerChangeEmail,
+		Version: "v1",
+	}
+	CustomerResetPasswordV1 = pubsub.Schema{
+		Type:    "tm.customer.password_reset_requested",
+		Name:    TopicCustomerResetPassword,
+		Version: "v1",
+	}
+	CustomerSignInLockedV1 = pubsub.Schema{
+		Type:    "tm.customer.sign_in_locked",
+		Name:    TopicCustomerSignInLocked,
+		Version: "v1",
+	}
+	// CustomerDeletedV1 tells the other services to erase the personal data that they hold of the customer.
+	CustomerDeletedV1 = pubsub.Schema{
+		Type:    "tm.customer.deleted",
+		Name:    TopicCustomerDeleted,
+		Version: "v1",
+	}
+)
@@ -0,0 +1,147 @@
+package contract_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+)
+
+// assertCompatible makes sure that every field of the golden payload is still produced with the same JSON type.
+// New fields may be added freely, but removing, renaming or retyping a field is a breaking change.
+func assertCompatible(t *testing.T, path string, golden, actual interface{}) {
+	switch g := golden.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !assert.Truef(t, ok, "%s: expected object, got %T", path, actual) {
+			return
+		}
+		for k, v := range g {
+			av, ok := a[k]
+			if !assert.Truef(t, ok, "%s.%s: field is missing", path, k) {
+				continue
+			}
+			assertCompatible(t, path+"."+k, v, av)
+		}
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !assert.Truef(t, ok, "%s: expected array, got %T", path, actual) {
+			return
+		}
+		for k := range g {
+			if k < len(a) {
+				assertCompatible(t, fmt.Sprintf("%s[%d]", path, k), g[k], a[k])
+			}
+		}
+	default:
+		assert.IsTypef(t, golden, actual, "%s: type has changed", path)
+	}
+}
+
+func TestContractCompatibility(t *testing.T) {
+	testCases := []struct {
+		schema pubsub.Schema
+		data   func() interface{}
+	}{
+		{schema: contract.OrderPaidV1, data: func() interface{} { return &contract.OrderPaid{} }},
+		{schema: contract.OrderTicketResendV1, data: func() interface{} { return &contract.OrderTicketResend{} }},
+		{schema: contract.CustomerSignUpV1, data: func() interface{} { return &contract.CustomerSignUp{} }},
+		{schema: contract.CustomerVerificationResentV1, data: func() interface{} { return &contract.CustomerSignUp{} }},
+		{schema: contract.CustomerChangeEmailV1, data: func() interface{} { return &contract.CustomerChangeEmail{} }},
+		{schema: contract.CustomerResetPasswordV1, data: func() interface{} { return &contract.CustomerResetPassword{} }},
+		{schema: contract.CustomerSignInLockedV1, data: func() interface{} { return &contract.CustomerSignInLocked{} }},
+		{schema: contract.CustomerDeletedV1, data: func() interface{} { return &contract.CustomerDeleted{} }},
+	}
+
+	for _, tc := range testCases {
+		name := fmt.Sprintf("%s.%s", tc.schema.Name, tc.schema.Version)
+		t.Run(name, func(t *testing.T) {
+			goldenByte, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			data := tc.data()
+			if !assert.NoError(t, json.Unmarshal(goldenByte, data)) {
+				return
+			}
+
+			e := pubsub.NewEnvelope("tm-order", tc.schema, "subject", data)
+			envelopeByte, err := json.Marshal(e)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			envelope := map[string]interface{}{}
+			if !assert.NoError(t, json.Unmarshal(envelopeByte, &envelope)) {
+				return
+			}
+
+			for _, attr := range []string{"id", "type", "source", "specversion", "time", "datacontenttype", "dataschema", "dataversion", "data"} {
+				assert.Contains(t, envelope, attr)
+			}
+			assert.Equal(t, tc.schema.Type, envelope["type"])
+			assert.Equal(t, tc.schema.URI(), envelope["dataschema"])
+			assert.Equal(t, tc.schema.Version, envelope["dataversion"])
+
+			var golden interface{}
+			if !assert.NoError(t, json.Unmarshal(goldenByte, &golden)) {
+				return
+			}
+
+			assertCompatible(t, name, golden, envelope["data"])
+		})
+	}
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	registry, err := pubsub.SchemaRegistryFromFile(filepath.Join("..", "..", "..", "schemas", "registry.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testCases := []struct {
+		schema      pubsub.Schema
+		data        interface{}
+		contentType string
+	}{
+		{schema: contract.OrderPaidV1, data: &contract.OrderPaid{}, contentType: pubsub.ContentTypeProtobuf},
+		{schema: contract.OrderTicketResendV1, data: &contract.OrderTicketResend{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+		{schema: contract.CustomerSignUpV1, data: &contract.CustomerSignUp{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+		{schema: contract.CustomerVerificationResentV1, data: &contract.CustomerSignUp{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+		{schema: contract.CustomerChangeEmailV1, data: &contract.CustomerChangeEmail{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+		{schema: contract.CustomerResetPasswordV1, data: &contract.CustomerResetPassword{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+		{schema: contract.CustomerSignInLockedV1, data: &contract.CustomerSignInLocked{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+		{schema: contract.CustomerDeletedV1, data: &contract.CustomerDeleted{}, contentType: pubsub.ContentTypeCloudEventsJSON},
+	}
+
+	for _, tc := range testCases {
+		name := fmt.Sprintf("%s.%s", tc.schema.Name, tc.schema.Version)
+		t.Run(name, func(t *testing.T) {
+			goldenByte, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+			if !assert.NoError(t, err) {
+				return
+			}
+			if !assert.NoError(t, json.Unmarshal(goldenByte, tc.data)) {
+				return
+			}
+
+			e := pubsub.NewEnvelope("tm-order", tc.schema, "subject", tc.data)
+
+			_, headers, err := pubsub.JSONSerializer{Registry: registry}.Serialize(e)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, headers[pubsub.HeaderSchemaID])
+			assert.Equal(t, pubsub.ContentTypeCloudEventsJSON, headers[pubsub.HeaderContentType])
+
+			_, headers, err = pubsub.ProtobufSerializer{Registry: registry}.Serialize(e)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, headers[pubsub.HeaderSchemaID])
+			assert.Equal(t, tc.contentType, headers[pubsub.HeaderContentType])
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package contract
+
+import "time"
+
+// CustomerSignUp is the data of CustomerSignUpV1 schema.
+type CustomerSignUp struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	Email              string    `json:"email"`
+	VerificationStatus string    `json:"verification_status"`
+	MemberStatus       string    `json:"member_status"`
+	VerificationLink   string    `json:"verification_link"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// CustomerChangeEmail is the data of CustomerChangeEmailV1 schema.
+type CustomerChangeEmail struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	ExistingEmail    string `json:"existing_email"`
+	NewEmail         string `json:"new_email"`
+	VerificationLink string `json:"verification_link"`
+}
+
+// CustomerResetPassword is the data of CustomerResetPasswordV1 schema.
+type CustomerResetPassword struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	Email             string    `json:"email"`
+	ResetPasswordLink string    `json:"reset_password_link"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// CustomerSignInLocked is the data of CustomerSignInLockedV1 schema.
+type CustomerSignInLocked struct {
+	ID             int64     `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	FailedAttempts int64     `json:"failed_attempts"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
+
+// CustomerDeleted is the data of CustomerDeletedV1 schema. It carries no personal data, the customer is already
+// anonymized when it is published.
+type CustomerDeleted struct {
+	ID                  int64     `json:"id"`
+	DeletionRequestedAt time.Time `json:"deletion_requested_at"`
+	DeletedAt           time.Time `json:"deleted_at"`
+}
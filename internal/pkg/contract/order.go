@@ -0,0 +1,95 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract/orderpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type OrderItem struct {
+	TicketStockID string  `json:"ticket_stock_id"`
+	ShowID        string  `json:"show_id"`
+	EventID       string  `json:"event_id"`
+	EventName     string  `json:"event_name"`
+	ShowVenue     string  `json:"show_venue"`
+	Tier          string  `json:"tier"`
+	Price         float64 `json:"price"`
+	Quantity      int64   `json:"quantity"`
+}
+
+// OrderPaid is the data of OrderPaidV1 schema.
+type OrderPaid struct {
+	ID                      string      `json:"id"`
+	PaymentMethod           string      `json:"payment_method"`
+	VirtualAccount          *string     `json:"virtual_account"`
+	TransactionID           *string     `json:"transaction_id"`
+	Status                  string      `json:"status"`
+	CustomerID              int64       `json:"customer_id"`
+	CustomerName            string      `json:"customer_name"`
+	CustomerEmail           string      `json:"customer_email"`
+	TaxPercentage           float64     `json:"tax_percentage"`
+	ServiceChargePercentage float64     `json:"service_charge_percentage"`
+	DiscountPercentage      float64     `json:"discount_percentage"`
+	ServiceCharge           float64     `json:"service_charge"`
+	Tax                     float64     `json:"tax"`
+	Discount                float64     `json:"discount"`
+	Items                   []OrderItem `json:"items"`
+	Subtotal                float64     `json:"subtotal"`
+	TotalAmount             float64     `json:"total_amount"`
+	CreatedAt               time.Time   `json:"created_at"`
+	UpdatedAt               time.Time   `json:"updated_at"`
+}
+
+// OrderTicketResend is the data of OrderTicketResendV1 schema. It asks for the tickets of a paid order to be
+// sent again to the customer.
+type OrderTicketResend struct {
+	OrderID       string      `json:"order_id"`
+	CustomerID    int64       `json:"customer_id"`
+	CustomerName  string      `json:"customer_name"`
+	CustomerEmail string      `json:"customer_email"`
+	Items         []OrderItem `json:"items"`
+	Reason        string      `json:"reason"`
+	RequestedBy   int64       `json:"requested_by"`
+	RequestedAt   time.Time   `json:"requested_at"`
+}
+
+// ToProto implements pubsub.ProtoConvertible.
+func (o OrderPaid) ToProto() proto.Message {
+	items := make([]*orderpb.OrderItem, len(o.Items))
+	for k, v := range o.Items {
+		items[k] = &orderpb.OrderItem{
+			TicketStockId: v.TicketStockID,
+			ShowId:        v.ShowID,
+			EventId:       v.EventID,
+			EventName:     v.EventName,
+			ShowVenue:     v.ShowVenue,
+			Tier:          v.Tier,
+			Price:         v.Price,
+			Quantity:      v.Quantity,
+		}
+	}
+
+	return &orderpb.OrderPaid{
+		Id:                      o.ID,
+		PaymentMethod:           o.PaymentMethod,
+		VirtualAccount:          o.VirtualAccount,
+		TransactionId:           o.TransactionID,
+		Status:                  o.Status,
+		CustomerId:              o.CustomerID,
+		CustomerName:            o.CustomerName,
+		CustomerEmail:           o.CustomerEmail,
+		TaxPercentage:           o.TaxPercentage,
+		ServiceChargePercentage: o.ServiceChargePercentage,
+		DiscountPercentage:      o.DiscountPercentage,
+		ServiceCharge:           o.ServiceCharge,
+		Tax:                     o.Tax,
+		Discount:                o.Discount,
+		Items:                   items,
+		Subtotal:                o.Subtotal,
+		TotalAmount:             o.TotalAmount,
+		CreatedAt:               timestamppb.New(o.CreatedAt),
+		UpdatedAt:               timestamppb.New(o.UpdatedAt),
+	}
+}
@@ -0,0 +1,4 @@
+// Package orderpb holds the protobuf representation of the order events.
+package orderpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative order.proto
@@ -0,0 +1,225 @@
+// Package lockout protects the sign in from brute force. The failed attempts of every identity, e.g. an email or an ip
+// address, are counted within a window. Past a threshold every failure makes the identity wait twice as long before the
+// next attempt and, past another threshold, the identity is locked for a while.
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+var (
+	failuresKeyPrefix = "lockout:%s:failures:%s"
+	delayKeyPrefix    = "lockout:%s:delay:%s"
+	lockKeyPrefix     = "lockout:%s:lock:%s"
+)
+
+var (
+	// CustomerSignInByEmail guards the sign in of a customer per email.
+	CustomerSignInByEmail = Policy{
+		Namespace:    "sign_in:customer:email",
+		Window:       15 * time.Minute,
+		DelayAfter:   3,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockAfter:    10,
+		LockDuration: 15 * time.Minute,
+	}
+	// CustomerSignInByIP guards the sign in of a customer per ip address, it is looser than CustomerSignInByEmail
+	// because many customers can share an address.
+	CustomerSignInByIP = Policy{
+		Namespace:    "sign_in:customer:ip",
+		Window:       15 * time.Minute,
+		DelayAfter:   10,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockAfter:    50,
+		LockDuration: 15 * time.Minute,
+	}
+	// AdminSignInByEmail guards the sign in of an admin per email, it is stricter than CustomerSignInByEmail because an
+	// admin account reaches every order and customer.
+	AdminSignInByEmail = Policy{
+		Namespace:    "sign_in:admin:email",
+		Window:       30 * time.Minute,
+		DelayAfter:   3,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockAfter:    5,
+		LockDuration: 30 * time.Minute,
+	}
+	// AdminSignInByIP guards the sign in of an admin per ip address.
+	AdminSignInByIP = Policy{
+		Namespace:    "sign_in:admin:ip",
+		Window:       30 * time.Minute,
+		DelayAfter:   5,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		LockAfter:    20,
+		LockDuration: 30 * time.Minute,
+	}
+)
+
+type Policy struct {
+	// Namespace separates the counters of the policies that share a redis.
+	Namespace string
+	// Window in which the failed attempts of an identity are counted.
+	Window time.Duration
+	// DelayAfter is the number of failures after which the identity has to wait before the next attempt. The wait
+	// starts at BaseDelay and doubles on every further failure up to MaxDelay.
+	DelayAfter int64
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	// LockAfter is the number of failures that locks the identity for LockDuration. Zero never locks.
+	LockAfter    int64
+	LockDuration time.Duration
+}
+
+// Delay returns the wait before the next attempt after the given number of failures.
+func (p Policy) Delay(attempts int64) time.Duration {
+	if p.DelayAfter <= 0 || attempts < p.DelayAfter {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.DelayAfter; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
+
+// Locks tells whether the given number of failures locks the identity.
+func (p Policy) Locks(attempts int64) bool {
+	return p.LockAfter > 0 && attempts >= p.LockAfter
+}
+
+// Failure is the outcome of a failed attempt.
+type Failure struct {
+	Attempts int64
+	Delay    time.Duration
+	// Locked is true only for the attempt that locks the identity.
+	Locked      bool
+	LockedUntil time.Time
+}
+
+type Guard interface {
+	// Check returns an error if the identity is locked or has to wait before the next attempt.
+	Check(ctx context.Context, identity string) error
+	// Fail records a failed attempt of the identity.
+	Fail(ctx context.Context, identity string) (Failure, error)
+	// Reset forgets the failed attempts of the identity and lifts its lock.
+	Reset(ctx context.Context, identity string) error
+}
+
+type redisGuard struct {
+	l      *logrus.Logger
+	r      redis.UniversalClient
+	policy Policy
+}
+
+// Check implements Guard.
+func (g *redisGuard) Check(ctx context.Context, identity string) error {
+	for _, key := range []string{g.key(lockKeyPrefix, identity), g.key(delayKeyPrefix, identity)} {
+		count, err := g.r.Exists(ctx, key).Result()
+		if err != nil {
+			g.l.WithContext(ctx).WithError(err).Error()
+			return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+
+		if count > 0 {
+			return errors.New(http.StatusTooManyRequests, status.TOO_MANY_REQUESTS, "too many failed attempts, please try again later")
+		}
+	}
+
+	return nil
+}
+
+// Fail implements Guard.
+func (g *redisGuard) Fail(ctx context.Context, identity string) (Failure, error) {
+	failuresKey := g.key(failuresKeyPrefix, identity)
+
+	attempts, err := g.r.Incr(ctx, failuresKey).Result()
+	if err != nil {
+		g.l.WithContext(ctx).WithError(err).Error()
+		return Failure{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	if attempts == 1 {
+		if err := g.r.Expire(ctx, failuresKey, g.policy.Window).Err(); err != nil {
+			g.l.WithContext(ctx).WithError(err).Error()
+		}
+	}
+
+	failure := Failure{Attempts: attempts}
+
+	if g.policy.Locks(attempts) {
+		if err := g.r.Set(ctx, g.key(lockKeyPrefix, identity), attempts, g.policy.LockDuration).Err(); err != nil {
+			g.l.WithContext(ctx).WithError(err).Error()
+			return Failure{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+
+		// the identity starts over once the lock is lifted.
+		if err := g.del(ctx, failuresKey, g.key(delayKeyPrefix, identity)); err != nil {
+			g.l.WithContext(ctx).WithError(err).Error()
+		}
+
+		failure.Locked = true
+		failure.LockedUntil = time.Now().Add(g.policy.LockDuration)
+
+		return failure, nil
+	}
+
+	if delay := g.policy.Delay(attempts); delay > 0 {
+		if err := g.r.Set(ctx, g.key(delayKeyPrefix, identity), attempts, delay).Err(); err != nil {
+			g.l.WithContext(ctx).WithError(err).Error()
+			return Failure{}, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+		failure.Delay = delay
+	}
+
+	return failure, nil
+}
+
+// Reset implements Guard.
+func (g *redisGuard) Reset(ctx context.Context, identity string) error {
+	if err := g.del(ctx, g.key(failuresKeyPrefix, identity), g.key(delayKeyPrefix, identity), g.key(lockKeyPrefix, identity)); err != nil {
+		g.l.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	return nil
+}
+
+// del deletes the keys one by one, the keys of an identity are in different slots of a redis cluster.
+func (g *redisGuard) del(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := g.r.Del(ctx, key).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *redisGuard) key(prefix, identity string) string {
+	return fmt.Sprintf(prefix, g.policy.Namespace, identity)
+}
+
+func NewRedisGuard(l *logrus.Logger, r redis.UniversalClient, policy Policy) Guard {
+	return &redisGuard{
+		l:      l,
+		r:      r,
+		policy: policy,
+	}
+}
@@ -0,0 +1,60 @@
+package lockout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	policy := lockout.Policy{
+		DelayAfter: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   10 * time.Second,
+	}
+
+	testCases := []struct {
+		attempts int64
+		delay    time.Duration
+	}{
+		{attempts: 1, delay: 0},
+		{attempts: 2, delay: 0},
+		{attempts: 3, delay: time.Second},
+		{attempts: 4, delay: 2 * time.Second},
+		{attempts: 5, delay: 4 * time.Second},
+		{attempts: 6, delay: 8 * time.Second},
+		{attempts: 7, delay: 10 * time.Second},
+		{attempts: 1000, delay: 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.delay, policy.Delay(tc.attempts), "attempts %d", tc.attempts)
+	}
+
+	t.Run("a policy without delay never delays", func(t *testing.T) {
+		assert.Zero(t, lockout.Policy{}.Delay(100))
+	})
+}
+
+func TestPolicyLocks(t *testing.T) {
+	policy := lockout.Policy{LockAfter: 10}
+
+	assert.False(t, policy.Locks(9))
+	assert.True(t, policy.Locks(10))
+	assert.True(t, policy.Locks(11))
+
+	t.Run("a policy without lock never locks", func(t *testing.T) {
+		assert.False(t, lockout.Policy{}.Locks(100))
+	})
+}
+
+func TestDefaultPolicies(t *testing.T) {
+	for _, policy := range []lockout.Policy{lockout.CustomerSignInByEmail, lockout.CustomerSignInByIP, lockout.AdminSignInByEmail, lockout.AdminSignInByIP} {
+		t.Run(policy.Namespace, func(t *testing.T) {
+			assert.Less(t, policy.DelayAfter, policy.LockAfter, "the identity should be delayed before it is locked")
+			assert.LessOrEqual(t, policy.MaxDelay, policy.LockDuration)
+		})
+	}
+}
@@ -67,6 +67,32 @@ func (s *AdminSession) Verify(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// RequireRole will only let the request through when the signed in admin has one of the given roles.
+// It must be chained after Verify.
+func (s *AdminSession) RequireRole(roles ...string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			acc, err := session.GetAccountFromCtx(r.Context())
+			if err != nil {
+				respondUnauthorized(w, err.Error())
+				return
+			}
+
+			for _, role := range roles {
+				if acc.Role == role {
+					next(w, r)
+					return
+				}
+			}
+
+			response.JSON(w, http.StatusForbidden, response.RESTEnvelope{
+				Status:  status.FORBIDDEN,
+				Message: "admin's role is not allowed to access this resource",
+			})
+		}
+	}
+}
+
 func respondUnauthorized(w http.ResponseWriter, message string) {
 	response.JSON(w, http.StatusUnauthorized, response.RESTEnvelope{
 		Status:  status.UNAUTHORIZED,
@@ -112,7 +138,13 @@ func (s *CustomerSession) Verify(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		acc, err := s.sess.Get(ctx, claim.Subject)
+		// every device of a customer has its own session, the jti of the token tells which one.
+		if claim.Id == "" {
+			respondUnauthorized(w, "invalid token")
+			return
+		}
+
+		acc, err := s.sess.Get(ctx, session.Key(claim.Subject, claim.Id))
 		if err != nil {
 			respondUnauthorized(w, err.Error())
 			return
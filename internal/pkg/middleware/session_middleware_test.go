@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/adminapp/admin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+)
+
+func TestAdminSessionRequireRole(t *testing.T) {
+	adminSession := middleware.NewAdminSessionMiddleware(nil, nil)
+	handler := adminSession.RequireRole(admin.RoleSuperAdmin, admin.RoleEventManager)(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		name     string
+		account  *session.Account
+		expected int
+	}{
+		{name: "a listed role is let through", account: &session.Account{ID: 1, Type: "ADMIN", Role: admin.RoleSuperAdmin}, expected: http.StatusOK},
+		{name: "every listed role is let through", account: &session.Account{ID: 1, Type: "ADMIN", Role: admin.RoleEventManager}, expected: http.StatusOK},
+		{name: "a role that is not listed is forbidden", account: &session.Account{ID: 1, Type: "ADMIN", Role: admin.RoleSupport}, expected: http.StatusForbidden},
+		{name: "an admin without a role is forbidden", account: &session.Account{ID: 1, Type: "ADMIN"}, expected: http.StatusForbidden},
+		{name: "a request that is not verified is unauthorized", expected: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.account != nil {
+				r = r.WithContext(context.WithValue(r.Context(), session.AccountContextKey{}, *tc.account))
+			}
+			w := httptest.NewRecorder()
+
+			handler(w, r)
+
+			assert.Equal(t, tc.expected, w.Code)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package orderrule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Customer holds the properties of the customer that the rules are evaluated against.
+type Customer struct {
+	ID            int64
+	Email         string
+	EmailVerified bool
+	MemberTier    string
+}
+
+// Input is the order that is being placed. Now has to be in the timezone of the event, the days and the times of
+// day are evaluated in it.
+type Input struct {
+	Now             time.Time
+	EventID         string
+	ShowID          string
+	TicketStockID   string
+	Quantity        int64
+	Customer        Customer
+	AcquiredTickets int64
+}
+
+// Denial is the structured reason of a rule that does not allow an order to be placed.
+type Denial struct {
+	Rule    string `json:"rule"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// Error implements error.
+func (d *Denial) Error() string {
+	return fmt.Sprintf("%s %s: %s", d.Rule, d.Reason, d.Message)
+}
+
+type Rule interface {
+	Type() string
+	// Evaluate returns nil if the rule allows the order to be placed.
+	Evaluate(in Input) *Denial
+}
+
+// Factory builds a rule from its json config.
+type Factory func(config json.RawMessage) (Rule, error)
+
+type Registry struct {
+	factories map[string]Factory
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+	}
+}
+
+// NewDefaultRegistry returns a registry with every built-in rule type.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(TypeRangeDate, newRangeDate)
+	r.Register(TypeDay, newDay)
+	r.Register(TypeTimeOfDay, newTimeOfDay)
+	r.Register(TypeMaximumTicket, newMaximumTicket)
+	r.Register(TypeVerifiedEmail, newVerifiedEmail)
+	r.Register(TypeEmailDomain, newEmailDomain)
+	r.Register(TypeMemberTier, newMemberTier)
+
+	return r
+}
+
+// Register adds a rule type, a type that is already registered is replaced.
+func (r *Registry) Register(ruleType string, factory Factory) {
+	r.factories[ruleType] = factory
+}
+
+// Types returns the registered rule types in alphabetical order.
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.factories))
+	for t := range r.factories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// Build validates the config of a rule type and builds the rule.
+func (r *Registry) Build(ruleType string, config json.RawMessage) (Rule, error) {
+	factory, ok := r.factories[ruleType]
+	if !ok {
+		return nil, fmt.Errorf("unknown order rule type '%s'", ruleType)
+	}
+
+	rule, err := factory(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config of order rule type '%s': %w", ruleType, err)
+	}
+
+	return rule, nil
+}
+
+// Evaluate evaluates the rules in order and returns the denial of the first rule that does not allow the order.
+func Evaluate(rules []Rule, in Input) *Denial {
+	for _, rule := range rules {
+		if denial := rule.Evaluate(in); denial != nil {
+			return denial
+		}
+	}
+
+	return nil
+}
+
+// WithDefaultMaximumTicket adds a MaximumTicket rule of DefaultMaximumTicket unless the rules already limit the tickets,
+// so an event keeps the limit of one ticket per customer until a MaximumTicket rule is attached to it.
+func WithDefaultMaximumTicket(rules []Rule) []Rule {
+	for _, rule := range rules {
+		if rule.Type() == TypeMaximumTicket {
+			return rules
+		}
+	}
+
+	return append(rules, MaximumTicket{Maximum: DefaultMaximumTicket})
+}
+
+// decodeConfig decodes the config strictly, so a misspelled key is reported instead of being ignored.
+func decodeConfig(config json.RawMessage, v interface{}) error {
+	config = bytes.TrimSpace(config)
+	if len(config) == 0 || bytes.Equal(config, []byte("null")) {
+		config = []byte("{}")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(config))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// ScopeSpecificity ranks a rule for the ticket stock of a show, the higher the rank the more specific the rule is,
+// it is -1 if the rule does not apply.
+func ScopeSpecificity(showID, ticketStockID *string, reqShowID, reqTicketStockID string) int {
+	if ticketStockID != nil {
+		if *ticketStockID != reqTicketStockID {
+			return -1
+		}
+		return 2
+	}
+
+	if showID != nil {
+		if *showID != reqShowID {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// ScopedRangeDate is a range date of an event, it is scoped to a show or to a ticket stock of a show when their ids
+// are set.
+type ScopedRangeDate struct {
+	ShowID        *string
+	TicketStockID *string
+	StartDate     time.Time
+	EndDate       time.Time
+}
+
+// ScopedDay is a day of an event, it is scoped to a show or to a ticket stock of a show when their ids are set.
+type ScopedDay struct {
+	ShowID        *string
+	TicketStockID *string
+	Day           int64
+}
+
+// EffectiveRangeDate returns the most specific range date that applies to the ticket stock of a show.
+func EffectiveRangeDate(rangeDates []ScopedRangeDate, showID, ticketStockID string) (RangeDate, bool) {
+	var (
+		effective RangeDate
+		rank      = -1
+	)
+
+	for _, rd := range rangeDates {
+		if r := ScopeSpecificity(rd.ShowID, rd.TicketStockID, showID, ticketStockID); r > rank {
+			effective, rank = RangeDate{StartDate: rd.StartDate, EndDate: rd.EndDate}, r
+		}
+	}
+
+	return effective, rank >= 0
+}
+
+// EffectiveDay returns the days of the most specific scope that has any day for the ticket stock of a show, it is
+// false if no day applies.
+func EffectiveDay(days []ScopedDay, showID, ticketStockID string) (Day, bool) {
+	var (
+		effective = Day{Days: make([]int64, 0)}
+		rank      = -1
+	)
+
+	for _, d := range days {
+		r := ScopeSpecificity(d.ShowID, d.TicketStockID, showID, ticketStockID)
+		switch {
+		case r > rank:
+			effective, rank = Day{Days: []int64{d.Day}}, r
+		case r == rank && r >= 0:
+			effective.Days = append(effective.Days, d.Day)
+		}
+	}
+
+	return effective, rank >= 0
+}
+
+// EffectiveTimeRules returns the range date and the days that apply to the ticket stock of a show, both the order and
+// the sale window of the event are evaluated against them. It is false if no range date applies, the ticket stock is
+// not on sale then. A ticket stock without any day is on sale on every day of the week.
+func EffectiveTimeRules(rangeDates []ScopedRangeDate, days []ScopedDay, showID, ticketStockID string) ([]Rule, bool) {
+	rangeDate, ok := EffectiveRangeDate(rangeDates, showID, ticketStockID)
+	if !ok {
+		return nil, false
+	}
+
+	rules := []Rule{rangeDate}
+	if day, ok := EffectiveDay(days, showID, ticketStockID); ok {
+		rules = append(rules, day)
+	}
+
+	return rules, true
+}
@@ -0,0 +1,369 @@
+package orderrule_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+)
+
+func build(t *testing.T, ruleType, config string) orderrule.Rule {
+	rule, err := orderrule.NewDefaultRegistry().Build(ruleType, json.RawMessage(config))
+	assert.NoError(t, err)
+
+	return rule
+}
+
+func reason(denial *orderrule.Denial) string {
+	if denial == nil {
+		return ""
+	}
+
+	return denial.Reason
+}
+
+func TestRegistryBuild(t *testing.T) {
+	registry := orderrule.NewDefaultRegistry()
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := registry.Build("ORDER_RULE_UNKNOWN", nil)
+		assert.EqualError(t, err, "unknown order rule type 'ORDER_RULE_UNKNOWN'")
+	})
+
+	t.Run("unknown config key", func(t *testing.T) {
+		_, err := registry.Build(orderrule.TypeMaximumTicket, json.RawMessage(`{"max": 2}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		for ruleType, config := range map[string]string{
+			orderrule.TypeRangeDate:     `{"start_date": "2026-02-01T00:00:00Z", "end_date": "2026-01-01T00:00:00Z"}`,
+			orderrule.TypeDay:           `{"days": [0]}`,
+			orderrule.TypeTimeOfDay:     `{"start_time": "25:00", "end_time": "10:00"}`,
+			orderrule.TypeMaximumTicket: `{"maximum": 0}`,
+			orderrule.TypeEmailDomain:   `{"domains": []}`,
+			orderrule.TypeMemberTier:    `{}`,
+		} {
+			_, err := registry.Build(ruleType, json.RawMessage(config))
+			assert.Error(t, err, ruleType)
+		}
+	})
+
+	t.Run("config without keys", func(t *testing.T) {
+		_, err := registry.Build(orderrule.TypeVerifiedEmail, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRules(t *testing.T) {
+	// 2026-01-05 is a monday.
+	monday := time.Date(2026, 1, 5, 10, 30, 0, 0, time.UTC)
+	sunday := time.Date(2026, 1, 11, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		rule   orderrule.Rule
+		in     orderrule.Input
+		reason string
+	}{
+		{
+			name:   "range date not yet open",
+			rule:   build(t, orderrule.TypeRangeDate, `{"start_date": "2026-01-06T00:00:00Z", "end_date": "2026-01-31T00:00:00Z"}`),
+			in:     orderrule.Input{Now: monday},
+			reason: orderrule.ReasonSalesNotOpen,
+		},
+		{
+			name:   "range date closed",
+			rule:   build(t, orderrule.TypeRangeDate, `{"start_date": "2025-12-01T00:00:00Z", "end_date": "2026-01-01T00:00:00Z"}`),
+			in:     orderrule.Input{Now: monday},
+			reason: orderrule.ReasonSalesClosed,
+		},
+		{
+			name: "day allowed",
+			rule: build(t, orderrule.TypeDay, `{"days": [1, 7]}`),
+			in:   orderrule.Input{Now: sunday},
+		},
+		{
+			name:   "day not allowed",
+			rule:   build(t, orderrule.TypeDay, `{"days": [6, 7]}`),
+			in:     orderrule.Input{Now: monday},
+			reason: orderrule.ReasonDayNotAllowed,
+		},
+		{
+			name: "time of day within window",
+			rule: build(t, orderrule.TypeTimeOfDay, `{"start_time": "10:00", "end_time": "12:00"}`),
+			in:   orderrule.Input{Now: monday},
+		},
+		{
+			name:   "time of day outside window",
+			rule:   build(t, orderrule.TypeTimeOfDay, `{"start_time": "11:00", "end_time": "12:00"}`),
+			in:     orderrule.Input{Now: monday},
+			reason: orderrule.ReasonOutsideSalesHours,
+		},
+		{
+			name: "time of day window spans midnight",
+			rule: build(t, orderrule.TypeTimeOfDay, `{"start_time": "22:00", "end_time": "02:00"}`),
+			in:   orderrule.Input{Now: sunday},
+		},
+		{
+			name:   "maximum ticket reached",
+			rule:   build(t, orderrule.TypeMaximumTicket, `{"maximum": 2}`),
+			in:     orderrule.Input{Now: monday, Quantity: 1, AcquiredTickets: 2},
+			reason: orderrule.ReasonTicketLimitReached,
+		},
+		{
+			name:   "email not verified",
+			rule:   build(t, orderrule.TypeVerifiedEmail, `{}`),
+			in:     orderrule.Input{Now: monday},
+			reason: orderrule.ReasonEmailNotVerified,
+		},
+		{
+			name: "email domain allowed",
+			rule: build(t, orderrule.TypeEmailDomain, `{"domains": ["@Telkomsel.co.id"]}`),
+			in:   orderrule.Input{Now: monday, Customer: orderrule.Customer{Email: "jane@telkomsel.co.id"}},
+		},
+		{
+			name:   "email domain not allowed",
+			rule:   build(t, orderrule.TypeEmailDomain, `{"domains": ["telkomsel.co.id"]}`),
+			in:     orderrule.Input{Now: monday, Customer: orderrule.Customer{Email: "jane@example.com"}},
+			reason: orderrule.ReasonEmailDomainNotAllowed,
+		},
+		{
+			name:   "member tier not allowed",
+			rule:   build(t, orderrule.TypeMemberTier, `{"tiers": ["GOLD"]}`),
+			in:     orderrule.Input{Now: monday, Customer: orderrule.Customer{MemberTier: "REGULAR"}},
+			reason: orderrule.ReasonMemberTierNotAllowed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.reason, reason(tc.rule.Evaluate(tc.in)))
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := []orderrule.Rule{
+		build(t, orderrule.TypeVerifiedEmail, `{}`),
+		build(t, orderrule.TypeMemberTier, `{"tiers": ["GOLD"]}`),
+	}
+
+	t.Run("first denial is returned", func(t *testing.T) {
+		denial := orderrule.Evaluate(rules, orderrule.Input{})
+		assert.Equal(t, &orderrule.Denial{
+			Rule:    orderrule.TypeVerifiedEmail,
+			Reason:  orderrule.ReasonEmailNotVerified,
+			Message: "your email has to be verified to order tickets for this event",
+		}, denial)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		denial := orderrule.Evaluate(rules, orderrule.Input{Customer: orderrule.Customer{EmailVerified: true, MemberTier: "gold"}})
+		assert.Nil(t, denial)
+	})
+}
+
+func TestWithDefaultMaximumTicket(t *testing.T) {
+	t.Run("an event without a maximum ticket rule keeps the limit of one ticket", func(t *testing.T) {
+		rules := orderrule.WithDefaultMaximumTicket([]orderrule.Rule{build(t, orderrule.TypeVerifiedEmail, `{}`)})
+		assert.Len(t, rules, 2)
+
+		in := orderrule.Input{Quantity: 1, Customer: orderrule.Customer{EmailVerified: true}}
+		assert.Nil(t, orderrule.Evaluate(rules, in))
+
+		in.AcquiredTickets = 1
+		assert.Equal(t, orderrule.ReasonTicketLimitReached, reason(orderrule.Evaluate(rules, in)))
+	})
+
+	t.Run("an attached maximum ticket rule replaces the limit of one ticket", func(t *testing.T) {
+		rules := orderrule.WithDefaultMaximumTicket([]orderrule.Rule{build(t, orderrule.TypeMaximumTicket, `{"maximum": 4}`)})
+		assert.Len(t, rules, 1)
+
+		assert.Nil(t, orderrule.Evaluate(rules, orderrule.Input{Quantity: 1, AcquiredTickets: 3}))
+		assert.Equal(t, orderrule.ReasonTicketLimitReached, reason(orderrule.Evaluate(rules, orderrule.Input{Quantity: 1, AcquiredTickets: 4})))
+	})
+}
+
+func TestEffectiveRangeDate(t *testing.T) {
+	show, otherShow, ticketStock := "show-1", "show-2", "stock-1"
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	eventRule := orderrule.ScopedRangeDate{StartDate: start, EndDate: start.AddDate(0, 1, 0)}
+	showRule := orderrule.ScopedRangeDate{ShowID: &show, StartDate: start.AddDate(0, 0, 1), EndDate: start.AddDate(0, 1, 0)}
+	otherShowRule := orderrule.ScopedRangeDate{ShowID: &otherShow, StartDate: start.AddDate(0, 0, 2), EndDate: start.AddDate(0, 1, 0)}
+	ticketStockRule := orderrule.ScopedRangeDate{ShowID: &show, TicketStockID: &ticketStock, StartDate: start.AddDate(0, 0, 3), EndDate: start.AddDate(0, 1, 0)}
+
+	rules := []orderrule.ScopedRangeDate{ticketStockRule, otherShowRule, showRule, eventRule}
+
+	t.Run("ticket stock rule wins", func(t *testing.T) {
+		rule, ok := orderrule.EffectiveRangeDate(rules, show, ticketStock)
+		assert.True(t, ok)
+		assert.Equal(t, ticketStockRule.StartDate, rule.StartDate)
+	})
+
+	t.Run("show rule wins over event rule", func(t *testing.T) {
+		rule, ok := orderrule.EffectiveRangeDate(rules, show, "stock-2")
+		assert.True(t, ok)
+		assert.Equal(t, showRule.StartDate, rule.StartDate)
+	})
+
+	t.Run("event rule applies to other shows", func(t *testing.T) {
+		rule, ok := orderrule.EffectiveRangeDate(rules, "show-3", "stock-3")
+		assert.True(t, ok)
+		assert.Equal(t, eventRule.StartDate, rule.StartDate)
+	})
+
+	t.Run("no rule applies", func(t *testing.T) {
+		_, ok := orderrule.EffectiveRangeDate([]orderrule.ScopedRangeDate{otherShowRule}, show, ticketStock)
+		assert.False(t, ok)
+	})
+}
+
+func TestEffectiveDay(t *testing.T) {
+	show, ticketStock := "show-1", "stock-1"
+
+	days := []orderrule.ScopedDay{
+		{Day: 1},
+		{Day: 2},
+		{ShowID: &show, Day: 6},
+		{ShowID: &show, Day: 7},
+		{ShowID: &show, TicketStockID: &ticketStock, Day: 5},
+	}
+
+	t.Run("ticket stock days win", func(t *testing.T) {
+		day, ok := orderrule.EffectiveDay(days, show, ticketStock)
+		assert.True(t, ok)
+		assert.Equal(t, []int64{5}, day.Days)
+	})
+
+	t.Run("show days win over event days", func(t *testing.T) {
+		day, _ := orderrule.EffectiveDay(days, show, "stock-2")
+		assert.Equal(t, []int64{6, 7}, day.Days)
+	})
+
+	t.Run("event days apply to other shows", func(t *testing.T) {
+		day, _ := orderrule.EffectiveDay(days, "show-2", "stock-2")
+		assert.Equal(t, []int64{1, 2}, day.Days)
+	})
+
+	t.Run("no day applies", func(t *testing.T) {
+		_, ok := orderrule.EffectiveDay(nil, show, ticketStock)
+		assert.False(t, ok)
+	})
+}
+
+func TestEffectiveTimeRules(t *testing.T) {
+	show := "show-1"
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rangeDates := []orderrule.ScopedRangeDate{{ShowID: &show, StartDate: start, EndDate: start.AddDate(0, 1, 0)}}
+
+	t.Run("the range date comes before the days", func(t *testing.T) {
+		rules, ok := orderrule.EffectiveTimeRules(rangeDates, []orderrule.ScopedDay{{Day: 3}}, show, "stock-1")
+		assert.True(t, ok)
+		assert.Equal(t, []orderrule.Rule{
+			orderrule.RangeDate{StartDate: start, EndDate: start.AddDate(0, 1, 0)},
+			orderrule.Day{Days: []int64{3}},
+		}, rules)
+	})
+
+	t.Run("a ticket stock without any day is on sale on every day", func(t *testing.T) {
+		rules, ok := orderrule.EffectiveTimeRules(rangeDates, nil, show, "stock-1")
+		assert.True(t, ok)
+		assert.Len(t, rules, 1)
+	})
+
+	t.Run("a ticket stock without a range date is not on sale", func(t *testing.T) {
+		_, ok := orderrule.EffectiveTimeRules(rangeDates, nil, "show-2", "stock-2")
+		assert.False(t, ok)
+	})
+}
+
+func TestRulesInTimezone(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	monday := build(t, orderrule.TypeDay, `{"days": [1]}`)
+	sunday := build(t, orderrule.TypeDay, `{"days": [7]}`)
+	earlyMorning := build(t, orderrule.TypeTimeOfDay, `{"start_time": "01:00", "end_time": "02:00"}`)
+	morning := build(t, orderrule.TypeTimeOfDay, `{"start_time": "01:00", "end_time": "03:30"}`)
+
+	tests := []struct {
+		name   string
+		rule   orderrule.Rule
+		now    time.Time
+		reason string
+	}{
+		{
+			// sunday 18:00 in UTC is already monday 01:00 in Jakarta.
+			name: "day is the day of the event timezone",
+			rule: monday,
+			now:  time.Date(2026, 1, 4, 18, 0, 0, 0, time.UTC).In(jakarta),
+		},
+		{
+			name:   "day of the server timezone is not used",
+			rule:   monday,
+			now:    time.Date(2026, 1, 4, 18, 0, 0, 0, time.UTC),
+			reason: orderrule.ReasonDayNotAllowed,
+		},
+		{
+			name: "sunday is day 7",
+			rule: sunday,
+			now:  time.Date(2026, 1, 4, 16, 59, 59, 0, time.UTC).In(jakarta),
+		},
+		{
+			// clocks move from 02:00 to 03:00 on 2026-03-08, 06:59 UTC is 01:59 EST.
+			name: "before the start of daylight saving time",
+			rule: morning,
+			now:  time.Date(2026, 3, 8, 6, 59, 0, 0, time.UTC).In(newYork),
+		},
+		{
+			// 07:00 UTC is 03:00 EDT, one minute later than 01:59 EST.
+			name: "after the start of daylight saving time",
+			rule: morning,
+			now:  time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC).In(newYork),
+		},
+		{
+			// 07:30 UTC is 03:30 EDT.
+			name:   "window end after the start of daylight saving time",
+			rule:   morning,
+			now:    time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC).In(newYork),
+			reason: orderrule.ReasonOutsideSalesHours,
+		},
+		{
+			// clocks move from 02:00 back to 01:00 on 2026-11-01, 05:30 UTC is 01:30 EDT.
+			name: "first pass of the repeated hour at the end of daylight saving time",
+			rule: earlyMorning,
+			now:  time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC).In(newYork),
+		},
+		{
+			// 06:30 UTC is 01:30 EST.
+			name: "second pass of the repeated hour at the end of daylight saving time",
+			rule: earlyMorning,
+			now:  time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC).In(newYork),
+		},
+		{
+			// 07:00 UTC is 02:00 EST.
+			name:   "after the repeated hour at the end of daylight saving time",
+			rule:   earlyMorning,
+			now:    time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC).In(newYork),
+			reason: orderrule.ReasonOutsideSalesHours,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.reason, reason(tc.rule.Evaluate(orderrule.Input{Now: tc.now})))
+		})
+	}
+}
+
+func TestISOWeekday(t *testing.T) {
+	// 2026-01-05 is a monday.
+	for k := int64(0); k < 7; k++ {
+		day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(k))
+		assert.Equal(t, k+1, orderrule.ISOWeekday(day), day.Weekday().String())
+	}
+}
@@ -0,0 +1,334 @@
+package orderrule
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TypeRangeDate     = "ORDER_RULE_RANGE_DATE"
+	TypeDay           = "ORDER_RULE_DAY"
+	TypeTimeOfDay     = "ORDER_RULE_TIME_OF_DAY"
+	TypeMaximumTicket = "ORDER_RULE_MAXIMUM_TICKET"
+	TypeVerifiedEmail = "ORDER_RULE_VERIFIED_EMAIL"
+	TypeEmailDomain   = "ORDER_RULE_EMAIL_DOMAIN"
+	TypeMemberTier    = "ORDER_RULE_MEMBER_TIER"
+
+	ReasonSalesNotOpen          = "SALES_NOT_OPEN"
+	ReasonSalesClosed           = "SALES_CLOSED"
+	ReasonDayNotAllowed         = "DAY_NOT_ALLOWED"
+	ReasonOutsideSalesHours     = "OUTSIDE_SALES_HOURS"
+	ReasonTicketLimitReached    = "TICKET_LIMIT_REACHED"
+	ReasonEmailNotVerified      = "EMAIL_NOT_VERIFIED"
+	ReasonEmailDomainNotAllowed = "EMAIL_DOMAIN_NOT_ALLOWED"
+	ReasonMemberTierNotAllowed  = "MEMBER_TIER_NOT_ALLOWED"
+
+	timeOfDayLayout = "15:04"
+
+	// DefaultMaximumTicket is the tickets of an event that a customer can acquire when the event has no MaximumTicket
+	// rule.
+	DefaultMaximumTicket = 1
+)
+
+// ISOWeekday returns the day of the week of t from 1 for monday to 7 for sunday.
+func ISOWeekday(t time.Time) int64 {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+
+	return int64(t.Weekday())
+}
+
+// RangeDate allows orders between the start and the end date.
+type RangeDate struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+func newRangeDate(config json.RawMessage) (Rule, error) {
+	r := RangeDate{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	if r.StartDate.IsZero() || r.EndDate.IsZero() {
+		return nil, fmt.Errorf("'start_date' and 'end_date' are required")
+	}
+
+	if !r.StartDate.Before(r.EndDate) {
+		return nil, fmt.Errorf("'start_date' must be before 'end_date'")
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r RangeDate) Type() string {
+	return TypeRangeDate
+}
+
+// Evaluate implements Rule.
+func (r RangeDate) Evaluate(in Input) *Denial {
+	if in.Now.Before(r.StartDate) {
+		return &Denial{Rule: TypeRangeDate, Reason: ReasonSalesNotOpen, Message: "ticket sales are not yet open"}
+	}
+
+	if in.Now.After(r.EndDate) {
+		return &Denial{Rule: TypeRangeDate, Reason: ReasonSalesClosed, Message: "ticket sales are already closed"}
+	}
+
+	return nil
+}
+
+// Day allows orders on the days of the week, from 1 for monday to 7 for sunday.
+type Day struct {
+	Days []int64 `json:"days"`
+}
+
+func newDay(config json.RawMessage) (Rule, error) {
+	r := Day{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	if len(r.Days) == 0 {
+		return nil, fmt.Errorf("'days' is required")
+	}
+
+	for _, d := range r.Days {
+		if d < 1 || d > 7 {
+			return nil, fmt.Errorf("day '%d' must be between 1 and 7", d)
+		}
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r Day) Type() string {
+	return TypeDay
+}
+
+// Evaluate implements Rule.
+func (r Day) Evaluate(in Input) *Denial {
+	today := ISOWeekday(in.Now)
+	for _, d := range r.Days {
+		if d == today {
+			return nil
+		}
+	}
+
+	return &Denial{Rule: TypeDay, Reason: ReasonDayNotAllowed, Message: "ticket sales are temporary closed for today"}
+}
+
+// TimeOfDay allows orders daily between the start and the end time, a window whose end is before its start
+// spans midnight.
+type TimeOfDay struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	start     int
+	end       int
+}
+
+// minuteOfDay parses a time of day in the format of 15:04.
+func minuteOfDay(value string) (int, error) {
+	t, err := time.Parse(timeOfDayLayout, value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a time of day in the format of %s", value, timeOfDayLayout)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func newTimeOfDay(config json.RawMessage) (Rule, error) {
+	r := TimeOfDay{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if r.start, err = minuteOfDay(r.StartTime); err != nil {
+		return nil, err
+	}
+	if r.end, err = minuteOfDay(r.EndTime); err != nil {
+		return nil, err
+	}
+
+	if r.start == r.end {
+		return nil, fmt.Errorf("'start_time' and 'end_time' must be different")
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r TimeOfDay) Type() string {
+	return TypeTimeOfDay
+}
+
+// Evaluate implements Rule.
+func (r TimeOfDay) Evaluate(in Input) *Denial {
+	now := in.Now.Hour()*60 + in.Now.Minute()
+
+	open := now >= r.start && now < r.end
+	if r.end < r.start {
+		open = now >= r.start || now < r.end
+	}
+
+	if !open {
+		return &Denial{
+			Rule:    TypeTimeOfDay,
+			Reason:  ReasonOutsideSalesHours,
+			Message: fmt.Sprintf("ticket sales are only open from %s to %s", r.StartTime, r.EndTime),
+		}
+	}
+
+	return nil
+}
+
+// MaximumTicket limits the tickets of the event that a customer can acquire.
+type MaximumTicket struct {
+	Maximum int64 `json:"maximum"`
+}
+
+func newMaximumTicket(config json.RawMessage) (Rule, error) {
+	r := MaximumTicket{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	if r.Maximum < 1 {
+		return nil, fmt.Errorf("'maximum' must be at least 1")
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r MaximumTicket) Type() string {
+	return TypeMaximumTicket
+}
+
+// Evaluate implements Rule.
+func (r MaximumTicket) Evaluate(in Input) *Denial {
+	if in.AcquiredTickets+in.Quantity > r.Maximum {
+		return &Denial{
+			Rule:    TypeMaximumTicket,
+			Reason:  ReasonTicketLimitReached,
+			Message: fmt.Sprintf("a customer can only acquire %d tickets for this event", r.Maximum),
+		}
+	}
+
+	return nil
+}
+
+// VerifiedEmail only allows the customers whose email is verified.
+type VerifiedEmail struct{}
+
+func newVerifiedEmail(config json.RawMessage) (Rule, error) {
+	r := VerifiedEmail{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r VerifiedEmail) Type() string {
+	return TypeVerifiedEmail
+}
+
+// Evaluate implements Rule.
+func (r VerifiedEmail) Evaluate(in Input) *Denial {
+	if !in.Customer.EmailVerified {
+		return &Denial{Rule: TypeVerifiedEmail, Reason: ReasonEmailNotVerified, Message: "your email has to be verified to order tickets for this event"}
+	}
+
+	return nil
+}
+
+// EmailDomain only allows the customers whose email belongs to one of the domains.
+type EmailDomain struct {
+	Domains []string `json:"domains"`
+}
+
+func newEmailDomain(config json.RawMessage) (Rule, error) {
+	r := EmailDomain{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	if len(r.Domains) == 0 {
+		return nil, fmt.Errorf("'domains' is required")
+	}
+
+	for k, d := range r.Domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" {
+			return nil, fmt.Errorf("domain must not be empty")
+		}
+		r.Domains[k] = d
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r EmailDomain) Type() string {
+	return TypeEmailDomain
+}
+
+// Evaluate implements Rule.
+func (r EmailDomain) Evaluate(in Input) *Denial {
+	at := strings.LastIndex(in.Customer.Email, "@")
+	domain := strings.ToLower(in.Customer.Email[at+1:])
+
+	for _, d := range r.Domains {
+		if d == domain {
+			return nil
+		}
+	}
+
+	return &Denial{Rule: TypeEmailDomain, Reason: ReasonEmailDomainNotAllowed, Message: "tickets of this event are not available for your email domain"}
+}
+
+// MemberTier only allows the customers of the member tiers.
+type MemberTier struct {
+	Tiers []string `json:"tiers"`
+}
+
+func newMemberTier(config json.RawMessage) (Rule, error) {
+	r := MemberTier{}
+	if err := decodeConfig(config, &r); err != nil {
+		return nil, err
+	}
+
+	if len(r.Tiers) == 0 {
+		return nil, fmt.Errorf("'tiers' is required")
+	}
+
+	return r, nil
+}
+
+// Type implements Rule.
+func (r MemberTier) Type() string {
+	return TypeMemberTier
+}
+
+// Evaluate implements Rule.
+func (r MemberTier) Evaluate(in Input) *Denial {
+	for _, t := range r.Tiers {
+		if strings.EqualFold(t, in.Customer.MemberTier) {
+			return nil
+		}
+	}
+
+	return &Denial{
+		Rule:    TypeMemberTier,
+		Reason:  ReasonMemberTierNotAllowed,
+		Message: fmt.Sprintf("tickets of this event are only available for %s members", strings.Join(r.Tiers, ", ")),
+	}
+}
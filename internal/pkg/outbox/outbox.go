@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+const defaultBatchSize = 100
+
+// Message is a serialized event that is stored within the transaction of the change it describes.
+type Message struct {
+	ID          int64
+	Topic       string
+	Key         string
+	Headers     pubsub.MessageHeaders
+	Message     []byte
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+}
+
+// Outbox publishes events only once the transaction of the change they describe is committed. A message that could
+// not be published right after the commit is published by a later relay, so every message is published at least once.
+// The messages of the same key are published in the order they are added, a message waits for the unpublished messages
+// of its key that precede it, even when they are held by another relay.
+type Outbox interface {
+	// Add serializes the envelope and stores it within the transaction, it returns the id of the message.
+	Add(ctx context.Context, topic string, key string, headers pubsub.MessageHeaders, e pubsub.Envelope, tx *sql.Tx) (int64, error)
+	// Relay publishes the given messages, or a batch of the pending messages when no id is given. A given message that
+	// waits for an earlier message of its key is left for a later relay.
+	Relay(ctx context.Context, IDs ...int64) error
+	// Run relays the pending messages on every interval until the context is done.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type OutboxProperty struct {
+	Logger     *logrus.Logger
+	Publisher  pubsub.Publisher
+	Serializer pubsub.Serializer
+	Repository Repository
+	// Number of messages that are published by a single relay.
+	BatchSize int64
+}
+
+type outbox struct {
+	logger     *logrus.Logger
+	publisher  pubsub.Publisher
+	serializer pubsub.Serializer
+	repository Repository
+	batchSize  int64
+}
+
+func NewOutbox(props OutboxProperty) Outbox {
+	if props.Serializer == nil {
+		props.Serializer = pubsub.JSONSerializer{}
+	}
+
+	if props.BatchSize <= 0 {
+		props.BatchSize = defaultBatchSize
+	}
+
+	return &outbox{
+		logger:     props.Logger,
+		publisher:  props.Publisher,
+		serializer: props.Serializer,
+		repository: props.Repository,
+		batchSize:  props.BatchSize,
+	}
+}
+
+// Add implements Outbox.
+func (o *outbox) Add(ctx context.Context, topic string, key string, headers pubsub.MessageHeaders, e pubsub.Envelope, tx *sql.Tx) (int64, error) {
+	message, serializerHeaders, err := o.serializer.Serialize(e)
+	if err != nil {
+		o.logger.WithContext(ctx).WithError(fmt.Errorf("serialize event '%s' of topic '%s': %w", e.Type, topic, err)).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while serializing event")
+	}
+
+	messageHeaders := pubsub.MessageHeaders{}
+	for k, v := range headers {
+		messageHeaders.Add(k, v)
+	}
+	for k, v := range serializerHeaders {
+		messageHeaders.Add(k, v)
+	}
+
+	return o.repository.Save(ctx, Message{
+		Topic:     topic,
+		Key:       key,
+		Headers:   messageHeaders,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}, tx)
+}
+
+// relay publishes a batch of the messages in the order of their id and returns the number of messages that are
+// published. A message whose key has an earlier unpublished message outside of the batch is left for a later relay,
+// and the relay stops at the first failure, so a message is never published before the messages of its key that
+// precede it.
+func (o *outbox) relay(ctx context.Context, IDs []int64) (int, error) {
+	tx, err := o.repository.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	messages, err := o.repository.FindManyUnpublishedForUpdate(ctx, IDs, o.batchSize, tx)
+	if err != nil {
+		o.repository.Rollback(ctx, tx)
+		return 0, err
+	}
+
+	if len(messages) == 0 {
+		return 0, o.repository.CommitTx(ctx, tx)
+	}
+
+	keys := make([]string, 0, len(messages))
+	lockedIDs := make([]int64, 0, len(messages))
+	for _, m := range messages {
+		keys = append(keys, m.Key)
+		lockedIDs = append(lockedIDs, m.ID)
+	}
+
+	// the first unpublished message of a key that is not in this batch, e.g. one that failed earlier or that is held by
+	// another relay, blocks the messages of the key that come after it.
+	blockers, err := o.repository.FindFirstUnpublishedIDByKeys(ctx, keys, lockedIDs, tx)
+	if err != nil {
+		o.repository.Rollback(ctx, tx)
+		return 0, err
+	}
+
+	published := make([]int64, 0, len(messages))
+	var publishErr error
+	for _, m := range messages {
+		if blocker, ok := blockers[m.Key]; ok && blocker < m.ID {
+			continue
+		}
+
+		if err := o.publisher.Publish(pubsub.WithSyncDelivery(ctx), m.Topic, m.Key, m.Headers, m.Message); err != nil {
+			o.logger.WithContext(ctx).WithError(err).WithField("outbox_id", m.ID).Error()
+			publishErr = errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while publishing outbox message")
+			break
+		}
+		published = append(published, m.ID)
+	}
+
+	if len(published) > 0 {
+		if err := o.repository.MarkPublished(ctx, published, time.Now(), tx); err != nil {
+			o.repository.Rollback(ctx, tx)
+			return 0, err
+		}
+	}
+
+	if err := o.repository.CommitTx(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	return len(published), publishErr
+}
+
+// Relay implements Outbox.
+func (o *outbox) Relay(ctx context.Context, IDs ...int64) error {
+	_, err := o.relay(ctx, IDs)
+
+	return err
+}
+
+// Run implements Outbox.
+func (o *outbox) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				found, err := o.relay(ctx, nil)
+				if err != nil || int64(found) < o.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package outbox_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/outbox"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+)
+
+// repositoryStandIn keeps the messages in memory, the locked messages are held by another relay.
+type repositoryStandIn struct {
+	mu        sync.Mutex
+	messages  []outbox.Message
+	locked    map[int64]bool
+	committed int
+}
+
+func (r *repositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, nil
+}
+
+func (r *repositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	r.committed++
+	return nil
+}
+
+func (r *repositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *repositoryStandIn) Save(ctx context.Context, m outbox.Message, tx *sql.Tx) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m.ID = int64(len(r.messages) + 1)
+	r.messages = append(r.messages, m)
+
+	return m.ID, nil
+}
+
+func (r *repositoryStandIn) FindManyUnpublishedForUpdate(ctx context.Context, IDs []int64, limit int64, tx *sql.Tx) ([]outbox.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[int64]bool, len(IDs))
+	for _, ID := range IDs {
+		wanted[ID] = true
+	}
+
+	messages := []outbox.Message{}
+	for _, m := range r.messages {
+		if m.PublishedAt == nil && !r.locked[m.ID] && (len(IDs) == 0 || wanted[m.ID]) && int64(len(messages)) < limit {
+			messages = append(messages, m)
+		}
+	}
+
+	return messages, nil
+}
+
+func (r *repositoryStandIn) FindFirstUnpublishedIDByKeys(ctx context.Context, keys []string, excludedIDs []int64, tx *sql.Tx) (map[string]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+	excluded := make(map[int64]bool, len(excludedIDs))
+	for _, ID := range excludedIDs {
+		excluded[ID] = true
+	}
+
+	first := make(map[string]int64)
+	for _, m := range r.messages {
+		if _, ok := first[m.Key]; !ok && m.PublishedAt == nil && wanted[m.Key] && !excluded[m.ID] {
+			first[m.Key] = m.ID
+		}
+	}
+
+	return first, nil
+}
+
+func (r *repositoryStandIn) MarkPublished(ctx context.Context, IDs []int64, publishedAt time.Time, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ID := range IDs {
+		r.messages[ID-1].PublishedAt = &publishedAt
+	}
+
+	return nil
+}
+
+func (r *repositoryStandIn) pending() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	IDs := []int64{}
+	for _, m := range r.messages {
+		if m.PublishedAt == nil {
+			IDs = append(IDs, m.ID)
+		}
+	}
+
+	return IDs
+}
+
+// failingPublisher fails every publish of the given key.
+type failingPublisher struct {
+	pubsub.Publisher
+	key string
+}
+
+func (p failingPublisher) Publish(ctx context.Context, topic string, key string, headers pubsub.MessageHeaders, message []byte) error {
+	if key == p.key {
+		return errors.New("broker is down")
+	}
+
+	return p.Publisher.Publish(ctx, topic, key, headers, message)
+}
+
+func newOutbox(publisher pubsub.Publisher, repository outbox.Repository, batchSize int64) outbox.Outbox {
+	return outbox.NewOutbox(outbox.OutboxProperty{
+		Logger:     standin.Logger(),
+		Publisher:  publisher,
+		Serializer: pubsub.JSONSerializer{},
+		Repository: repository,
+		BatchSize:  batchSize,
+	})
+}
+
+func add(t *testing.T, o outbox.Outbox, key string) int64 {
+	t.Helper()
+
+	e := pubsub.NewEnvelope("tm-order", pubsub.Schema{Type: "order-paid", Name: "order-paid", Version: "v1"}, key, map[string]string{"id": key})
+	ID, err := o.Add(context.Background(), "order-paid", key, pubsub.MessageHeaders{"origin": "tm-order"}, e, nil)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return ID
+}
+
+func TestOutbox(t *testing.T) {
+	t.Run("a message is only published by the relay", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		repository := &repositoryStandIn{}
+		o := newOutbox(broker.Publisher(), repository, 0)
+
+		ID := add(t, o, "1")
+		assert.Empty(t, broker.Messages("order-paid"))
+
+		stored := repository.messages[0]
+		assert.Equal(t, "tm-order", stored.Headers["origin"])
+		assert.Equal(t, pubsub.ContentTypeCloudEventsJSON, stored.Headers[pubsub.HeaderContentType])
+
+		assert.NoError(t, o.Relay(context.Background(), ID))
+		assert.Empty(t, repository.pending())
+
+		messages := broker.Messages("order-paid")
+		if assert.Len(t, messages, 1) {
+			assert.Equal(t, "1", string(messages[0].Key))
+			assert.Equal(t, stored.Message, messages[0].Value)
+		}
+
+		assert.NoError(t, o.Relay(context.Background(), ID))
+		assert.Len(t, broker.Messages("order-paid"), 1, "a published message is never published again")
+	})
+
+	t.Run("relay only the given messages", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		repository := &repositoryStandIn{}
+		o := newOutbox(broker.Publisher(), repository, 0)
+
+		add(t, o, "1")
+		ID := add(t, o, "2")
+
+		assert.NoError(t, o.Relay(context.Background(), ID))
+		assert.Equal(t, []int64{1}, repository.pending())
+	})
+
+	t.Run("stop at the first failure and keep the rest pending", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		repository := &repositoryStandIn{}
+		o := newOutbox(failingPublisher{Publisher: broker.Publisher(), key: "2"}, repository, 0)
+
+		add(t, o, "1")
+		add(t, o, "2")
+		add(t, o, "3")
+
+		assert.Error(t, o.Relay(context.Background()))
+		assert.Equal(t, []int64{2, 3}, repository.pending())
+		assert.Len(t, broker.Messages("order-paid"), 1)
+		assert.Equal(t, 1, repository.committed, "the published messages are committed despite the failure")
+	})
+
+	t.Run("a message waits for the earlier unpublished message of its key", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		repository := &repositoryStandIn{}
+		o := newOutbox(failingPublisher{Publisher: broker.Publisher(), key: "1"}, repository, 0)
+
+		first := add(t, o, "1")
+		assert.Error(t, o.Relay(context.Background(), first))
+
+		o = newOutbox(broker.Publisher(), repository, 0)
+		second := add(t, o, "1")
+		assert.NoError(t, o.Relay(context.Background(), second))
+		assert.Equal(t, []int64{first, second}, repository.pending())
+		assert.Empty(t, broker.Messages("order-paid"))
+
+		assert.NoError(t, o.Relay(context.Background()))
+		assert.Empty(t, repository.pending())
+
+		values := []string{}
+		for _, m := range broker.Messages("order-paid") {
+			values = append(values, string(m.Value))
+		}
+		if assert.Len(t, values, 2) {
+			assert.Equal(t, string(repository.messages[0].Message), values[0])
+			assert.Equal(t, string(repository.messages[1].Message), values[1])
+		}
+	})
+
+	t.Run("a key whose earlier message is held by another relay is skipped", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		repository := &repositoryStandIn{locked: map[int64]bool{1: true}}
+		o := newOutbox(broker.Publisher(), repository, 0)
+
+		add(t, o, "1")
+		add(t, o, "1")
+		add(t, o, "2")
+
+		assert.NoError(t, o.Relay(context.Background()))
+		assert.Equal(t, []int64{1, 2}, repository.pending())
+
+		messages := broker.Messages("order-paid")
+		if assert.Len(t, messages, 1) {
+			assert.Equal(t, "2", string(messages[0].Key))
+		}
+	})
+
+	t.Run("run relays every pending batch", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		repository := &repositoryStandIn{}
+		o := newOutbox(broker.Publisher(), repository, 2)
+
+		for _, key := range []string{"1", "2", "3", "4", "5"} {
+			add(t, o, key)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			o.Run(ctx, 10*time.Millisecond)
+			close(done)
+		}()
+
+		assert.Eventually(t, func() bool { return len(repository.pending()) == 0 }, time.Second, 10*time.Millisecond)
+		cancel()
+		<-done
+
+		keys := []string{}
+		for _, m := range broker.Messages("order-paid") {
+			keys = append(keys, string(m.Key))
+		}
+		assert.Equal(t, []string{"1", "2", "3", "4", "5"}, keys)
+	})
+}
@@ -0,0 +1,264 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type Repository interface {
+	BeginTx(ctx context.Context) (*sql.Tx, error)
+	CommitTx(ctx context.Context, tx *sql.Tx) error
+	Rollback(ctx context.Context, tx *sql.Tx) error
+
+	Save(ctx context.Context, m Message, tx *sql.Tx) (int64, error)
+	// FindManyUnpublishedForUpdate locks the unpublished messages in the order of their id, the messages that are
+	// locked by another relay are skipped. Empty IDs finds any unpublished message.
+	FindManyUnpublishedForUpdate(ctx context.Context, IDs []int64, limit int64, tx *sql.Tx) ([]Message, error)
+	// FindFirstUnpublishedIDByKeys returns the id of the first unpublished message of each key, leaving the excluded IDs
+	// out. The messages that are locked by another relay are included, a key without unpublished message is absent.
+	FindFirstUnpublishedIDByKeys(ctx context.Context, keys []string, excludedIDs []int64, tx *sql.Tx) (map[string]int64, error)
+	MarkPublished(ctx context.Context, IDs []int64, publishedAt time.Time, tx *sql.Tx) error
+}
+
+type sqlCommand interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type repository struct {
+	logger *logrus.Logger
+	db     *sql.DB
+}
+
+func NewRepository(logger *logrus.Logger, db *sql.DB) Repository {
+	return &repository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// BeginTx implements Repository.
+func (r *repository) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to begin transaction")
+	}
+
+	return tx, nil
+}
+
+// CommitTx implements Repository.
+func (r *repository) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Commit(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to commit transaction")
+	}
+
+	return nil
+}
+
+// Rollback implements Repository.
+func (r *repository) Rollback(ctx context.Context, tx *sql.Tx) error {
+	if err := tx.Rollback(); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred trying to rollback transaction")
+	}
+
+	return nil
+}
+
+// Save implements Repository.
+func (r *repository) Save(ctx context.Context, m Message, tx *sql.Tx) (int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		INSERT INTO outbox
+		(
+			topic, message_key, headers, message, created_at
+		)
+		VALUES
+		(
+			$1, $2, $3, $4, $5
+		)
+		RETURNING id
+	`
+
+	headers, err := json.Marshal(m.Headers)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving outbox message's properties")
+	}
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving outbox message's properties")
+	}
+	defer stmt.Close()
+
+	var ID int64
+	if err := stmt.QueryRowContext(ctx, m.Topic, m.Key, headers, m.Message, m.CreatedAt).Scan(&ID); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return 0, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while saving outbox message's properties")
+	}
+
+	return ID, nil
+}
+
+// FindManyUnpublishedForUpdate implements Repository.
+func (r *repository) FindManyUnpublishedForUpdate(ctx context.Context, IDs []int64, limit int64, tx *sql.Tx) ([]Message, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			id, topic, message_key, headers, message, created_at
+		FROM outbox
+		WHERE
+			published_at IS NULL
+			AND (CARDINALITY($1::BIGINT[]) = 0 OR id = ANY($1))
+		ORDER BY id ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+	}
+	defer stmt.Close()
+
+	if IDs == nil {
+		IDs = []int64{}
+	}
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(IDs), limit)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+	}
+	defer rows.Close()
+
+	var data = make([]Message, 0)
+	for rows.Next() {
+		var m Message
+		var headers []byte
+
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &headers, &m.Message, &m.CreatedAt); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+		}
+
+		if err := json.Unmarshal(headers, &m.Headers); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+		}
+
+		data = append(data, m)
+	}
+
+	return data, nil
+}
+
+// FindFirstUnpublishedIDByKeys implements Repository.
+func (r *repository) FindFirstUnpublishedIDByKeys(ctx context.Context, keys []string, excludedIDs []int64, tx *sql.Tx) (map[string]int64, error) {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		SELECT
+			message_key, MIN(id)
+		FROM outbox
+		WHERE
+			published_at IS NULL
+			AND message_key = ANY($1)
+			AND NOT (id = ANY($2))
+		GROUP BY message_key
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+	}
+	defer stmt.Close()
+
+	if excludedIDs == nil {
+		excludedIDs = []int64{}
+	}
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(keys), pq.Array(excludedIDs))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+	}
+	defer rows.Close()
+
+	var data = make(map[string]int64)
+	for rows.Next() {
+		var key string
+		var ID int64
+
+		if err := rows.Scan(&key, &ID); err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while getting bunch of outbox message's properties")
+		}
+
+		data[key] = ID
+	}
+
+	return data, nil
+}
+
+// MarkPublished implements Repository.
+func (r *repository) MarkPublished(ctx context.Context, IDs []int64, publishedAt time.Time, tx *sql.Tx) error {
+	var cmd sqlCommand = r.db
+
+	if tx != nil {
+		cmd = tx
+	}
+
+	query := `
+		UPDATE outbox
+		SET
+			published_at = $2
+		WHERE
+			id = ANY($1)
+	`
+
+	stmt, err := cmd.PrepareContext(ctx, query)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating outbox message's properties")
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, pq.Array(IDs), publishedAt); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "an error occurred while updating outbox message's properties")
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Package password hashes the passwords of the customers and of the admins. A hash is stored in the PHC string format,
+// e.g. $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>, so the algorithm and its parameters travel with the hash and can be
+// raised later without breaking the existing accounts. The hashes that were made before, a bare base64 PBKDF2-SHA512
+// with a separate salt, are still verified and are reported as legacy so they can be upgraded on the next sign in.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// Argon2id is the name of the current algorithm in the hash.
+	Argon2id = "argon2id"
+	// Prefix starts every hash that is not legacy.
+	Prefix = "$"
+)
+
+// Params of argon2id.
+type Params struct {
+	// Memory in KiB.
+	Memory     uint32
+	Iterations uint32
+	Threads    uint8
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// DefaultParams follows the recommendation of OWASP for argon2id.
+var DefaultParams = Params{
+	Memory:     64 * 1024,
+	Iterations: 3,
+	Threads:    2,
+	SaltLength: 16,
+	KeyLength:  32,
+}
+
+// Hash returns the hash of the plain password peppered with the secret using DefaultParams.
+func Hash(secret, plain string) (string, error) {
+	return HashWithParams(secret, plain, DefaultParams)
+}
+
+// HashWithParams returns the hash of the plain password peppered with the secret using the given params.
+func HashWithParams(secret, plain string, p Params) (string, error) {
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(secret+plain), salt, p.Iterations, p.Memory, p.Threads, p.KeyLength)
+
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2id, argon2.Version, p.Memory, p.Iterations, p.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify tells whether the plain password matches the hash. The legacy salt is only used by a legacy hash. Rehash is
+// true when the password matches but the hash is legacy or made with other params than DefaultParams, the caller should
+// then store a new Hash of the password.
+func Verify(secret, plain, hash, legacySalt string) (match, rehash bool) {
+	if IsLegacy(hash) {
+		legacyHash := util.GenerateSecret(secret+plain, legacySalt, 256)
+		match = subtle.ConstantTimeCompare([]byte(legacyHash), []byte(hash)) == 1
+
+		return match, match
+	}
+
+	p, salt, key, err := decode(hash)
+	if err != nil {
+		return false, false
+	}
+
+	otherKey := argon2.IDKey([]byte(secret+plain), salt, p.Iterations, p.Memory, p.Threads, uint32(len(key)))
+	match = subtle.ConstantTimeCompare(key, otherKey) == 1
+
+	return match, match && p != DefaultParams
+}
+
+// IsLegacy tells whether the hash is made by the PBKDF2-SHA512 scheme that predates this package.
+func IsLegacy(hash string) bool {
+	return !strings.HasPrefix(hash, Prefix)
+}
+
+func decode(hash string) (Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != Argon2id {
+		return Params{}, nil, nil, fmt.Errorf("password: unsupported hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("password: unsupported argon2 version")
+	}
+
+	p := Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Threads); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("password: invalid params: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("password: invalid salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("password: invalid hash: %w", err)
+	}
+
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
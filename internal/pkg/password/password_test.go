@@ -0,0 +1,77 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+)
+
+func TestHashAndVerify(t *testing.T) {
+	hash, err := password.Hash("secret", "rahasia")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$"))
+	assert.False(t, password.IsLegacy(hash))
+
+	otherHash, _ := password.Hash("secret", "rahasia")
+	assert.NotEqual(t, hash, otherHash, "every hash has its own salt")
+
+	testCases := []struct {
+		name   string
+		secret string
+		plain  string
+		match  bool
+	}{
+		{name: "the right password", secret: "secret", plain: "rahasia", match: true},
+		{name: "a wrong password", secret: "secret", plain: "salah", match: false},
+		{name: "a wrong secret", secret: "other", plain: "rahasia", match: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, rehash := password.Verify(tc.secret, tc.plain, hash, "")
+			assert.Equal(t, tc.match, match)
+			assert.False(t, rehash)
+		})
+	}
+}
+
+func TestVerifyLegacy(t *testing.T) {
+	salt := util.GenerateRandomHEX(32)
+	legacyHash := util.GenerateSecret("secret"+"rahasia", salt, 256)
+	assert.True(t, password.IsLegacy(legacyHash))
+
+	match, rehash := password.Verify("secret", "rahasia", legacyHash, salt)
+	assert.True(t, match)
+	assert.True(t, rehash, "a legacy hash is upgraded")
+
+	match, rehash = password.Verify("secret", "salah", legacyHash, salt)
+	assert.False(t, match)
+	assert.False(t, rehash)
+}
+
+func TestVerifyOutdatedParams(t *testing.T) {
+	params := password.DefaultParams
+	params.Iterations = 1
+	hash, err := password.HashWithParams("secret", "rahasia", params)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	match, rehash := password.Verify("secret", "rahasia", hash, "")
+	assert.True(t, match)
+	assert.True(t, rehash, "a hash with outdated params is upgraded")
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	for _, hash := range []string{"$argon2id$", "$bcrypt$v=19$m=1,t=1,p=1$c2FsdA$aGFzaA", "$argon2id$v=19$m=1,t=1,p=1$!$aGFzaA"} {
+		match, rehash := password.Verify("secret", "rahasia", hash, "")
+		assert.False(t, match, hash)
+		assert.False(t, rehash, hash)
+	}
+}
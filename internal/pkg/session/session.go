@@ -14,7 +14,8 @@ import (
 )
 
 var (
-	sessionKeyPrefix string = "session:user:%s"
+	sessionKeyPrefix       string = "session:user:%s"
+	sessionDeviceKeyPrefix string = "session:user:%s:devices"
 )
 
 type AccountContextKey struct{}
@@ -24,12 +25,30 @@ type Account struct {
 	Name  string
 	Email string
 	Type  string
+	Role  string
+	// SessionID identifies the session of a device, it is the jti of the tokens of the session.
+	SessionID string
+	IPAddress string
+	UserAgent string
+	CreatedAt time.Time
 }
 
 type Session interface {
 	Set(ctx context.Context, key string, acc Account, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
 	Get(ctx context.Context, key string) (Account, error)
+
+	// SetDevice sets the session of a device of the owner under Key(owner, acc.SessionID).
+	SetDevice(ctx context.Context, owner string, acc Account, ttl time.Duration) error
+	// ListDevices returns the sessions of every device of the owner.
+	ListDevices(ctx context.Context, owner string) ([]Account, error)
+	// DeleteDevices deletes the sessions of every device of the owner.
+	DeleteDevices(ctx context.Context, owner string) error
+}
+
+// Key returns the key of the session of a device of the owner, e.g. Key("customer:1", jti).
+func Key(owner, sessionID string) string {
+	return fmt.Sprintf("%s:%s", owner, sessionID)
 }
 
 type redisSessionStore struct {
@@ -80,6 +99,82 @@ func (s *redisSessionStore) Set(ctx context.Context, key string, acc Account, tt
 	return nil
 }
 
+// SetDevice implements Session.
+func (s *redisSessionStore) SetDevice(ctx context.Context, owner string, acc Account, ttl time.Duration) error {
+	if err := s.Set(ctx, Key(owner, acc.SessionID), acc, ttl); err != nil {
+		return err
+	}
+
+	deviceKey := fmt.Sprintf(sessionDeviceKeyPrefix, owner)
+	if err := s.r.SAdd(ctx, deviceKey, acc.SessionID).Err(); err != nil {
+		s.l.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	// the sessions of an owner share the same ttl, so the index lives as long as the latest session.
+	if err := s.r.Expire(ctx, deviceKey, ttl).Err(); err != nil {
+		s.l.WithContext(ctx).WithError(err).Error()
+	}
+
+	return nil
+}
+
+// ListDevices implements Session.
+func (s *redisSessionStore) ListDevices(ctx context.Context, owner string) ([]Account, error) {
+	deviceKey := fmt.Sprintf(sessionDeviceKeyPrefix, owner)
+	sessionIDs, err := s.r.SMembers(ctx, deviceKey).Result()
+	if err != nil {
+		s.l.WithContext(ctx).WithError(err).Error()
+		return nil, errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	accs := make([]Account, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		acc, err := s.Get(ctx, Key(owner, sessionID))
+		if err != nil {
+			if !errors.MatchStatus(err, status.NOT_FOUND) {
+				return nil, err
+			}
+
+			// the session has expired or has been deleted on its own.
+			if err := s.r.SRem(ctx, deviceKey, sessionID).Err(); err != nil {
+				s.l.WithContext(ctx).WithError(err).Error()
+			}
+			continue
+		}
+		accs = append(accs, acc)
+	}
+
+	return accs, nil
+}
+
+// DeleteDevices implements Session.
+func (s *redisSessionStore) DeleteDevices(ctx context.Context, owner string) error {
+	deviceKey := fmt.Sprintf(sessionDeviceKeyPrefix, owner)
+	sessionIDs, err := s.r.SMembers(ctx, deviceKey).Result()
+	if err != nil {
+		s.l.WithContext(ctx).WithError(err).Error()
+		return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+	}
+
+	// the keys are deleted one by one since they are spread over the slots of a redis cluster, the devices go last so
+	// a retry still finds the sessions that were left.
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, fmt.Sprintf(sessionKeyPrefix, Key(owner, sessionID)))
+	}
+	keys = append(keys, deviceKey)
+
+	for _, key := range keys {
+		if err := s.r.Del(ctx, key).Err(); err != nil {
+			s.l.WithContext(ctx).WithError(err).Error()
+			return errors.New(http.StatusInternalServerError, status.INTERNAL_SERVER_ERROR, "")
+		}
+	}
+
+	return nil
+}
+
 func NewRedisSessionStore(l *logrus.Logger, r redis.UniversalClient) Session {
 	return &redisSessionStore{
 		l: l,
@@ -0,0 +1,28 @@
+package standin
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/jwt"
+)
+
+// JSONWebToken returns a json web token that signs and parses with a new RSA key pair.
+func JSONWebToken() *jwt.JSONWebToken {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER})
+
+	return jwt.NewJSONWebToken(privateKey, publicKey)
+}
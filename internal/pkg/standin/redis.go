@@ -0,0 +1,237 @@
+package standin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var errCrossSlot = fmt.Errorf("CROSSSLOT Keys in request don't hash to the same slot")
+
+// Redis keeps the values of the cache and of the session store in memory, only the commands that are used by the apps
+// are implemented. A key expires once its time has passed on the clock of the stand-in, which Advance moves forward.
+// Like a redis cluster, it rejects a command on keys that may be in different slots.
+type Redis struct {
+	redis.UniversalClient
+	mu        sync.Mutex
+	values    map[string][]byte
+	sets      map[string]map[string]struct{}
+	expiresAt map[string]time.Time
+	elapsed   time.Duration
+}
+
+func NewRedis() *Redis {
+	return &Redis{
+		values:    make(map[string][]byte),
+		sets:      make(map[string]map[string]struct{}),
+		expiresAt: make(map[string]time.Time),
+	}
+}
+
+// Advance moves the clock of the stand-in forward, the keys whose time passes expire.
+func (r *Redis) Advance(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.elapsed += d
+}
+
+// expire deletes the key when its time has passed, it is called with the lock held.
+func (r *Redis) expire(key string) {
+	expiresAt, ok := r.expiresAt[key]
+	if !ok || time.Now().Add(r.elapsed).Before(expiresAt) {
+		return
+	}
+
+	delete(r.values, key)
+	delete(r.sets, key)
+	delete(r.expiresAt, key)
+}
+
+// setExpiration sets the time of the key, it is called with the lock held.
+func (r *Redis) setExpiration(key string, expiration time.Duration) {
+	if expiration <= 0 {
+		delete(r.expiresAt, key)
+		return
+	}
+
+	r.expiresAt[key] = time.Now().Add(r.elapsed + expiration)
+}
+
+func (r *Redis) Get(ctx context.Context, key string) *redis.StringCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(key)
+
+	value, ok := r.values[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+
+	return redis.NewStringResult(string(value), nil)
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch v := value.(type) {
+	case []byte:
+		r.values[key] = v
+	case string:
+		r.values[key] = []byte(v)
+	default:
+		r.values[key] = []byte(fmt.Sprint(v))
+	}
+	r.setExpiration(key, expiration)
+
+	return redis.NewStatusResult("OK", nil)
+}
+
+func (r *Redis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	if r.Exists(ctx, key).Val() > 0 {
+		return redis.NewBoolResult(false, nil)
+	}
+	r.Set(ctx, key, value, expiration)
+
+	return redis.NewBoolResult(true, nil)
+}
+
+func (r *Redis) SAdd(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(key)
+
+	set, ok := r.sets[key]
+	if !ok {
+		set = make(map[string]struct{})
+		r.sets[key] = set
+	}
+
+	var added int64
+	for _, member := range members {
+		m := fmt.Sprint(member)
+		if _, ok := set[m]; !ok {
+			set[m] = struct{}{}
+			added++
+		}
+	}
+
+	return redis.NewIntResult(added, nil)
+}
+
+func (r *Redis) SMembers(ctx context.Context, key string) *redis.StringSliceCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(key)
+
+	members := []string{}
+	for member := range r.sets[key] {
+		members = append(members, member)
+	}
+
+	return redis.NewStringSliceResult(members, nil)
+}
+
+func (r *Redis) SRem(ctx context.Context, key string, members ...interface{}) *redis.IntCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(key)
+
+	var removed int64
+	for _, member := range members {
+		m := fmt.Sprint(member)
+		if _, ok := r.sets[key][m]; ok {
+			delete(r.sets[key], m)
+			removed++
+		}
+	}
+
+	return redis.NewIntResult(removed, nil)
+}
+
+func (r *Redis) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
+	if len(keys) > 1 {
+		return redis.NewIntResult(0, errCrossSlot)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, key := range keys {
+		r.expire(key)
+		if _, ok := r.values[key]; ok {
+			count++
+		}
+	}
+
+	return redis.NewIntResult(count, nil)
+}
+
+func (r *Redis) GetDel(ctx context.Context, key string) *redis.StringCmd {
+	cmd := r.Get(ctx, key)
+	r.Del(ctx, key)
+
+	return cmd
+}
+
+func (r *Redis) Incr(ctx context.Context, key string) *redis.IntCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(key)
+
+	value, _ := strconv.ParseInt(string(r.values[key]), 10, 64)
+	value++
+	r.values[key] = []byte(strconv.FormatInt(value, 10))
+
+	return redis.NewIntResult(value, nil)
+}
+
+func (r *Redis) Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.expire(key)
+	_, ok := r.values[key]
+	_, isSet := r.sets[key]
+	if ok || isSet {
+		r.setExpiration(key, expiration)
+	}
+
+	return redis.NewBoolResult(ok || isSet, nil)
+}
+
+func (r *Redis) Del(ctx context.Context, keys ...string) *redis.IntCmd {
+	if len(keys) > 1 {
+		return redis.NewIntResult(0, errCrossSlot)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for _, key := range keys {
+		r.expire(key)
+		delete(r.expiresAt, key)
+		if _, ok := r.values[key]; ok {
+			delete(r.values, key)
+			deleted++
+		}
+		if _, ok := r.sets[key]; ok {
+			delete(r.sets, key)
+			deleted++
+		}
+	}
+
+	return redis.NewIntResult(deleted, nil)
+}
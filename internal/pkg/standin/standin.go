@@ -0,0 +1,27 @@
+// Package standin holds what the tests of the modules share. A stand-in takes the place of a dependency that a test can
+// not reach, e.g. the session of a signed in admin.
+package standin
+
+import (
+	"context"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+)
+
+// AdminID is the id of the admin that AdminContext signs in.
+const AdminID int64 = 7
+
+// AdminContext returns a context that is signed in as an admin, as the session middleware of the admin's app leaves it.
+func AdminContext() context.Context {
+	return context.WithValue(context.Background(), session.AccountContextKey{}, session.Account{ID: AdminID, Type: "ADMIN"})
+}
+
+// Logger returns a logger that discards every entry.
+func Logger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return logger
+}
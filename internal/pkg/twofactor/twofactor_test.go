@@ -0,0 +1,114 @@
+package twofactor_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/twofactor"
+)
+
+// rfcSecret is the sha1 key of the test vectors of RFC 6238.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range testCases {
+		code, err := twofactor.Code(rfcSecret, time.Unix(tc.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.code, code, "unix %d", tc.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, _ := twofactor.Code(rfcSecret, now)
+
+	testCases := []struct {
+		name  string
+		at    time.Time
+		code  string
+		valid bool
+	}{
+		{name: "the current period", at: now, code: code, valid: true},
+		{name: "the previous period", at: now.Add(twofactor.Period), code: code, valid: true},
+		{name: "the next period", at: now.Add(-twofactor.Period), code: code, valid: true},
+		{name: "an expired code", at: now.Add(2 * twofactor.Period), code: code, valid: false},
+		{name: "a wrong code", at: now, code: "000000", valid: false},
+		{name: "a short code", at: now, code: "0059", valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			step, valid := twofactor.Validate(rfcSecret, tc.code, tc.at)
+			assert.Equal(t, tc.valid, valid)
+			if valid {
+				assert.Equal(t, now.Unix()/30, step)
+			}
+		})
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	secret, err := twofactor.GenerateSecret()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	uri, err := url.Parse(twofactor.ProvisioningURI("ticket-master", "budi@example.com", secret))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/ticket-master:budi@example.com", uri.Path)
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+	assert.Equal(t, "ticket-master", uri.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := twofactor.GenerateRecoveryCodes()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Len(t, codes, twofactor.RecoveryCodeCount)
+
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		assert.Regexp(t, `^[0-9a-f]{5}-[0-9a-f]{5}$`, c)
+		hashes[i] = twofactor.HashRecoveryCode("secret", c)
+	}
+
+	assert.Equal(t, 3, twofactor.MatchRecoveryCode("secret", hashes, codes[3]))
+	assert.Equal(t, 3, twofactor.MatchRecoveryCode("secret", hashes, " "+codes[3]+" "), "the code is trimmed")
+	assert.Equal(t, -1, twofactor.MatchRecoveryCode("other", hashes, codes[3]))
+	assert.Equal(t, -1, twofactor.MatchRecoveryCode("secret", hashes, "00000-00000"))
+}
+
+func TestSealAndOpen(t *testing.T) {
+	sealed, err := twofactor.Seal("secret", rfcSecret)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NotContains(t, sealed, rfcSecret)
+
+	opened, err := twofactor.Open("secret", sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, rfcSecret, opened)
+
+	_, err = twofactor.Open("other", sealed)
+	assert.Error(t, err)
+}
@@ -6,6 +6,9 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"net"
+	"net/http"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/pbkdf2"
@@ -13,7 +16,7 @@ import (
 
 // GenerateRandomHEX returns random hex number in string format by the given size (in bytes).
 func GenerateRandomHEX(size int) string {
-	b := make([]byte, 16)
+	b := make([]byte, size)
 	if _, err := rand.Read(b); err != nil {
 		panic(err)
 	}
@@ -22,6 +25,8 @@ func GenerateRandomHEX(size int) string {
 }
 
 // GenerateSecret returns hashed string from combination of plain and salt. It presented in base64 format. The used algorithm is sha512.
+//
+// Deprecated: it is only kept to verify the legacy password hashes, use package password to hash a password.
 func GenerateSecret(plain, salt string, len int) string {
 	b := pbkdf2.Key([]byte(plain), []byte(salt), 128, len, sha512.New)
 
@@ -34,3 +39,30 @@ func GenerateTimestampWithPrefix(prefix string) string {
 
 	return fmt.Sprintf("%s%d", prefix, micro)
 }
+
+// ClientIP returns the ip address of the client of the request. Each of the trusted proxies in front of the service
+// appends the address that it received the request from to X-Forwarded-For, so the client is the entry that the
+// outermost trusted proxy added, trustedHops entries from the right. The entries on the left of it are set by the
+// client and can not be trusted. Without trusted proxies, or when the header is shorter than expected, it is the
+// address of the connection.
+func ClientIP(r *http.Request, trustedHops int) string {
+	if trustedHops > 0 {
+		entries := make([]string, 0)
+		for _, forwardedFor := range r.Header.Values("X-Forwarded-For") {
+			entries = append(entries, strings.Split(forwardedFor, ",")...)
+		}
+
+		if len(entries) >= trustedHops {
+			if ip := net.ParseIP(strings.TrimSpace(entries[len(entries)-trustedHops])); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,39 @@
+package util_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+)
+
+func TestClientIP(t *testing.T) {
+	testCases := []struct {
+		name         string
+		forwardedFor []string
+		trustedHops  int
+		expected     string
+	}{
+		{name: "the address of the connection without trusted proxies", forwardedFor: []string{"203.0.113.7"}, trustedHops: 0, expected: "192.0.2.1"},
+		{name: "the entry that the trusted proxy added", forwardedFor: []string{"203.0.113.7"}, trustedHops: 1, expected: "203.0.113.7"},
+		{name: "an entry that the client spoofed is ignored", forwardedFor: []string{"10.0.0.1, 203.0.113.7"}, trustedHops: 1, expected: "203.0.113.7"},
+		{name: "the entry of the outermost of two trusted proxies", forwardedFor: []string{"10.0.0.1, 203.0.113.7, 198.51.100.2"}, trustedHops: 2, expected: "203.0.113.7"},
+		{name: "the entries of every header are counted", forwardedFor: []string{"10.0.0.1", "203.0.113.7"}, trustedHops: 1, expected: "203.0.113.7"},
+		{name: "the address of the connection when the header is shorter than the trusted proxies", forwardedFor: []string{"203.0.113.7"}, trustedHops: 2, expected: "192.0.2.1"},
+		{name: "the address of the connection without the header", trustedHops: 1, expected: "192.0.2.1"},
+		{name: "the address of the connection when the entry is not an ip", forwardedFor: []string{"unknown"}, trustedHops: 1, expected: "192.0.2.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			for _, forwardedFor := range tc.forwardedFor {
+				r.Header.Add("X-Forwarded-For", forwardedFor)
+			}
+
+			assert.Equal(t, tc.expected, util.ClientIP(r, tc.trustedHops))
+		})
+	}
+}
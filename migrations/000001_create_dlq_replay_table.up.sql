@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS dlq_replay (
+    message_id VARCHAR(255) PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    partition INTEGER NOT NULL,
+    "offset" BIGINT NOT NULL,
+    channel VARCHAR(255) NOT NULL,
+    consumer VARCHAR(255) NOT NULL,
+    message_key TEXT NOT NULL,
+    replayed_by VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    replayed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS dlq_replay_channel_idx ON dlq_replay (channel);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS event_status_idx;
+
+ALTER TABLE event DROP COLUMN IF EXISTS deleted_at;
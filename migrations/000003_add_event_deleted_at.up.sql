@@ -0,0 +1,3 @@
+ALTER TABLE event ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS event_status_idx ON event (status) WHERE deleted_at IS NULL;
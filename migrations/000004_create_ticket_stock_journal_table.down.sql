@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ticket_stock_journal_ticket_stock_id_idx;
+
+DROP TABLE IF EXISTS ticket_stock_journal;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS ticket_stock_journal (
+    id BIGSERIAL PRIMARY KEY,
+    ticket_stock_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    quantity BIGINT NOT NULL,
+    stock BIGINT NOT NULL,
+    price NUMERIC NOT NULL,
+    description TEXT NOT NULL,
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ticket_stock_journal_ticket_stock_id_idx ON ticket_stock_journal (ticket_stock_id, created_at DESC);
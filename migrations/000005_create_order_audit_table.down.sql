@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS order_audit_order_id_idx;
+
+DROP TABLE IF EXISTS order_audit;
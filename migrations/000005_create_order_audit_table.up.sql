@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_audit (
+    id BIGSERIAL PRIMARY KEY,
+    order_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL,
+    proof TEXT NULL,
+    previous_status VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_audit_order_id_idx ON order_audit (order_id, created_at DESC);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS order_rule_day_scope_idx;
+DROP INDEX IF EXISTS order_rule_range_date_scope_idx;
+
+DELETE FROM order_rule_day WHERE show_id IS NOT NULL OR ticket_stock_id IS NOT NULL;
+DELETE FROM order_rule_range_date WHERE show_id IS NOT NULL OR ticket_stock_id IS NOT NULL;
+
+ALTER TABLE order_rule_day
+    DROP COLUMN IF EXISTS ticket_stock_id,
+    DROP COLUMN IF EXISTS show_id;
+
+ALTER TABLE order_rule_range_date
+    DROP COLUMN IF EXISTS ticket_stock_id,
+    DROP COLUMN IF EXISTS show_id;
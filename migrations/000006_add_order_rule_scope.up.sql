@@ -0,0 +1,10 @@
+ALTER TABLE order_rule_range_date
+    ADD COLUMN IF NOT EXISTS show_id VARCHAR(255) NULL,
+    ADD COLUMN IF NOT EXISTS ticket_stock_id VARCHAR(255) NULL;
+
+ALTER TABLE order_rule_day
+    ADD COLUMN IF NOT EXISTS show_id VARCHAR(255) NULL,
+    ADD COLUMN IF NOT EXISTS ticket_stock_id VARCHAR(255) NULL;
+
+CREATE INDEX IF NOT EXISTS order_rule_range_date_scope_idx ON order_rule_range_date (event_id, show_id, ticket_stock_id);
+CREATE INDEX IF NOT EXISTS order_rule_day_scope_idx ON order_rule_day (event_id, show_id, ticket_stock_id);
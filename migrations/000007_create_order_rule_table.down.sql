@@ -0,0 +1,6 @@
+ALTER TABLE customer
+    DROP COLUMN IF EXISTS member_tier;
+
+DROP INDEX IF EXISTS order_rule_event_id_idx;
+
+DROP TABLE IF EXISTS order_rule;
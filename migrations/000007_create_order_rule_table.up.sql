@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS order_rule (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    config JSONB NOT NULL,
+    position INT NOT NULL,
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_rule_event_id_idx ON order_rule (event_id, position);
+
+ALTER TABLE customer
+    ADD COLUMN IF NOT EXISTS member_tier VARCHAR(50) NOT NULL DEFAULT 'REGULAR';
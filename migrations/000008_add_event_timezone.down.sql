@@ -0,0 +1,2 @@
+ALTER TABLE event
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,3 @@
+-- the events that already exist were created in the default APP_TIMEZONE.
+ALTER TABLE event
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta';
@@ -0,0 +1,9 @@
+ALTER TABLE admin
+    DROP COLUMN IF EXISTS two_factor_recovery_codes,
+    DROP COLUMN IF EXISTS two_factor_enabled,
+    DROP COLUMN IF EXISTS two_factor_secret;
+
+ALTER TABLE customer
+    DROP COLUMN IF EXISTS two_factor_recovery_codes,
+    DROP COLUMN IF EXISTS two_factor_enabled,
+    DROP COLUMN IF EXISTS two_factor_secret;
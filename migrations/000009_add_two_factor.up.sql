@@ -0,0 +1,10 @@
+-- the secret is sealed with CRYPTO_SECRET and only the hashes of the recovery codes are stored.
+ALTER TABLE customer
+    ADD COLUMN IF NOT EXISTS two_factor_secret TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS two_factor_recovery_codes TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE admin
+    ADD COLUMN IF NOT EXISTS two_factor_secret TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS two_factor_recovery_codes TEXT[] NOT NULL DEFAULT '{}';
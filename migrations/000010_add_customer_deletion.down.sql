@@ -0,0 +1,3 @@
+ALTER TABLE customer
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deletion_requested_at;
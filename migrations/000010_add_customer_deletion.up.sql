@@ -0,0 +1,5 @@
+-- a customer that has requested the deletion is anonymized once the grace period has passed, the orders and the
+-- acquired tickets are kept for the financial records with the personal data of the customer anonymized.
+ALTER TABLE customer
+    ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ NULL;
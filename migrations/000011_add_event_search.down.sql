@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS event_show_location_coordinate_idx;
+DROP INDEX IF EXISTS event_show_location_country_idx;
+DROP INDEX IF EXISTS event_show_location_city_idx;
+
+DROP INDEX IF EXISTS event_show_event_id_time_idx;
+
+DROP INDEX IF EXISTS event_artist_search_idx;
+DROP INDEX IF EXISTS event_search_idx;
@@ -0,0 +1,12 @@
+-- the expressions of the full-text indexes have to match the ones of the search of the customer's app exactly. the
+-- 'simple' configuration is used since the names and the descriptions are mostly in indonesian, which postgres does
+-- not stem.
+CREATE INDEX IF NOT EXISTS event_search_idx ON event USING GIN (to_tsvector('simple', name || ' ' || description)) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS event_artist_search_idx ON event_artist USING GIN (to_tsvector('simple', name));
+
+CREATE INDEX IF NOT EXISTS event_show_event_id_time_idx ON event_show (event_id, time);
+
+CREATE INDEX IF NOT EXISTS event_show_location_city_idx ON event_show_location (LOWER(city));
+CREATE INDEX IF NOT EXISTS event_show_location_country_idx ON event_show_location (LOWER(country));
+-- the radius of the search is narrowed down to a bounding box on the coordinates before the distance is computed.
+CREATE INDEX IF NOT EXISTS event_show_location_coordinate_idx ON event_show_location (latitude, longitude);
@@ -0,0 +1,13 @@
+-- the events are written within the transaction of the change they describe and published by the relay once the
+-- transaction is committed, so an event is never published for a change that is rolled back.
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    message_key TEXT NOT NULL,
+    headers JSONB NOT NULL,
+    message BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    published_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON outbox (id) WHERE published_at IS NULL;
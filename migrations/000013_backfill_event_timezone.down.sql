@@ -0,0 +1,2 @@
+ALTER TABLE event
+    ALTER COLUMN timezone SET DEFAULT 'Asia/Jakarta';
@@ -0,0 +1,22 @@
+-- the events that were given the default of 000008 are backfilled from the location of their first show. indonesia
+-- spans three zones, which are told apart by the longitude of the location. an event without a show location, or
+-- with one outside indonesia, keeps Asia/Jakarta, the APP_TIMEZONE that it was created in.
+UPDATE event e
+SET timezone = CASE
+        WHEN LOWER(COALESCE(l.country, '')) NOT IN ('indonesia', 'id') THEN 'Asia/Jakarta'
+        WHEN l.longitude >= 127.0 THEN 'Asia/Jayapura'
+        WHEN l.longitude >= 114.5 THEN 'Asia/Makassar'
+        ELSE 'Asia/Jakarta'
+    END
+FROM (
+    SELECT DISTINCT ON (s.event_id) s.event_id, sl.country, sl.longitude
+    FROM event_show s
+        JOIN event_show_location sl ON sl.show_id = s.id
+    ORDER BY s.event_id, s.time
+) l
+WHERE l.event_id = e.id AND e.timezone = 'Asia/Jakarta';
+
+-- every event is created with its own timezone, a missing one must fail instead of silently taking a zone.
+ALTER TABLE event
+    ALTER COLUMN timezone DROP DEFAULT,
+    ALTER COLUMN timezone SET NOT NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS outbox_unpublished_key_idx;
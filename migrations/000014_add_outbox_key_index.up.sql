@@ -0,0 +1,2 @@
+-- the relay looks up the first unpublished message of a key, so the messages of a key are published in order.
+CREATE INDEX IF NOT EXISTS outbox_unpublished_key_idx ON outbox (message_key, id) WHERE published_at IS NULL;
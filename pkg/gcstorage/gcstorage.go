@@ -0,0 +1,79 @@
+package gcstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/option"
+)
+
+// ErrObjectNotExist is returned by NewReader when the object does not exist.
+var ErrObjectNotExist = storage.ErrObjectNotExist
+
+// Client reads and writes the objects of a single bucket, so every instance of the service sees the same objects.
+type Client interface {
+	// NewWriter writes the object, it is only created once the writer is closed without error. Cancelling ctx before
+	// the writer is closed discards the object.
+	NewWriter(ctx context.Context, name string, contentType string) io.WriteCloser
+	// NewReader reads the object until ctx is done.
+	NewReader(ctx context.Context, name string) (io.ReadCloser, error)
+	Delete(ctx context.Context, name string) error
+	Close() error
+}
+
+type storageClientImpl struct {
+	logger *logrus.Logger
+	client *storage.Client
+	bucket *storage.BucketHandle
+}
+
+func NewGCStorage(logger *logrus.Logger, bucket string, credsJson []byte) Client {
+	c, err := storage.NewClient(context.Background(), option.WithCredentialsJSON(credsJson))
+	if err != nil {
+		logger.WithField("object", "gcstorage").Error(err)
+		return nil
+	}
+
+	return &storageClientImpl{
+		logger: logger,
+		client: c,
+		bucket: c.Bucket(bucket),
+	}
+}
+
+func (sc *storageClientImpl) Close() error {
+	return sc.client.Close()
+}
+
+func (sc *storageClientImpl) NewWriter(ctx context.Context, name string, contentType string) io.WriteCloser {
+	w := sc.bucket.Object(name).NewWriter(ctx)
+	w.ContentType = contentType
+
+	return w
+}
+
+func (sc *storageClientImpl) NewReader(ctx context.Context, name string) (io.ReadCloser, error) {
+	r, err := sc.bucket.Object(name).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrObjectNotExist
+		}
+
+		sc.logger.WithField("object", "gcstorage").Error(err)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (sc *storageClientImpl) Delete(ctx context.Context, name string) error {
+	if err := sc.bucket.Object(name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		sc.logger.WithField("object", "gcstorage").Error(err)
+		return err
+	}
+
+	return nil
+}
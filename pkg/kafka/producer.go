@@ -12,11 +12,16 @@ func NewProducer() *splunkkafka.Producer {
 	cfg := config.Get()
 
 	cm := ck.ConfigMap{
-		"bootstrap.servers": cfg.Kafka.Hosts,
-		"security.protocol": cfg.Kafka.SecurityProtocol,
-		"sasl.mechanisms":   cfg.Kafka.SASLMechanisms,
-		"sasl.username":     cfg.Kafka.SASLUsername,
-		"sasl.password":     cfg.Kafka.SASLPassword,
+		"bootstrap.servers":  cfg.Kafka.Hosts,
+		"security.protocol":  cfg.Kafka.SecurityProtocol,
+		"sasl.mechanisms":    cfg.Kafka.SASLMechanisms,
+		"sasl.username":      cfg.Kafka.SASLUsername,
+		"sasl.password":      cfg.Kafka.SASLPassword,
+		"enable.idempotence": cfg.Kafka.ProducerIdempotence,
+	}
+
+	if cfg.Kafka.ProducerAcks != "" {
+		cm["acks"] = cfg.Kafka.ProducerAcks
 	}
 
 	producer, err := splunkkafka.NewProducer(&cm)
@@ -0,0 +1,190 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	ck "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sirupsen/logrus"
+)
+
+type ConfluentKafkaDLQConsumer interface {
+	GetMetadata(topic *string, allTopics bool, timeoutMs int) (*ck.Metadata, error)
+	QueryWatermarkOffsets(topic string, partition int32, timeoutMs int) (low, high int64, err error)
+	Assign(partitions []ck.TopicPartition) (err error)
+	Unassign() (err error)
+	ReadMessage(timeout time.Duration) (*ck.Message, error)
+	Close() (err error)
+}
+
+type ConfluentKafkaDLQReaderProperty struct {
+	Logger   *logrus.Logger
+	Topic    string
+	Consumer ConfluentKafkaDLQConsumer
+}
+
+type confluentKafkaDLQReader struct {
+	mu       sync.Mutex
+	logger   *logrus.Logger
+	topic    string
+	consumer ConfluentKafkaDLQConsumer
+}
+
+const (
+	dlqReaderMetadataTimeoutMs = 5000
+	dlqReaderPollTimeout       = time.Second
+)
+
+// Close implements DeadLetterQueueReader.
+func (r *confluentKafkaDLQReader) Close() (err error) {
+	return r.consumer.Close()
+}
+
+// Read implements DeadLetterQueueReader.
+func (r *confluentKafkaDLQReader) Read(ctx context.Context) (records []DeadLetterQueueRecord, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	md, err := r.consumer.GetMetadata(&r.topic, false, dlqReaderMetadataTimeoutMs)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, err
+	}
+
+	topicMetadata, ok := md.Topics[r.topic]
+	if !ok {
+		return nil, fmt.Errorf("topic '%s' is not found", r.topic)
+	}
+
+	partitions := make([]ck.TopicPartition, 0)
+	lastOffsets := make(map[int32]int64)
+	for _, p := range topicMetadata.Partitions {
+		low, high, err := r.consumer.QueryWatermarkOffsets(r.topic, p.ID, dlqReaderMetadataTimeoutMs)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, err
+		}
+
+		if high <= low {
+			continue
+		}
+
+		partitions = append(partitions, ck.TopicPartition{
+			Topic:     &r.topic,
+			Partition: p.ID,
+			Offset:    ck.Offset(low),
+		})
+		lastOffsets[p.ID] = high - 1
+	}
+
+	records = make([]DeadLetterQueueRecord, 0)
+	if len(partitions) == 0 {
+		return records, nil
+	}
+
+	if err := r.consumer.Assign(partitions); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return nil, err
+	}
+	defer r.consumer.Unassign()
+
+	for len(lastOffsets) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		msg, err := r.consumer.ReadMessage(dlqReaderPollTimeout)
+		if err != nil {
+			if ke, ok := err.(ck.Error); ok && ke.Code() == ck.ErrTimedOut {
+				continue
+			}
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return nil, err
+		}
+
+		partition := msg.TopicPartition.Partition
+		offset := int64(msg.TopicPartition.Offset)
+		if offset >= lastOffsets[partition] {
+			delete(lastOffsets, partition)
+		}
+
+		record, err := r.toRecord(msg)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).WithField("offset", offset).Warn()
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ReadOne implements DeadLetterQueueReader.
+func (r *confluentKafkaDLQReader) ReadOne(ctx context.Context, partition int32, offset int64) (record DeadLetterQueueRecord, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.consumer.Assign([]ck.TopicPartition{
+		{
+			Topic:     &r.topic,
+			Partition: partition,
+			Offset:    ck.Offset(offset),
+		},
+	}); err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error()
+		return DeadLetterQueueRecord{}, err
+	}
+	defer r.consumer.Unassign()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return DeadLetterQueueRecord{}, err
+		}
+
+		msg, err := r.consumer.ReadMessage(dlqReaderPollTimeout)
+		if err != nil {
+			if ke, ok := err.(ck.Error); ok && ke.Code() == ck.ErrTimedOut {
+				continue
+			}
+			r.logger.WithContext(ctx).WithError(err).Error()
+			return DeadLetterQueueRecord{}, err
+		}
+
+		if int64(msg.TopicPartition.Offset) != offset {
+			return DeadLetterQueueRecord{}, fmt.Errorf("message at offset '%d' is no longer available", offset)
+		}
+
+		return r.toRecord(msg)
+	}
+}
+
+func (r *confluentKafkaDLQReader) toRecord(msg *ck.Message) (DeadLetterQueueRecord, error) {
+	var dlqMessage DeadLetterQueueMessage
+	if err := json.Unmarshal(msg.Value, &dlqMessage); err != nil {
+		return DeadLetterQueueRecord{}, err
+	}
+
+	partition := msg.TopicPartition.Partition
+	offset := int64(msg.TopicPartition.Offset)
+
+	return DeadLetterQueueRecord{
+		ID:        DeadLetterQueueRecordID(r.topic, partition, offset),
+		Topic:     r.topic,
+		Partition: partition,
+		Offset:    offset,
+		Timestamp: msg.Timestamp,
+		Message:   dlqMessage,
+	}, nil
+}
+
+func DeadLetterQueueReaderFromConfluentKafkaConsumer(props ConfluentKafkaDLQReaderProperty) DeadLetterQueueReader {
+	return &confluentKafkaDLQReader{
+		logger:   props.Logger,
+		topic:    props.Topic,
+		consumer: props.Consumer,
+	}
+}
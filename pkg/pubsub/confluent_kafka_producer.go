@@ -2,9 +2,19 @@ package pubsub
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	ck "github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// Deadline of a synchronous publish whose context has no deadline.
+	defaultSyncDeliveryTimeout = 10 * time.Second
 )
 
 type ConfluentKafkaProducer interface {
@@ -14,10 +24,51 @@ type ConfluentKafkaProducer interface {
 	Close()
 }
 
+type syncDeliveryContextKey struct{}
+
+// WithSyncDelivery returns a context that makes the publisher wait for the delivery report of the message
+// until the context deadline and return the real delivery error.
+func WithSyncDelivery(ctx context.Context) context.Context {
+	return context.WithValue(ctx, syncDeliveryContextKey{}, true)
+}
+
+// IsSyncDelivery reports whether the context asks for a synchronous publish.
+func IsSyncDelivery(ctx context.Context) bool {
+	sync, _ := ctx.Value(syncDeliveryContextKey{}).(bool)
+	return sync
+}
+
+// deliveryOpaque is attached to every produced message, so the delivery report can be measured.
+type deliveryOpaque struct {
+	topic     string
+	startedAt time.Time
+}
+
+type producerMetrics struct {
+	inFlight metric.Int64UpDownCounter
+	failed   metric.Int64Counter
+	latency  metric.Float64Histogram
+}
+
+func newProducerMetrics() producerMetrics {
+	meter := otel.Meter("github.com/tsel-ticketmaster/tm-order/pkg/pubsub")
+
+	inFlight, _ := meter.Int64UpDownCounter("pubsub.publisher.in_flight", metric.WithDescription("Number of messages that are waiting for their delivery report."))
+	failed, _ := meter.Int64Counter("pubsub.publisher.failed", metric.WithDescription("Number of messages that are failed to be delivered."))
+	latency, _ := meter.Float64Histogram("pubsub.publisher.latency", metric.WithDescription("Time between producing a message and receiving its delivery report."), metric.WithUnit("ms"))
+
+	return producerMetrics{
+		inFlight: inFlight,
+		failed:   failed,
+		latency:  latency,
+	}
+}
+
 type confluentKafkaProducer struct {
 	closeChan chan struct{}
 	logger    *logrus.Logger
 	producer  ConfluentKafkaProducer
+	metrics   producerMetrics
 }
 
 func (p *confluentKafkaProducer) watchDeliveryReport() {
@@ -36,8 +87,8 @@ func (p *confluentKafkaProducer) processEventReport(event ck.Event) {
 	switch e := event.(type) {
 	case *ck.Message:
 		m := e
-		if m.TopicPartition.Error != nil {
-			p.logger.WithError(m.TopicPartition.Error).Error()
+		if err := p.recordDelivery(context.Background(), m); err != nil {
+			p.logger.WithError(err).Error()
 		}
 
 	default:
@@ -46,6 +97,21 @@ func (p *confluentKafkaProducer) processEventReport(event ck.Event) {
 
 }
 
+// recordDelivery records the metrics of the delivery report and returns the delivery error.
+func (p *confluentKafkaProducer) recordDelivery(ctx context.Context, m *ck.Message) error {
+	opaque, ok := m.Opaque.(*deliveryOpaque)
+	if ok {
+		attrs := metric.WithAttributes(attribute.String("topic", opaque.topic))
+		p.metrics.inFlight.Add(ctx, -1, attrs)
+		p.metrics.latency.Record(ctx, float64(time.Since(opaque.startedAt))/float64(time.Millisecond), attrs)
+		if m.TopicPartition.Error != nil {
+			p.metrics.failed.Add(ctx, 1, attrs)
+		}
+	}
+
+	return m.TopicPartition.Error
+}
+
 // Close implements Publisher.
 func (p *confluentKafkaProducer) Close() (err error) {
 	close(p.closeChan)
@@ -54,7 +120,8 @@ func (p *confluentKafkaProducer) Close() (err error) {
 	return nil
 }
 
-// Publish implements Publisher.
+// Publish implements Publisher. By default the message is produced asynchronously and only the produce error
+// is returned, use WithSyncDelivery to wait for the delivery report.
 func (p *confluentKafkaProducer) Publish(ctx context.Context, topic string, key string, headers MessageHeaders, message []byte) (err error) {
 	kafkaMessageKey := []byte(key)
 	kafkaMessageHeader := make([]ck.Header, 0)
@@ -73,13 +140,59 @@ func (p *confluentKafkaProducer) Publish(ctx context.Context, topic string, key
 		Value:   message,
 		Key:     kafkaMessageKey,
 		Headers: kafkaMessageHeader,
+		Opaque: &deliveryOpaque{
+			topic:     topic,
+			startedAt: time.Now(),
+		},
 	}
 
-	if err := p.producer.Produce(kafkaMessage, nil); err != nil {
-		p.logger.WithContext(ctx).Error(err)
+	var deliveryChan chan ck.Event
+	if IsSyncDelivery(ctx) {
+		deliveryChan = make(chan ck.Event, 1)
 	}
 
-	return nil
+	attrs := metric.WithAttributes(attribute.String("topic", topic))
+	if err := p.producer.Produce(kafkaMessage, deliveryChan); err != nil {
+		p.metrics.failed.Add(ctx, 1, attrs)
+		p.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Error()
+		return err
+	}
+	p.metrics.inFlight.Add(ctx, 1, attrs)
+
+	if deliveryChan == nil {
+		return nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSyncDeliveryTimeout)
+		defer cancel()
+	}
+
+	select {
+	case event := <-deliveryChan:
+		m, ok := event.(*ck.Message)
+		if !ok {
+			return fmt.Errorf("unexpected delivery report: %s", event.String())
+		}
+
+		if err := p.recordDelivery(ctx, m); err != nil {
+			p.logger.WithContext(ctx).WithError(err).WithField("topic", topic).Error()
+			return err
+		}
+
+		return nil
+	case <-ctx.Done():
+		// The message is only unconfirmed, its delivery report is still recorded once it arrives.
+		go func() {
+			if m, ok := (<-deliveryChan).(*ck.Message); ok {
+				p.recordDelivery(context.Background(), m)
+			}
+		}()
+
+		p.logger.WithContext(ctx).WithError(ctx.Err()).WithField("topic", topic).Error("delivery report is not received in time")
+		return fmt.Errorf("waiting for delivery report of topic '%s': %w", topic, ctx.Err())
+	}
 }
 
 func PublisherFromConfluentKafkaProducer(logger *logrus.Logger, producer ConfluentKafkaProducer) Publisher {
@@ -87,6 +200,7 @@ func PublisherFromConfluentKafkaProducer(logger *logrus.Logger, producer Conflue
 		closeChan: make(chan struct{}, 1),
 		logger:    logger,
 		producer:  producer,
+		metrics:   newProducerMetrics(),
 	}
 
 	go publisher.watchDeliveryReport()
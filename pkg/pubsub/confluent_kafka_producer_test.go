@@ -0,0 +1,93 @@
+package pubsub_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ck "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+)
+
+type fakeProducer struct {
+	events       chan ck.Event
+	produceErr   error
+	deliveryErr  error
+	dropDelivery bool
+}
+
+func (p *fakeProducer) Events() chan ck.Event {
+	return p.events
+}
+
+func (p *fakeProducer) Produce(msg *ck.Message, deliveryChan chan ck.Event) error {
+	if p.produceErr != nil {
+		return p.produceErr
+	}
+
+	if p.dropDelivery {
+		return nil
+	}
+
+	msg.TopicPartition.Error = p.deliveryErr
+	if deliveryChan != nil {
+		deliveryChan <- msg
+		return nil
+	}
+
+	p.events <- msg
+	return nil
+}
+
+func (p *fakeProducer) Flush(timeoutMs int) int {
+	return 0
+}
+
+func (p *fakeProducer) Close() {}
+
+func TestConfluentKafkaProducerPublish(t *testing.T) {
+	logger := logrus.New()
+	errDelivery := errors.New("delivery failed")
+
+	t.Run("return produce error", func(t *testing.T) {
+		errProduce := errors.New("queue is full")
+		publisher := pubsub.PublisherFromConfluentKafkaProducer(logger, &fakeProducer{events: make(chan ck.Event, 1), produceErr: errProduce})
+		defer publisher.Close()
+
+		assert.ErrorIs(t, publisher.Publish(context.Background(), "order-paid", "1", nil, []byte("message")), errProduce)
+	})
+
+	t.Run("asynchronous publish does not wait for delivery report", func(t *testing.T) {
+		publisher := pubsub.PublisherFromConfluentKafkaProducer(logger, &fakeProducer{events: make(chan ck.Event, 1), deliveryErr: errDelivery})
+		defer publisher.Close()
+
+		assert.NoError(t, publisher.Publish(context.Background(), "order-paid", "1", nil, []byte("message")))
+	})
+
+	t.Run("synchronous publish return delivery error", func(t *testing.T) {
+		publisher := pubsub.PublisherFromConfluentKafkaProducer(logger, &fakeProducer{events: make(chan ck.Event, 1), deliveryErr: errDelivery})
+		defer publisher.Close()
+
+		assert.ErrorIs(t, publisher.Publish(pubsub.WithSyncDelivery(context.Background()), "order-paid", "1", nil, []byte("message")), errDelivery)
+	})
+
+	t.Run("synchronous publish succeed", func(t *testing.T) {
+		publisher := pubsub.PublisherFromConfluentKafkaProducer(logger, &fakeProducer{events: make(chan ck.Event, 1)})
+		defer publisher.Close()
+
+		assert.NoError(t, publisher.Publish(pubsub.WithSyncDelivery(context.Background()), "order-paid", "1", nil, []byte("message")))
+	})
+
+	t.Run("synchronous publish respect context deadline", func(t *testing.T) {
+		publisher := pubsub.PublisherFromConfluentKafkaProducer(logger, &fakeProducer{events: make(chan ck.Event, 1), dropDelivery: true})
+		defer publisher.Close()
+
+		ctx, cancel := context.WithTimeout(pubsub.WithSyncDelivery(context.Background()), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, publisher.Publish(ctx, "order-paid", "1", nil, []byte("message")), context.DeadlineExceeded)
+	})
+}
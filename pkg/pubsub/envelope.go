@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// CloudEventsSpecVersion is the version of CloudEvents specification that is used by the envelope.
+	CloudEventsSpecVersion = "1.0"
+
+	ContentTypeJSON            = "application/json"
+	ContentTypeCloudEventsJSON = "application/cloudevents+json; charset=UTF-8"
+
+	HeaderContentType = "content-type"
+)
+
+// Envelope is a CloudEvents (structured mode) envelope that wraps every published event.
+type Envelope struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Source          string      `json:"source"`
+	SpecVersion     string      `json:"specversion"`
+	Time            time.Time   `json:"time"`
+	Subject         string      `json:"subject,omitempty"`
+	DataContentType string      `json:"datacontenttype"`
+	DataSchema      string      `json:"dataschema"`
+	DataVersion     string      `json:"dataversion"`
+	Data            interface{} `json:"data"`
+}
+
+// Schema describes the contract of the data that is carried by an envelope.
+type Schema struct {
+	Type    string
+	Name    string
+	Version string
+}
+
+// URI returns the data schema reference of the schema.
+func (s Schema) URI() string {
+	return "/schemas/" + s.Name + "/" + s.Version
+}
+
+// NewEnvelope is a constructor.
+func NewEnvelope(source string, schema Schema, subject string, data interface{}) Envelope {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return Envelope{
+		ID:              hex.EncodeToString(b),
+		Type:            schema.Type,
+		Source:          source,
+		SpecVersion:     CloudEventsSpecVersion,
+		Time:            time.Now().UTC(),
+		Subject:         subject,
+		DataContentType: ContentTypeJSON,
+		DataSchema:      schema.URI(),
+		DataVersion:     schema.Version,
+		Data:            data,
+	}
+}
+
+// PublishEvent will serialize the envelope and publish it to the assigned topic. The envelope is serialized by
+// the publisher when it is an EventPublisher, otherwise it falls back to JSONSerializer.
+func PublishEvent(ctx context.Context, publisher Publisher, topic string, key string, headers MessageHeaders, e Envelope) (err error) {
+	eventPublisher, ok := publisher.(EventPublisher)
+	if !ok {
+		eventPublisher = PublisherWithSerializer(publisher, JSONSerializer{})
+	}
+
+	return eventPublisher.PublishEvent(ctx, topic, key, headers, e)
+}
@@ -0,0 +1,391 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	ck "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultInMemoryPartitions = 3
+	defaultInMemoryBufferSize = 256
+	defaultInMemoryRetention  = 10000
+)
+
+type InMemoryBrokerProperty struct {
+	Logger *logrus.Logger
+	// Number of partitions of every topic. Messages with the same key always land in the same partition.
+	Partitions int
+	// Number of messages that can be buffered by every partition of a subscriber.
+	BufferSize int
+	// Number of messages that are retained by every topic, the oldest messages are dropped first.
+	Retention int
+}
+
+// InMemoryBroker is an in process message broker that is built on channels. It is meant for tests and local mode,
+// the messages are delivered as *ck.Message so the event handlers behave the same as with kafka.
+type InMemoryBroker struct {
+	mu          sync.RWMutex
+	logger      *logrus.Logger
+	partitions  int
+	bufferSize  int
+	retention   int
+	offsets     map[string][]int64
+	ordering    map[string][]*sync.Mutex
+	messages    map[string][]*ck.Message
+	subscribers map[string][]*inMemorySubscriber
+	failures    map[string]error
+	inFlight    sync.WaitGroup
+}
+
+// NewInMemoryBroker is a constructor.
+func NewInMemoryBroker(props InMemoryBrokerProperty) *InMemoryBroker {
+	if props.Partitions <= 0 {
+		props.Partitions = defaultInMemoryPartitions
+	}
+
+	if props.BufferSize <= 0 {
+		props.BufferSize = defaultInMemoryBufferSize
+	}
+
+	if props.Retention <= 0 {
+		props.Retention = defaultInMemoryRetention
+	}
+
+	return &InMemoryBroker{
+		logger:      props.Logger,
+		partitions:  props.Partitions,
+		bufferSize:  props.BufferSize,
+		retention:   props.Retention,
+		offsets:     make(map[string][]int64),
+		ordering:    make(map[string][]*sync.Mutex),
+		messages:    make(map[string][]*ck.Message),
+		subscribers: make(map[string][]*inMemorySubscriber),
+		failures:    make(map[string]error),
+	}
+}
+
+func (b *InMemoryBroker) partition(key string) int32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+
+	return int32(h.Sum32() % uint32(b.partitions))
+}
+
+// FailPublish makes every publish to the topic fail with the given error. An empty topic applies to every topic.
+func (b *InMemoryBroker) FailPublish(topic string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures[topic] = err
+}
+
+// ClearFailures removes every simulated failure.
+func (b *InMemoryBroker) ClearFailures() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = make(map[string]error)
+}
+
+// Messages returns the retained messages of the topic in the order of publishing.
+func (b *InMemoryBroker) Messages(topic string) []*ck.Message {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	messages := make([]*ck.Message, len(b.messages[topic]))
+	copy(messages, b.messages[topic])
+
+	return messages
+}
+
+// Reset removes every captured message.
+func (b *InMemoryBroker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.messages = make(map[string][]*ck.Message)
+}
+
+// Flush waits until every published message has been handled by the subscribers.
+func (b *InMemoryBroker) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// partitionLock returns the lock that keeps the messages of the partition in the order of their offsets, from
+// the moment they are appended to the log until they are handed over to every subscriber.
+func (b *InMemoryBroker) partitionLock(topic string, partition int32) *sync.Mutex {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.ordering[topic]; !ok {
+		locks := make([]*sync.Mutex, b.partitions)
+		for k := range locks {
+			locks[k] = new(sync.Mutex)
+		}
+		b.ordering[topic] = locks
+	}
+
+	return b.ordering[topic][partition]
+}
+
+func (b *InMemoryBroker) publish(ctx context.Context, topic string, key string, headers MessageHeaders, message []byte) error {
+	partition := b.partition(key)
+
+	lock := b.partitionLock(topic, partition)
+	lock.Lock()
+	defer lock.Unlock()
+
+	b.mu.Lock()
+
+	if err, ok := b.failures[topic]; ok {
+		b.mu.Unlock()
+		return err
+	}
+
+	if err, ok := b.failures[""]; ok {
+		b.mu.Unlock()
+		return err
+	}
+
+	if _, ok := b.offsets[topic]; !ok {
+		b.offsets[topic] = make([]int64, b.partitions)
+	}
+
+	offset := b.offsets[topic][partition]
+	b.offsets[topic][partition]++
+
+	kafkaMessageHeader := make([]ck.Header, 0, len(headers))
+	for k, v := range headers {
+		kafkaMessageHeader = append(kafkaMessageHeader, ck.Header{
+			Key:   k,
+			Value: []byte(v),
+		})
+	}
+
+	t := topic
+	kafkaMessage := &ck.Message{
+		TopicPartition: ck.TopicPartition{
+			Topic:     &t,
+			Partition: partition,
+			Offset:    ck.Offset(offset),
+		},
+		Value:     message,
+		Key:       []byte(key),
+		Headers:   kafkaMessageHeader,
+		Timestamp: time.Now(),
+	}
+
+	messages := append(b.messages[topic], kafkaMessage)
+	if len(messages) > b.retention {
+		messages = append(messages[:0:0], messages[len(messages)-b.retention:]...)
+	}
+	b.messages[topic] = messages
+
+	subscribers := make([]*inMemorySubscriber, len(b.subscribers[topic]))
+	copy(subscribers, b.subscribers[topic])
+
+	b.inFlight.Add(len(subscribers))
+	b.mu.Unlock()
+
+	// the partition lock is still held, so the next message of the partition can only be delivered after this one.
+	for k, s := range subscribers {
+		if err := s.deliver(ctx, partition, kafkaMessage); err != nil {
+			b.inFlight.Add(-(len(subscribers) - k))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Publisher returns a publisher that publishes to the broker.
+func (b *InMemoryBroker) Publisher() Publisher {
+	return &inMemoryPublisher{broker: b}
+}
+
+// Subscriber returns a subscriber of the topic. Every subscriber receives every message of the topic.
+func (b *InMemoryBroker) Subscriber(topic string, eventHandler EventHandler) Subscriber {
+	s := &inMemorySubscriber{
+		broker:       b,
+		topic:        topic,
+		eventHandler: eventHandler,
+		closeChan:    make(chan struct{}),
+		partitions:   make([]chan *ck.Message, b.partitions),
+	}
+
+	for k := range s.partitions {
+		s.partitions[k] = make(chan *ck.Message, b.bufferSize)
+	}
+
+	return s
+}
+
+// DeadLetterQueueReader returns a dead letter queue reader of the topic.
+func (b *InMemoryBroker) DeadLetterQueueReader(topic string) DeadLetterQueueReader {
+	return &inMemoryDLQReader{broker: b, topic: topic}
+}
+
+type inMemoryPublisher struct {
+	broker *InMemoryBroker
+}
+
+// Publish implements Publisher.
+func (p *inMemoryPublisher) Publish(ctx context.Context, topic string, key string, headers MessageHeaders, message []byte) (err error) {
+	return p.broker.publish(ctx, topic, key, headers, message)
+}
+
+// Close implements Publisher.
+func (p *inMemoryPublisher) Close() (err error) {
+	return nil
+}
+
+type inMemorySubscriber struct {
+	broker       *InMemoryBroker
+	topic        string
+	eventHandler EventHandler
+	mu           sync.RWMutex
+	closed       bool
+	closeChan    chan struct{}
+	partitions   []chan *ck.Message
+}
+
+func (s *inMemorySubscriber) deliver(ctx context.Context, partition int32, m *ck.Message) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		s.broker.inFlight.Done()
+		return nil
+	}
+
+	select {
+	case s.partitions[partition] <- m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe implements Subscriber.
+func (s *inMemorySubscriber) Subscribe() {
+	s.broker.mu.Lock()
+	s.broker.subscribers[s.topic] = append(s.broker.subscribers[s.topic], s)
+	s.broker.mu.Unlock()
+
+	for _, partition := range s.partitions {
+		go s.consume(partition)
+	}
+}
+
+func (s *inMemorySubscriber) consume(partition chan *ck.Message) {
+	for {
+		select {
+		case <-s.closeChan:
+			for {
+				select {
+				case <-partition:
+					s.broker.inFlight.Done()
+				default:
+					return
+				}
+			}
+		case m := <-partition:
+			if err := s.eventHandler.Handle(context.Background(), m); err != nil && s.broker.logger != nil {
+				s.broker.logger.WithError(err).Error()
+			}
+			s.broker.inFlight.Done()
+		}
+	}
+}
+
+// Close implements Subscriber.
+func (s *inMemorySubscriber) Close() (err error) {
+	s.broker.mu.Lock()
+	subscribers := s.broker.subscribers[s.topic]
+	for k, v := range subscribers {
+		if v == s {
+			s.broker.subscribers[s.topic] = append(subscribers[:k:k], subscribers[k+1:]...)
+			break
+		}
+	}
+	s.broker.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.closeChan)
+	}
+
+	return nil
+}
+
+type inMemoryDLQReader struct {
+	broker *InMemoryBroker
+	topic  string
+}
+
+func (r *inMemoryDLQReader) toRecord(m *ck.Message) (DeadLetterQueueRecord, error) {
+	record := DeadLetterQueueRecord{
+		ID:        DeadLetterQueueRecordID(r.topic, m.TopicPartition.Partition, int64(m.TopicPartition.Offset)),
+		Topic:     r.topic,
+		Partition: m.TopicPartition.Partition,
+		Offset:    int64(m.TopicPartition.Offset),
+		Timestamp: m.Timestamp,
+	}
+
+	if err := json.Unmarshal(m.Value, &record.Message); err != nil {
+		return DeadLetterQueueRecord{}, err
+	}
+
+	return record, nil
+}
+
+// Read implements DeadLetterQueueReader.
+func (r *inMemoryDLQReader) Read(ctx context.Context) (records []DeadLetterQueueRecord, err error) {
+	records = make([]DeadLetterQueueRecord, 0)
+	for _, m := range r.broker.Messages(r.topic) {
+		record, err := r.toRecord(m)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ReadOne implements DeadLetterQueueReader.
+func (r *inMemoryDLQReader) ReadOne(ctx context.Context, partition int32, offset int64) (record DeadLetterQueueRecord, err error) {
+	for _, m := range r.broker.Messages(r.topic) {
+		if m.TopicPartition.Partition == partition && int64(m.TopicPartition.Offset) == offset {
+			return r.toRecord(m)
+		}
+	}
+
+	return DeadLetterQueueRecord{}, fmt.Errorf("message at partition %d offset %d of topic '%s' is not found", partition, offset, r.topic)
+}
+
+// Close implements DeadLetterQueueReader.
+func (r *inMemoryDLQReader) Close() (err error) {
+	return nil
+}
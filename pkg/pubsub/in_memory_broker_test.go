@@ -0,0 +1,169 @@
+package pubsub_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ck "github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+)
+
+type recordingHandler struct {
+	mu       sync.Mutex
+	received map[string][]string
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, message interface{}) (err error) {
+	m := message.(*ck.Message)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.received[string(m.Key)] = append(h.received[string(m.Key)], string(m.Value))
+
+	return nil
+}
+
+func TestInMemoryBroker(t *testing.T) {
+	t.Run("preserve per key ordering to every subscriber", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Partitions: 4, BufferSize: 1})
+		publisher := broker.Publisher()
+
+		handlers := []*recordingHandler{
+			{received: map[string][]string{}},
+			{received: map[string][]string{}},
+		}
+		for _, h := range handlers {
+			s := broker.Subscriber("order-paid", h)
+			s.Subscribe()
+			defer s.Close()
+		}
+
+		expected := map[string][]string{}
+		for i := 0; i < 50; i++ {
+			key := fmt.Sprintf("key-%d", i%5)
+			value := fmt.Sprintf("%d", i)
+			expected[key] = append(expected[key], value)
+			assert.NoError(t, publisher.Publish(context.Background(), "order-paid", key, pubsub.MessageHeaders{"origin": "test"}, []byte(value)))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, broker.Flush(ctx))
+
+		for _, h := range handlers {
+			assert.Equal(t, expected, h.received)
+		}
+	})
+
+	t.Run("deliver concurrent publishes of a key in the order of the log", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Partitions: 2, BufferSize: 1})
+		publisher := broker.Publisher()
+
+		handler := &recordingHandler{received: map[string][]string{}}
+		s := broker.Subscriber("order-paid", handler)
+		s.Subscribe()
+		defer s.Close()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				assert.NoError(t, publisher.Publish(context.Background(), "order-paid", "1", nil, []byte(fmt.Sprintf("%d", i))))
+			}(i)
+		}
+		wg.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, broker.Flush(ctx))
+
+		logged := make([]string, 0, 100)
+		for _, m := range broker.Messages("order-paid") {
+			logged = append(logged, string(m.Value))
+		}
+		assert.Len(t, logged, 100)
+		assert.Equal(t, logged, handler.received["1"])
+	})
+
+	t.Run("retain only the latest messages of a topic", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Retention: 3})
+		publisher := broker.Publisher()
+
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, publisher.Publish(context.Background(), "order-paid", "1", nil, []byte(fmt.Sprintf("%d", i))))
+		}
+
+		messages := broker.Messages("order-paid")
+		if assert.Len(t, messages, 3) {
+			assert.Equal(t, "2", string(messages[0].Value))
+			assert.Equal(t, "4", string(messages[2].Value))
+			assert.Equal(t, int64(4), int64(messages[2].TopicPartition.Offset))
+		}
+	})
+
+	t.Run("capture published messages", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		publisher := broker.Publisher()
+
+		assert.NoError(t, publisher.Publish(context.Background(), "customer-sign-up", "1", pubsub.MessageHeaders{"origin": "test"}, []byte("first")))
+		assert.NoError(t, publisher.Publish(context.Background(), "customer-sign-up", "1", nil, []byte("second")))
+
+		messages := broker.Messages("customer-sign-up")
+		if assert.Len(t, messages, 2) {
+			assert.Equal(t, "first", string(messages[0].Value))
+			assert.Equal(t, "second", string(messages[1].Value))
+			assert.Equal(t, messages[0].TopicPartition.Partition, messages[1].TopicPartition.Partition)
+			assert.Equal(t, messages[0].TopicPartition.Offset+1, messages[1].TopicPartition.Offset)
+			assert.Equal(t, []ck.Header{{Key: "origin", Value: []byte("test")}}, messages[0].Headers)
+		}
+		assert.Empty(t, broker.Messages("order-paid"))
+
+		broker.Reset()
+		assert.Empty(t, broker.Messages("customer-sign-up"))
+	})
+
+	t.Run("simulate delivery failure", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		publisher := broker.Publisher()
+		errBrokerDown := errors.New("broker is down")
+
+		broker.FailPublish("order-paid", errBrokerDown)
+		assert.ErrorIs(t, publisher.Publish(context.Background(), "order-paid", "1", nil, []byte("message")), errBrokerDown)
+		assert.NoError(t, publisher.Publish(context.Background(), "customer-sign-up", "1", nil, []byte("message")))
+
+		broker.FailPublish("", errBrokerDown)
+		assert.ErrorIs(t, publisher.Publish(context.Background(), "customer-sign-up", "1", nil, []byte("message")), errBrokerDown)
+
+		broker.ClearFailures()
+		assert.NoError(t, publisher.Publish(context.Background(), "order-paid", "1", nil, []byte("message")))
+		assert.Len(t, broker.Messages("order-paid"), 1)
+	})
+
+	t.Run("read dead letter queue", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		dlqHandler := pubsub.NewDLQHandlerAdapter("dlq", broker.Publisher())
+		assert.NoError(t, dlqHandler.Send(context.Background(), &pubsub.DeadLetterQueueMessage{
+			Channel:  "order-paid",
+			Consumer: "tm-notification",
+			Key:      "1",
+			Message:  "{}",
+		}))
+
+		reader := broker.DeadLetterQueueReader("dlq")
+		records, err := reader.Read(context.Background())
+		assert.NoError(t, err)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, "order-paid", records[0].Message.Channel)
+
+			record, err := reader.ReadOne(context.Background(), records[0].Partition, records[0].Offset)
+			assert.NoError(t, err)
+			assert.Equal(t, records[0].ID, record.ID)
+		}
+	})
+}
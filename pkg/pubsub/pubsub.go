@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	ck "github.com/confluentinc/confluent-kafka-go/kafka"
@@ -30,6 +32,52 @@ type DeadLetterQueueMessage struct {
 	FailedConsumeDate string         `json:"failed_consume_date"`
 }
 
+// DeadLetterQueueRecord is a dead letter queue message along with its position in the dead letter queue topic.
+type DeadLetterQueueRecord struct {
+	ID        string
+	Topic     string
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Message   DeadLetterQueueMessage
+}
+
+// DeadLetterQueueRecordID returns the identifier of a message in the dead letter queue topic.
+func DeadLetterQueueRecordID(topic string, partition int32, offset int64) string {
+	return fmt.Sprintf("%s:%d:%d", topic, partition, offset)
+}
+
+// ParseDeadLetterQueueRecordID returns the topic, partition and offset of the given dead letter queue record id.
+func ParseDeadLetterQueueRecordID(ID string) (topic string, partition int32, offset int64, err error) {
+	parts := strings.Split(ID, ":")
+	if len(parts) < 3 {
+		return "", 0, 0, fmt.Errorf("invalid dead letter queue record id '%s'", ID)
+	}
+
+	offset, err = strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid dead letter queue record id '%s'", ID)
+	}
+
+	p, err := strconv.ParseInt(parts[len(parts)-2], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid dead letter queue record id '%s'", ID)
+	}
+
+	topic = strings.Join(parts[:len(parts)-2], ":")
+
+	return topic, int32(p), offset, nil
+}
+
+// FailedConsumeTime returns the time when the message was failed to be consumed. It falls back to the record timestamp.
+func (r DeadLetterQueueRecord) FailedConsumeTime() time.Time {
+	if t, err := time.Parse(time.RFC3339, r.Message.FailedConsumeDate); err == nil {
+		return t
+	}
+
+	return r.Timestamp
+}
+
 // DLQHandlerAdapter is an dead letter queue adapter.
 type DLQHandlerAdapter struct {
 	topic     string
@@ -72,6 +120,15 @@ type DLQHandler interface {
 	Send(ctx context.Context, dlqMessage *DeadLetterQueueMessage) (err error)
 }
 
+// DeadLetterQueueReader is a collection of behavior of a dead letter queue reader.
+type DeadLetterQueueReader interface {
+	// Will read every message that is currently stored in the dead letter queue topic.
+	Read(ctx context.Context) (records []DeadLetterQueueRecord, err error)
+	// Will read a single message by its position in the dead letter queue topic.
+	ReadOne(ctx context.Context, partition int32, offset int64) (record DeadLetterQueueRecord, err error)
+	Close() (err error)
+}
+
 // EventHandler is an event handler. It will be called after message is arrived to consumer
 type EventHandler interface {
 	Handle(ctx context.Context, message interface{}) (err error)
@@ -0,0 +1,151 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	SchemaFormatJSON     = "json"
+	SchemaFormatProtobuf = "protobuf"
+)
+
+// SchemaRegistry is a collection of behavior of a schema registry.
+type SchemaRegistry interface {
+	// Will validate the payload against the registered schema and return the id of the schema.
+	Validate(schema string, format string, payload []byte) (schemaID string, err error)
+}
+
+// RegisteredSchema is a schema that is registered in the file based schema registry.
+type RegisteredSchema struct {
+	ID     string `json:"id"`
+	Schema string `json:"schema"`
+	Format string `json:"format"`
+	// Full name of the protobuf message, only for protobuf format.
+	Message string `json:"message,omitempty"`
+	// JSON type of every field (string, number, boolean, object or array), only for json format.
+	// A type with '?' suffix accepts null.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+type fileSchemaRegistry struct {
+	schemas map[string]RegisteredSchema
+}
+
+func registeredSchemaKey(schema, format string) string {
+	return schema + "@" + format
+}
+
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func (r *fileSchemaRegistry) validateJSON(rs RegisteredSchema, payload []byte) error {
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return fmt.Errorf("payload is not a json object: %w", err)
+	}
+
+	for field, fieldType := range rs.Fields {
+		v, ok := data[field]
+		if !ok {
+			return fmt.Errorf("field '%s' is required by schema '%s'", field, rs.ID)
+		}
+
+		actualType := jsonType(v)
+		if actualType == "null" && strings.HasSuffix(fieldType, "?") {
+			continue
+		}
+
+		if actualType != strings.TrimSuffix(fieldType, "?") {
+			return fmt.Errorf("field '%s' must be %s, got %s", field, fieldType, actualType)
+		}
+	}
+
+	return nil
+}
+
+func (r *fileSchemaRegistry) validateProtobuf(rs RegisteredSchema, payload []byte) error {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(rs.Message))
+	if err != nil {
+		return fmt.Errorf("protobuf message '%s' of schema '%s': %w", rs.Message, rs.ID, err)
+	}
+
+	m := mt.New().Interface()
+	if err := proto.Unmarshal(payload, m); err != nil {
+		return fmt.Errorf("payload is not a valid '%s': %w", rs.Message, err)
+	}
+
+	if len(m.ProtoReflect().GetUnknown()) > 0 {
+		return fmt.Errorf("payload has fields that are unknown to '%s'", rs.Message)
+	}
+
+	return nil
+}
+
+// Validate implements SchemaRegistry.
+func (r *fileSchemaRegistry) Validate(schema string, format string, payload []byte) (schemaID string, err error) {
+	rs, ok := r.schemas[registeredSchemaKey(schema, format)]
+	if !ok {
+		return "", fmt.Errorf("schema '%s' with format '%s' is not registered", schema, format)
+	}
+
+	switch rs.Format {
+	case SchemaFormatJSON:
+		err = r.validateJSON(rs, payload)
+	case SchemaFormatProtobuf:
+		err = r.validateProtobuf(rs, payload)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return rs.ID, nil
+}
+
+// SchemaRegistryFromFile returns a schema registry that is loaded from a local json file. It is a stand-in of a live
+// schema registry, so the payloads can be validated at publish time.
+func SchemaRegistryFromFile(path string) (SchemaRegistry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := struct {
+		Schemas []RegisteredSchema `json:"schemas"`
+	}{}
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, fmt.Errorf("invalid schema registry file '%s': %w", path, err)
+	}
+
+	r := &fileSchemaRegistry{
+		schemas: make(map[string]RegisteredSchema, len(file.Schemas)),
+	}
+	for _, rs := range file.Schemas {
+		if rs.Format != SchemaFormatJSON && rs.Format != SchemaFormatProtobuf {
+			return nil, fmt.Errorf("schema '%s' has unsupported format '%s'", rs.ID, rs.Format)
+		}
+		r.schemas[registeredSchemaKey(rs.Schema, rs.Format)] = rs
+	}
+
+	return r, nil
+}
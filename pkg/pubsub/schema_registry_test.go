@@ -0,0 +1,189 @@
+package pubsub_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const registryFile = `{
+  "schemas": [
+    {
+      "id": "10",
+      "schema": "/schemas/sample/v1",
+      "format": "json",
+      "fields": {
+        "id": "string",
+        "amount": "number",
+        "paid": "boolean",
+        "note": "string?",
+        "items": "array",
+        "meta": "object"
+      }
+    },
+    {
+      "id": "11",
+      "schema": "/schemas/sample/v1",
+      "format": "protobuf",
+      "message": "google.protobuf.Duration"
+    },
+    {
+      "id": "12",
+      "schema": "/schemas/missing/v1",
+      "format": "protobuf",
+      "message": "tm.order.Missing"
+    }
+  ]
+}`
+
+func newSchemaRegistry(t *testing.T, content string) (pubsub.SchemaRegistry, error) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "registry.json")
+	if !assert.NoError(t, os.WriteFile(path, []byte(content), 0o600)) {
+		t.FailNow()
+	}
+
+	return pubsub.SchemaRegistryFromFile(path)
+}
+
+func TestSchemaRegistryFromFile(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, err := pubsub.SchemaRegistryFromFile(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := newSchemaRegistry(t, `{"schemas": [`)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := newSchemaRegistry(t, `{"schemas": [{"id": "1", "schema": "/schemas/sample/v1", "format": "avro"}]}`)
+		assert.Error(t, err)
+	})
+}
+
+func TestFileSchemaRegistryValidate(t *testing.T) {
+	registry, err := newSchemaRegistry(t, registryFile)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	duration, _ := proto.Marshal(durationpb.New(90))
+	unknownField := protowire.AppendVarint(protowire.AppendTag(append([]byte{}, duration...), 9, protowire.VarintType), 1)
+
+	testCases := []struct {
+		name     string
+		schema   string
+		format   string
+		payload  []byte
+		schemaID string
+		invalid  bool
+	}{
+		{
+			name:     "json payload that matches the schema",
+			schema:   "/schemas/sample/v1",
+			format:   pubsub.SchemaFormatJSON,
+			payload:  []byte(`{"id": "1", "amount": 10.5, "paid": true, "note": "n", "items": [], "meta": {}, "extra": 1}`),
+			schemaID: "10",
+		},
+		{
+			name:     "json payload with null on a nullable field",
+			schema:   "/schemas/sample/v1",
+			format:   pubsub.SchemaFormatJSON,
+			payload:  []byte(`{"id": "1", "amount": 10, "paid": false, "note": null, "items": [1], "meta": {"a": 1}}`),
+			schemaID: "10",
+		},
+		{
+			name:    "json payload without a required field",
+			schema:  "/schemas/sample/v1",
+			format:  pubsub.SchemaFormatJSON,
+			payload: []byte(`{"id": "1", "amount": 10, "paid": false, "note": null, "items": []}`),
+			invalid: true,
+		},
+		{
+			name:    "json payload with null on a non nullable field",
+			schema:  "/schemas/sample/v1",
+			format:  pubsub.SchemaFormatJSON,
+			payload: []byte(`{"id": null, "amount": 10, "paid": false, "note": null, "items": [], "meta": {}}`),
+			invalid: true,
+		},
+		{
+			name:    "json payload with a retyped field",
+			schema:  "/schemas/sample/v1",
+			format:  pubsub.SchemaFormatJSON,
+			payload: []byte(`{"id": "1", "amount": "10", "paid": false, "note": null, "items": [], "meta": {}}`),
+			invalid: true,
+		},
+		{
+			name:    "json payload that is not an object",
+			schema:  "/schemas/sample/v1",
+			format:  pubsub.SchemaFormatJSON,
+			payload: []byte(`[1, 2]`),
+			invalid: true,
+		},
+		{
+			name:     "protobuf payload that matches the message",
+			schema:   "/schemas/sample/v1",
+			format:   pubsub.SchemaFormatProtobuf,
+			payload:  duration,
+			schemaID: "11",
+		},
+		{
+			name:    "protobuf payload with unknown fields",
+			schema:  "/schemas/sample/v1",
+			format:  pubsub.SchemaFormatProtobuf,
+			payload: unknownField,
+			invalid: true,
+		},
+		{
+			name:    "protobuf payload that can not be decoded",
+			schema:  "/schemas/sample/v1",
+			format:  pubsub.SchemaFormatProtobuf,
+			payload: []byte{0xff, 0xff},
+			invalid: true,
+		},
+		{
+			name:    "protobuf message that is not linked in",
+			schema:  "/schemas/missing/v1",
+			format:  pubsub.SchemaFormatProtobuf,
+			payload: duration,
+			invalid: true,
+		},
+		{
+			name:    "schema that is not registered",
+			schema:  "/schemas/unknown/v1",
+			format:  pubsub.SchemaFormatJSON,
+			payload: []byte(`{}`),
+			invalid: true,
+		},
+		{
+			name:    "format that is not registered for the schema",
+			schema:  "/schemas/missing/v1",
+			format:  pubsub.SchemaFormatJSON,
+			payload: []byte(`{}`),
+			invalid: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaID, err := registry.Validate(tc.schema, tc.format, tc.payload)
+			if tc.invalid {
+				assert.Error(t, err)
+				assert.Empty(t, schemaID)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.schemaID, schemaID)
+		})
+	}
+}
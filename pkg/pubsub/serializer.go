@@ -0,0 +1,149 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ContentTypeProtobuf = "application/x-protobuf"
+
+	HeaderSchemaID = "schema-id"
+
+	// Header prefix of CloudEvents attributes in binary content mode of the Kafka protocol binding.
+	headerCloudEventsPrefix = "ce_"
+)
+
+// Serializer is a collection of behavior of an envelope serializer.
+type Serializer interface {
+	// Will return the message and the headers that represent the envelope.
+	Serialize(e Envelope) (message []byte, headers MessageHeaders, err error)
+}
+
+// ProtoConvertible is implemented by event data that has a protobuf representation.
+type ProtoConvertible interface {
+	ToProto() proto.Message
+}
+
+// JSONSerializer serializes the envelope in structured content mode, the data is carried as JSON inside the envelope.
+type JSONSerializer struct {
+	// Registry is optional. If it is assigned, the data will be validated before it is serialized.
+	Registry SchemaRegistry
+}
+
+// Serialize implements Serializer.
+func (s JSONSerializer) Serialize(e Envelope) (message []byte, headers MessageHeaders, err error) {
+	headers = MessageHeaders{}
+	headers.Add(HeaderContentType, ContentTypeCloudEventsJSON)
+
+	if s.Registry != nil {
+		dataByte, err := json.Marshal(e.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		schemaID, err := s.Registry.Validate(e.DataSchema, SchemaFormatJSON, dataByte)
+		if err != nil {
+			return nil, nil, err
+		}
+		headers.Add(HeaderSchemaID, schemaID)
+	}
+
+	e.DataContentType = ContentTypeJSON
+	message, err = json.Marshal(e)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return message, headers, nil
+}
+
+// ProtobufSerializer serializes the envelope in binary content mode, the data is carried as protobuf in the message value
+// and the envelope attributes are carried in the headers. Data without protobuf representation falls back to JSONSerializer.
+type ProtobufSerializer struct {
+	// Registry is optional. If it is assigned, the data will be validated before it is serialized.
+	Registry SchemaRegistry
+}
+
+// Serialize implements Serializer.
+func (s ProtobufSerializer) Serialize(e Envelope) (message []byte, headers MessageHeaders, err error) {
+	var data proto.Message
+	switch d := e.Data.(type) {
+	case proto.Message:
+		data = d
+	case ProtoConvertible:
+		data = d.ToProto()
+	default:
+		return JSONSerializer{Registry: s.Registry}.Serialize(e)
+	}
+
+	message, err = proto.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers = MessageHeaders{}
+	if s.Registry != nil {
+		schemaID, err := s.Registry.Validate(e.DataSchema, SchemaFormatProtobuf, message)
+		if err != nil {
+			return nil, nil, err
+		}
+		headers.Add(HeaderSchemaID, schemaID)
+	}
+
+	headers.Add(HeaderContentType, ContentTypeProtobuf)
+	headers.Add(headerCloudEventsPrefix+"id", e.ID)
+	headers.Add(headerCloudEventsPrefix+"type", e.Type)
+	headers.Add(headerCloudEventsPrefix+"source", e.Source)
+	headers.Add(headerCloudEventsPrefix+"specversion", e.SpecVersion)
+	headers.Add(headerCloudEventsPrefix+"time", e.Time.Format(time.RFC3339Nano))
+	headers.Add(headerCloudEventsPrefix+"dataschema", e.DataSchema)
+	headers.Add(headerCloudEventsPrefix+"dataversion", e.DataVersion)
+	if e.Subject != "" {
+		headers.Add(headerCloudEventsPrefix+"subject", e.Subject)
+	}
+
+	return message, headers, nil
+}
+
+// EventPublisher is a publisher that knows how to serialize an envelope.
+type EventPublisher interface {
+	Publisher
+	// Will serialize the envelope and send it to the assigned topic.
+	PublishEvent(ctx context.Context, topic string, key string, headers MessageHeaders, e Envelope) (err error)
+}
+
+type serializerPublisher struct {
+	Publisher
+	serializer Serializer
+}
+
+// PublishEvent implements EventPublisher.
+func (p *serializerPublisher) PublishEvent(ctx context.Context, topic string, key string, headers MessageHeaders, e Envelope) (err error) {
+	message, serializerHeaders, err := p.serializer.Serialize(e)
+	if err != nil {
+		return fmt.Errorf("serialize event '%s' of topic '%s': %w", e.Type, topic, err)
+	}
+
+	messageHeaders := MessageHeaders{}
+	for k, v := range headers {
+		messageHeaders.Add(k, v)
+	}
+	for k, v := range serializerHeaders {
+		messageHeaders.Add(k, v)
+	}
+
+	return p.Publish(ctx, topic, key, messageHeaders, message)
+}
+
+// PublisherWithSerializer returns a publisher that serializes every published envelope with the given serializer.
+func PublisherWithSerializer(publisher Publisher, serializer Serializer) EventPublisher {
+	return &serializerPublisher{
+		Publisher:  publisher,
+		serializer: serializer,
+	}
+}
@@ -0,0 +1,200 @@
+package pubsub_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var sampleSchema = pubsub.Schema{Type: "sample-created", Name: "sample", Version: "v1"}
+
+type sampleData struct {
+	ID     string                 `json:"id"`
+	Amount float64                `json:"amount"`
+	Paid   bool                   `json:"paid"`
+	Note   *string                `json:"note"`
+	Items  []string               `json:"items"`
+	Meta   map[string]interface{} `json:"meta"`
+}
+
+// sampleDuration is data that has a protobuf representation.
+type sampleDuration time.Duration
+
+func (d sampleDuration) ToProto() proto.Message {
+	return durationpb.New(time.Duration(d))
+}
+
+func newSampleEnvelope(subject string, data interface{}) pubsub.Envelope {
+	e := pubsub.NewEnvelope("tm-order", sampleSchema, subject, data)
+	e.Time = time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	return e
+}
+
+func TestJSONSerializer(t *testing.T) {
+	registry, err := newSchemaRegistry(t, registryFile)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	valid := sampleData{ID: "1", Amount: 10, Items: []string{}, Meta: map[string]interface{}{}}
+
+	testCases := []struct {
+		name     string
+		registry pubsub.SchemaRegistry
+		data     interface{}
+		schemaID string
+		invalid  bool
+	}{
+		{name: "without registry", data: sampleData{ID: "1"}},
+		{name: "with registry", registry: registry, data: valid, schemaID: "10"},
+		{name: "data that is rejected by the registry", registry: registry, data: map[string]interface{}{"id": 1}, invalid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newSampleEnvelope("1", tc.data)
+			e.DataContentType = ""
+
+			message, headers, err := pubsub.JSONSerializer{Registry: tc.registry}.Serialize(e)
+			if tc.invalid {
+				assert.Error(t, err)
+				assert.Nil(t, message)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, pubsub.ContentTypeCloudEventsJSON, headers[pubsub.HeaderContentType])
+			schemaID, ok := headers[pubsub.HeaderSchemaID]
+			assert.Equal(t, tc.schemaID != "", ok)
+			assert.Equal(t, tc.schemaID, schemaID)
+
+			actual := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(message, &actual))
+			assert.Equal(t, e.ID, actual["id"])
+			assert.Equal(t, "sample-created", actual["type"])
+			assert.Equal(t, "tm-order", actual["source"])
+			assert.Equal(t, pubsub.CloudEventsSpecVersion, actual["specversion"])
+			assert.Equal(t, pubsub.ContentTypeJSON, actual["datacontenttype"])
+			assert.Equal(t, "/schemas/sample/v1", actual["dataschema"])
+			assert.Equal(t, "v1", actual["dataversion"])
+			assert.Equal(t, "1", actual["subject"])
+			assert.Equal(t, "1", actual["data"].(map[string]interface{})["id"])
+		})
+	}
+}
+
+func TestProtobufSerializer(t *testing.T) {
+	registry, err := newSchemaRegistry(t, registryFile)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	expectedMessage, _ := proto.Marshal(durationpb.New(90 * time.Second))
+
+	testCases := []struct {
+		name     string
+		registry pubsub.SchemaRegistry
+		subject  string
+		data     interface{}
+		schemaID string
+	}{
+		{name: "proto message", data: durationpb.New(90 * time.Second), subject: "1"},
+		{name: "data that is convertible to proto", data: sampleDuration(90 * time.Second), subject: "1"},
+		{name: "with registry and without subject", registry: registry, data: sampleDuration(90 * time.Second), schemaID: "11"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newSampleEnvelope(tc.subject, tc.data)
+
+			message, headers, err := pubsub.ProtobufSerializer{Registry: tc.registry}.Serialize(e)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedMessage, message)
+
+			assert.Equal(t, pubsub.ContentTypeProtobuf, headers[pubsub.HeaderContentType])
+			assert.Equal(t, e.ID, headers["ce_id"])
+			assert.Equal(t, "sample-created", headers["ce_type"])
+			assert.Equal(t, "tm-order", headers["ce_source"])
+			assert.Equal(t, pubsub.CloudEventsSpecVersion, headers["ce_specversion"])
+			assert.Equal(t, "2026-10-01T10:00:00Z", headers["ce_time"])
+			assert.Equal(t, "/schemas/sample/v1", headers["ce_dataschema"])
+			assert.Equal(t, "v1", headers["ce_dataversion"])
+
+			subject, ok := headers["ce_subject"]
+			assert.Equal(t, tc.subject != "", ok)
+			assert.Equal(t, tc.subject, subject)
+
+			schemaID, ok := headers[pubsub.HeaderSchemaID]
+			assert.Equal(t, tc.schemaID != "", ok)
+			assert.Equal(t, tc.schemaID, schemaID)
+		})
+	}
+
+	t.Run("data without protobuf representation falls back to json", func(t *testing.T) {
+		message, headers, err := pubsub.ProtobufSerializer{}.Serialize(newSampleEnvelope("1", sampleData{ID: "1"}))
+		assert.NoError(t, err)
+		assert.Equal(t, pubsub.ContentTypeCloudEventsJSON, headers[pubsub.HeaderContentType])
+		assert.True(t, json.Valid(message))
+	})
+
+	t.Run("data that is rejected by the registry", func(t *testing.T) {
+		e := newSampleEnvelope("1", sampleDuration(time.Second))
+		e.DataSchema = "/schemas/missing/v1"
+
+		_, _, err := pubsub.ProtobufSerializer{Registry: registry}.Serialize(e)
+		assert.Error(t, err)
+	})
+}
+
+func TestPublishEvent(t *testing.T) {
+	t.Run("serializer headers are merged into the given headers", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		publisher := pubsub.PublisherWithSerializer(broker.Publisher(), pubsub.ProtobufSerializer{})
+
+		err := pubsub.PublishEvent(context.Background(), publisher, "sample", "1", pubsub.MessageHeaders{"origin": "test"}, newSampleEnvelope("1", sampleDuration(time.Second)))
+		assert.NoError(t, err)
+
+		messages := broker.Messages("sample")
+		if assert.Len(t, messages, 1) {
+			headers := map[string]string{}
+			for _, h := range messages[0].Headers {
+				headers[h.Key] = string(h.Value)
+			}
+			assert.Equal(t, "test", headers["origin"])
+			assert.Equal(t, pubsub.ContentTypeProtobuf, headers[pubsub.HeaderContentType])
+		}
+	})
+
+	t.Run("publisher without serializer falls back to json", func(t *testing.T) {
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+
+		err := pubsub.PublishEvent(context.Background(), broker.Publisher(), "sample", "1", nil, newSampleEnvelope("1", sampleData{ID: "1"}))
+		assert.NoError(t, err)
+
+		messages := broker.Messages("sample")
+		if assert.Len(t, messages, 1) {
+			assert.True(t, json.Valid(messages[0].Value))
+		}
+	})
+
+	t.Run("nothing is published when the serializer fails", func(t *testing.T) {
+		registry, err := newSchemaRegistry(t, registryFile)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+
+		broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{})
+		publisher := pubsub.PublisherWithSerializer(broker.Publisher(), pubsub.JSONSerializer{Registry: registry})
+
+		err = pubsub.PublishEvent(context.Background(), publisher, "sample", "1", nil, newSampleEnvelope("1", map[string]interface{}{"id": 1}))
+		assert.Error(t, err)
+		assert.Empty(t, broker.Messages("sample"))
+	})
+}
@@ -3,12 +3,15 @@ package status
 const (
 	OK                    = "OK"
 	CREATED               = "CREATED"
+	ACCEPTED              = "ACCEPTED"
 	BAD_REQUEST           = "BAD_REQUEST"
 	UNAUTHORIZED          = "UNAUTHORIZED"
 	FORBIDDEN             = "FORBIDDEN"
 	NOT_FOUND             = "NOT_FOUND"
+	CONFLICT              = "CONFLICT"
 	UNPROCESSABLE_ENTITY  = "UNPROCESSABLE_ENTITY"
 	EXPECTATION_FAILED    = "EXPECTATION_FAILED"
+	TOO_MANY_REQUESTS     = "TOO_MANY_REQUESTS"
 	INTERNAL_SERVER_ERROR = "INTERNAL_SERVER_ERROR"
 
 	// custom status
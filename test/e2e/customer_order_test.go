@@ -0,0 +1,535 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/customer"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/midtrans"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/contract"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/lockout"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/middleware"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/orderrule"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/outbox"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/password"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/session"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/standin"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/twofactor"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/util"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gctasks"
+	"github.com/tsel-ticketmaster/tm-order/pkg/pubsub"
+	"github.com/tsel-ticketmaster/tm-order/pkg/validator"
+)
+
+type envelope struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func do(t *testing.T, method, url, token string, body interface{}) (int, envelope) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if body != nil {
+		buff, _ := json.Marshal(body)
+		reqBody = bytes.NewBuffer(buff)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer resp.Body.Close()
+
+	var env envelope
+	json.NewDecoder(resp.Body).Decode(&env)
+
+	return resp.StatusCode, env
+}
+
+func TestCustomerAccountAndOrder(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	jsonWebToken := standin.JSONWebToken()
+
+	rc := standin.NewRedis()
+	sess := session.NewRedisSessionStore(logger, rc)
+	broker := pubsub.NewInMemoryBroker(pubsub.InMemoryBrokerProperty{Logger: logger})
+	publisher := pubsub.PublisherWithSerializer(broker.Publisher(), pubsub.JSONSerializer{})
+	midtransServer := newMidtransStandIn()
+	defer midtransServer.Close()
+	cloudTask := &cloudTaskStandIn{}
+
+	now := time.Now()
+	showID, ticketStockID := "show-1", "ticket-stock-1"
+	cat := &catalog{
+		event: event.Event{
+			ID:       "event-1",
+			Name:     "Konser",
+			Status:   event.StatusActive,
+			Timezone: "Asia/Jakarta",
+		},
+		show: event.Show{
+			EventID: "event-1",
+			ID:      showID,
+			Venue:   "Gelora Bung Karno",
+			Status:  event.StatusActive,
+			Time:    now.Add(30 * 24 * time.Hour),
+		},
+		ticketStock: ticket.TicketStock{
+			EventID:    "event-1",
+			ShowID:     showID,
+			ID:         ticketStockID,
+			Tier:       "VIP",
+			Allocation: 100,
+			Price:      1000000,
+		},
+		rangeDate: order.OrderRuleRangeDate{
+			EventID:   "event-1",
+			StartDate: now.Add(-24 * time.Hour),
+			EndDate:   now.Add(24 * time.Hour),
+		},
+	}
+	customerRepo := newCustomerRepositoryStandIn()
+	orderRepo := &orderRepositoryStandIn{}
+	outboxRepo := &outboxRepositoryStandIn{}
+	orderOutbox := outbox.NewOutbox(outbox.OutboxProperty{
+		Logger:     logger,
+		Publisher:  broker.Publisher(),
+		Serializer: pubsub.JSONSerializer{},
+		Repository: outboxRepo,
+	})
+
+	router := mux.NewRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	customerSessionMiddleware := middleware.NewCustomerSessionMiddleware(jsonWebToken, sess)
+	validate := validator.Get()
+
+	customerUseCase := customer.NewCustomerUseCase(customer.CustomerUseCaseProperty{
+		AppName:                  "tm-order",
+		Logger:                   logger,
+		Timeout:                  5 * time.Second,
+		TMUserBaseURL:            server.URL + "/tm-order",
+		BaseURL:                  server.URL + "/tm-order",
+		VerificationExpiration:   5 * time.Minute,
+		DeletionGracePeriod:      time.Second,
+		CryptoSecret:             "secret",
+		JSONWebToken:             jsonWebToken,
+		Session:                  sess,
+		Cache:                    rc,
+		Publisher:                publisher,
+		CloudTask:                cloudTask,
+		CustomerRepository:       customerRepo,
+		OrderRepository:          orderRepo,
+		AcquiredTicketRepository: acquiredTicketRepositoryStandIn{},
+		SignInEmailGuard:         lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByEmail),
+		SignInIPGuard:            lockout.NewRedisGuard(logger, rc, lockout.CustomerSignInByIP),
+	})
+	customer.InitHTTPHandler(router, customerSessionMiddleware, validate, customerUseCase, 0)
+
+	orderUseCase := order.NewOrderUseCase(order.OrderUseCaseProperty{
+		AppName:                      "tm-order",
+		Logger:                       logger,
+		Timeout:                      5 * time.Second,
+		BaseURL:                      server.URL + "/tm-order",
+		OrderExpireDuration:          15 * time.Minute,
+		ServiceChargePercentage:      5,
+		TaxPercentage:                11,
+		EventRepository:              eventRepositoryStandIn{cat},
+		ShowRepository:               showRepositoryStandIn{cat},
+		TicketStockRepository:        ticketStockRepositoryStandIn{cat},
+		OrderRuleRangeDateRepository: orderRuleRangeDateRepositoryStandIn{cat},
+		OrderRuleDay:                 orderRuleDayRepositoryStandIn{},
+		OrderRuleRepository:          orderRuleRepositoryStandIn{},
+		OrderRuleRegistry:            orderrule.NewDefaultRegistry(),
+		CustomerRepository:           customerRepo,
+		OrderRepository:              orderRepo,
+		ItemRepository:               itemRepositoryStandIn{orderRepo},
+		Publisher:                    publisher,
+		Outbox:                       orderOutbox,
+		MidtransRepository:           midtrans.NewMidtransRepository(midtransServer.URL, "key", logger, midtransServer.Client()),
+		CloudTask:                    cloudTask,
+		AcquiredTicketRepository:     acquiredTicketRepositoryStandIn{},
+	})
+	order.InitHTTPHandler(router, customerSessionMiddleware, validate, orderUseCase)
+
+	baseURL := server.URL + "/tm-order/v1/customerapp"
+	credential := map[string]string{
+		"name":     "Budi",
+		"email":    "budi@example.com",
+		"password": "rahasia",
+	}
+	placeOrder := order.PlaceOrderRequest{
+		PaymentMethod: "bca",
+		EventID:       "event-1",
+		ShowID:        showID,
+		TicketStockID: ticketStockID,
+		Quantity:      1,
+	}
+
+	var token string
+
+	t.Run("sign up publishes the verification link", func(t *testing.T) {
+		code, _ := do(t, http.MethodPost, baseURL+"/customers/signup", "", credential)
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Len(t, broker.Messages(contract.TopicCustomerSignUp), 1)
+	})
+
+	t.Run("the resent verification link verifies the customer", func(t *testing.T) {
+		code, _ := do(t, http.MethodPost, baseURL+"/customers/resend-verification", "", map[string]string{"email": credential["email"]})
+		assert.Equal(t, http.StatusOK, code)
+
+		messages := broker.Messages(contract.TopicCustomerSignUp)
+		if !assert.Len(t, messages, 2) {
+			t.FailNow()
+		}
+
+		links := make([]string, len(messages))
+		for i, m := range messages {
+			var signUp struct {
+				Type string                  `json:"type"`
+				Data contract.CustomerSignUp `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(m.Value, &signUp))
+			links[i] = signUp.Data.VerificationLink
+			if i > 0 {
+				assert.Equal(t, contract.CustomerVerificationResentV1.Type, signUp.Type)
+			}
+		}
+
+		code, _ = do(t, http.MethodGet, links[1], "", nil)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("a verified customer signs in", func(t *testing.T) {
+		code, env := do(t, http.MethodPost, baseURL+"/customers/signin", "", credential)
+		assert.Equal(t, http.StatusOK, code)
+
+		var resp customer.SignInResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &resp))
+		assert.NotEmpty(t, resp.Token)
+		token = resp.Token
+	})
+
+	t.Run("the signed in customer places an order", func(t *testing.T) {
+		code, env := do(t, http.MethodPost, baseURL+"/orders", token, placeOrder)
+		if !assert.Equal(t, http.StatusCreated, code, env.Message) {
+			t.FailNow()
+		}
+
+		var resp order.PlaceOrderResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &resp))
+		assert.Equal(t, "WAITING_FOR_PAYMENT", resp.Status)
+		assert.Equal(t, "Budi", resp.CustomerName)
+		assert.Equal(t, "budi@example.com", resp.CustomerEmail)
+		assert.Equal(t, float64(1160000), resp.TotalAmount)
+		if assert.NotNil(t, resp.VirtualAccount) {
+			assert.Equal(t, "80000000001", *resp.VirtualAccount)
+		}
+		assert.Len(t, orderRepo.orders, 1)
+		assert.Len(t, cloudTask.tasks, 1, "the order should be scheduled to expire")
+	})
+
+	t.Run("a settled payment publishes order paid through the outbox once the order is paid", func(t *testing.T) {
+		o := orderRepo.orders[0]
+		notification := order.PaymentNotificationEvent{
+			TransactionID:     *o.TransactionID,
+			TransactionStatus: "settlement",
+			OrderID:           o.ID,
+		}
+
+		broker.FailPublish(contract.TopicOrderPaid, io.ErrClosedPipe)
+		code, _ := do(t, http.MethodPost, baseURL+"/orders/on-payment-notification", "", notification)
+		assert.Equal(t, http.StatusOK, code, "the order is paid even though the event could not be published yet")
+		o, _ = orderRepo.FindByID(context.Background(), o.ID, nil)
+		assert.Equal(t, "PAID", o.Status)
+		assert.Empty(t, broker.Messages(contract.TopicOrderPaid))
+		assert.Equal(t, 1, outboxRepo.pending())
+
+		broker.ClearFailures()
+		code, _ = do(t, http.MethodPost, baseURL+"/orders/on-payment-notification", "", notification)
+		assert.Equal(t, http.StatusOK, code, "a retried notification is a no-op")
+		assert.Equal(t, 1, outboxRepo.pending())
+
+		assert.NoError(t, orderOutbox.Relay(context.Background()))
+		assert.Zero(t, outboxRepo.pending())
+		messages := broker.Messages(contract.TopicOrderPaid)
+		if assert.Len(t, messages, 1) {
+			assert.Equal(t, *o.TransactionID, string(messages[0].Key))
+		}
+	})
+
+	t.Run("a forgotten password is reset through the emailed token", func(t *testing.T) {
+		code, _ := do(t, http.MethodPost, baseURL+"/customers/forgot-password", "", map[string]string{"email": credential["email"]})
+		assert.Equal(t, http.StatusOK, code)
+
+		messages := broker.Messages(contract.TopicCustomerResetPassword)
+		if !assert.Len(t, messages, 1) {
+			t.FailNow()
+		}
+
+		var resetPassword struct {
+			Data contract.CustomerResetPassword `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(messages[0].Value, &resetPassword))
+
+		link, err := url.Parse(resetPassword.Data.ResetPasswordLink)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		reset := customer.ResetPasswordRequest{
+			Token:       link.Query().Get("token"),
+			NewPassword: "rahasia-baru",
+		}
+
+		code, _ = do(t, http.MethodPost, baseURL+"/customers/reset-password", "", reset)
+		assert.Equal(t, http.StatusOK, code)
+
+		code, _ = do(t, http.MethodGet, baseURL+"/customers/profile", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, code, "the sessions are invalidated on reset")
+
+		code, _ = do(t, http.MethodPost, baseURL+"/customers/signin", "", map[string]string{"email": credential["email"], "password": reset.NewPassword})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("every device has its own session and refresh token", func(t *testing.T) {
+		currentCredential := map[string]string{"email": credential["email"], "password": "rahasia-baru"}
+		signIn := func(t *testing.T, userAgent string) customer.SignInResponse {
+			buff, _ := json.Marshal(currentCredential)
+			req, _ := http.NewRequest(http.MethodPost, baseURL+"/customers/signin", bytes.NewBuffer(buff))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", userAgent)
+
+			resp, err := http.DefaultClient.Do(req)
+			if !assert.NoError(t, err) || !assert.Equal(t, http.StatusOK, resp.StatusCode) {
+				t.FailNow()
+			}
+			defer resp.Body.Close()
+
+			var env envelope
+			var signInResp customer.SignInResponse
+			json.NewDecoder(resp.Body).Decode(&env)
+			assert.NoError(t, json.Unmarshal(env.Data, &signInResp))
+
+			return signInResp
+		}
+
+		code, _ := do(t, http.MethodDelete, baseURL+"/customers/sessions", signIn(t, "cleanup").Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		phone := signIn(t, "phone")
+		laptop := signIn(t, "laptop")
+		assert.NotEmpty(t, phone.RefreshToken)
+
+		code, env := do(t, http.MethodGet, baseURL+"/customers/sessions", phone.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+		var sessions []customer.SessionResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &sessions))
+		if assert.Len(t, sessions, 2) {
+			assert.Equal(t, "laptop", sessions[0].UserAgent, "the newest session comes first")
+			assert.False(t, sessions[0].Current)
+			assert.Equal(t, "phone", sessions[1].UserAgent)
+			assert.True(t, sessions[1].Current)
+		}
+
+		code, env = do(t, http.MethodPost, baseURL+"/customers/refresh", "", customer.RefreshRequest{RefreshToken: phone.RefreshToken})
+		assert.Equal(t, http.StatusOK, code)
+		var refreshed customer.SignInResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &refreshed))
+		assert.NotEqual(t, phone.RefreshToken, refreshed.RefreshToken, "the refresh token is rotated")
+
+		code, _ = do(t, http.MethodGet, baseURL+"/customers/profile", refreshed.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		tablet := signIn(t, "tablet")
+		code, env = do(t, http.MethodGet, baseURL+"/customers/sessions", tablet.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+		sessions = nil
+		assert.NoError(t, json.Unmarshal(env.Data, &sessions))
+		if !assert.Len(t, sessions, 3) || !assert.Equal(t, "laptop", sessions[1].UserAgent) {
+			t.FailNow()
+		}
+		laptopSessionID := sessions[1].ID
+
+		code, _ = do(t, http.MethodDelete, baseURL+"/customers/sessions/"+laptopSessionID, tablet.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+		code, _ = do(t, http.MethodGet, baseURL+"/customers/profile", laptop.Token, nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		code, _ = do(t, http.MethodDelete, baseURL+"/customers/sessions", tablet.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+		code, _ = do(t, http.MethodGet, baseURL+"/customers/profile", tablet.Token, nil)
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+	t.Run("a legacy password hash is upgraded on sign in", func(t *testing.T) {
+		legacySalt := util.GenerateRandomHEX(32)
+		ID, _ := customerRepo.Save(context.Background(), customer.Customer{
+			Name:               "Lama",
+			Email:              "lama@example.com",
+			Password:           util.GenerateSecret("secret"+"rahasia-lama", legacySalt, 256),
+			PasswordSalt:       legacySalt,
+			VerificationStatus: customer.VerficationStatusVerified,
+			MemberStatus:       customer.MemberStatusActive,
+		}, nil)
+		legacy := map[string]string{"email": "lama@example.com", "password": "rahasia-lama"}
+
+		code, _ := do(t, http.MethodPost, baseURL+"/customers/signin", "", legacy)
+		assert.Equal(t, http.StatusOK, code)
+
+		c, _ := customerRepo.FindByID(context.Background(), ID, nil)
+		assert.False(t, password.IsLegacy(c.Password))
+		assert.Empty(t, c.PasswordSalt)
+
+		code, _ = do(t, http.MethodPost, baseURL+"/customers/signin", "", legacy)
+		assert.Equal(t, http.StatusOK, code, "the upgraded hash still verifies")
+	})
+	t.Run("a customer with two-factor authentication completes the sign in with a code", func(t *testing.T) {
+		currentCredential := map[string]string{"email": credential["email"], "password": "rahasia-baru"}
+		code, env := do(t, http.MethodPost, baseURL+"/customers/signin", "", currentCredential)
+		if !assert.Equal(t, http.StatusOK, code) {
+			t.FailNow()
+		}
+		var signIn customer.SignInResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &signIn))
+		assert.Nil(t, signIn.TwoFactor)
+
+		code, env = do(t, http.MethodPost, baseURL+"/customers/two-factor/enroll", signIn.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+		var enrollment customer.EnrollTwoFactorResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &enrollment))
+		assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/")
+
+		totp, _ := twofactor.Code(enrollment.Secret, time.Now())
+		code, env = do(t, http.MethodPost, baseURL+"/customers/two-factor/confirm", signIn.Token, map[string]string{"code": totp})
+		if !assert.Equal(t, http.StatusOK, code, env.Message) {
+			t.FailNow()
+		}
+		var recovery customer.RecoveryCodeResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &recovery))
+		assert.Len(t, recovery.RecoveryCodes, twofactor.RecoveryCodeCount)
+
+		challenge := func(t *testing.T) string {
+			code, env := do(t, http.MethodPost, baseURL+"/customers/signin", "", currentCredential)
+			var signIn customer.SignInResponse
+			assert.NoError(t, json.Unmarshal(env.Data, &signIn))
+			if !assert.Equal(t, http.StatusOK, code) || !assert.NotNil(t, signIn.TwoFactor) {
+				t.FailNow()
+			}
+			assert.Empty(t, signIn.Token, "the token is only issued once the code is verified")
+
+			return signIn.TwoFactor.ChallengeToken
+		}
+
+		code, env = do(t, http.MethodPost, baseURL+"/customers/signin/two-factor", "", customer.VerifyTwoFactorRequest{ChallengeToken: challenge(t), Code: recovery.RecoveryCodes[0]})
+		assert.Equal(t, http.StatusOK, code)
+		var verified customer.SignInResponse
+		assert.NoError(t, json.Unmarshal(env.Data, &verified))
+		code, _ = do(t, http.MethodGet, baseURL+"/customers/profile", verified.Token, nil)
+		assert.Equal(t, http.StatusOK, code)
+
+		nextTOTP, _ := twofactor.Code(enrollment.Secret, time.Now().Add(twofactor.Period))
+		code, _ = do(t, http.MethodPost, baseURL+"/customers/two-factor/disable", verified.Token, map[string]string{"password": "rahasia-baru", "code": nextTOTP})
+		assert.Equal(t, http.StatusOK, code)
+
+		code, env = do(t, http.MethodPost, baseURL+"/customers/signin", "", currentCredential)
+		assert.Equal(t, http.StatusOK, code)
+		signIn = customer.SignInResponse{}
+		assert.NoError(t, json.Unmarshal(env.Data, &signIn))
+		assert.Nil(t, signIn.TwoFactor)
+		assert.NotEmpty(t, signIn.Token)
+	})
+
+	t.Run("a deleted account is anonymized after the grace period unless the customer signs in", func(t *testing.T) {
+		currentCredential := map[string]string{"email": credential["email"], "password": "rahasia-baru"}
+		signIn := func(t *testing.T) string {
+			code, env := do(t, http.MethodPost, baseURL+"/customers/signin", "", currentCredential)
+			var signIn customer.SignInResponse
+			assert.NoError(t, json.Unmarshal(env.Data, &signIn))
+			if !assert.Equal(t, http.StatusOK, code) {
+				t.FailNow()
+			}
+
+			return signIn.Token
+		}
+		deleteProfile := func(t *testing.T, token string) gctasks.Request {
+			code, env := do(t, http.MethodDelete, baseURL+"/customers/profile", token, map[string]string{"password": currentCredential["password"]})
+			if !assert.Equal(t, http.StatusOK, code, env.Message) {
+				t.FailNow()
+			}
+			var resp customer.DeleteProfileResponse
+			assert.NoError(t, json.Unmarshal(env.Data, &resp))
+			assert.False(t, resp.DeletionScheduledAt.IsZero())
+
+			task := cloudTask.tasks[len(cloudTask.tasks)-1]
+			assert.Equal(t, server.URL+"/tm-order"+customer.OnDeleteURLPath, task.URL)
+
+			return task
+		}
+		onDelete := func(t *testing.T, task gctasks.Request) int {
+			code, _ := do(t, http.MethodPost, task.URL, "", json.RawMessage(task.Body))
+			return code
+		}
+
+		token := signIn(t)
+		cancelledTask := deleteProfile(t, token)
+		code, _ := do(t, http.MethodGet, baseURL+"/customers/profile", token, nil)
+		assert.Equal(t, http.StatusUnauthorized, code, "every session is signed out")
+
+		token = signIn(t)
+		c, _ := customerRepo.FindByEmail(context.Background(), credential["email"], nil)
+		assert.Nil(t, c.DeletionRequestedAt, "signing in cancels the deletion")
+		assert.Equal(t, http.StatusOK, onDelete(t, cancelledTask))
+		c, _ = customerRepo.FindByID(context.Background(), c.ID, nil)
+		assert.Equal(t, credential["email"], c.Email, "a cancelled deletion does nothing")
+
+		task := deleteProfile(t, token)
+		time.Sleep(time.Second)
+		assert.Equal(t, http.StatusOK, onDelete(t, task))
+
+		c, _ = customerRepo.FindByID(context.Background(), c.ID, nil)
+		assert.Equal(t, customer.AnonymizedName, c.Name)
+		assert.NotEqual(t, credential["email"], c.Email)
+		assert.Equal(t, customer.MemberStatusDeleted, c.MemberStatus)
+		assert.Empty(t, c.Password)
+		assert.NotNil(t, c.DeletedAt)
+		for _, o := range orderRepo.orders {
+			if o.CustomerID == c.ID {
+				assert.Equal(t, customer.AnonymizedName, o.CustomerName)
+				assert.Equal(t, c.Email, o.CustomerEmail)
+			}
+		}
+		assert.Len(t, broker.Messages(contract.TopicCustomerDeleted), 1)
+
+		deletedAt := *c.DeletedAt
+		assert.Equal(t, http.StatusOK, onDelete(t, task))
+		c, _ = customerRepo.FindByID(context.Background(), c.ID, nil)
+		assert.True(t, deletedAt.Equal(*c.DeletedAt), "a retried task does not anonymize the customer again")
+		assert.Len(t, broker.Messages(contract.TopicCustomerDeleted), 2, "a retried task publishes the deletion again")
+	})
+}
@@ -0,0 +1,469 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/customer"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/event"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/midtrans"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/order"
+	"github.com/tsel-ticketmaster/tm-order/internal/module/customerapp/ticket"
+	"github.com/tsel-ticketmaster/tm-order/internal/pkg/outbox"
+	"github.com/tsel-ticketmaster/tm-order/pkg/errors"
+	"github.com/tsel-ticketmaster/tm-order/pkg/gctasks"
+	"github.com/tsel-ticketmaster/tm-order/pkg/status"
+)
+
+type customerRepositoryStandIn struct {
+	mu        sync.Mutex
+	customers map[int64]customer.Customer
+}
+
+func newCustomerRepositoryStandIn() *customerRepositoryStandIn {
+	return &customerRepositoryStandIn{customers: make(map[int64]customer.Customer)}
+}
+
+func (r *customerRepositoryStandIn) Save(ctx context.Context, c customer.Customer, tx *sql.Tx) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c.ID = int64(len(r.customers) + 1)
+	r.customers[c.ID] = c
+
+	return c.ID, nil
+}
+
+func (r *customerRepositoryStandIn) FindByID(ctx context.Context, ID int64, tx *sql.Tx) (customer.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.customers[ID]
+	if !ok {
+		return customer.Customer{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "customer is not found")
+	}
+
+	return c, nil
+}
+
+func (r *customerRepositoryStandIn) FindByEmail(ctx context.Context, email string, tx *sql.Tx) (customer.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.customers {
+		if c.Email == email {
+			return c, nil
+		}
+	}
+
+	return customer.Customer{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "customer is not found")
+}
+
+func (r *customerRepositoryStandIn) Update(ctx context.Context, ID int64, update customer.Customer, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.customers[ID]; !ok {
+		return errors.New(http.StatusNotFound, status.NOT_FOUND, "customer is not found")
+	}
+	r.customers[ID] = update
+
+	return nil
+}
+
+func (r *customerRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, nil
+}
+
+func (r *customerRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *customerRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+// catalog holds a single event with a single show and a single ticket stock.
+type catalog struct {
+	event       event.Event
+	show        event.Show
+	ticketStock ticket.TicketStock
+	rangeDate   order.OrderRuleRangeDate
+}
+
+type eventRepositoryStandIn struct{ *catalog }
+
+func (r eventRepositoryStandIn) FindByID(ctx context.Context, ID string, tx *sql.Tx) (event.Event, error) {
+	if ID != r.event.ID {
+		return event.Event{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "event is not found")
+	}
+
+	return r.event, nil
+}
+
+func (r eventRepositoryStandIn) FindMany(ctx context.Context, filter event.EventFilter, offset, limit int64, tx *sql.Tx) ([]event.Event, error) {
+	if offset > 0 || (filter.Status != "" && filter.Status != r.event.Status) {
+		return []event.Event{}, nil
+	}
+
+	return []event.Event{r.event}, nil
+}
+
+func (r eventRepositoryStandIn) Count(ctx context.Context, filter event.EventFilter, tx *sql.Tx) (int64, error) {
+	if filter.Status != "" && filter.Status != r.event.Status {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
+func (r eventRepositoryStandIn) Search(ctx context.Context, filter event.SearchFilter, offset, limit int64, tx *sql.Tx) ([]event.SearchResult, error) {
+	return []event.SearchResult{}, nil
+}
+
+func (r eventRepositoryStandIn) CountSearch(ctx context.Context, filter event.SearchFilter, tx *sql.Tx) (int64, error) {
+	return 0, nil
+}
+
+type showRepositoryStandIn struct{ *catalog }
+
+func (r showRepositoryStandIn) FindByID(ctx context.Context, ID string, tx *sql.Tx) (event.Show, error) {
+	if ID != r.show.ID {
+		return event.Show{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "show is not found")
+	}
+
+	return r.show, nil
+}
+
+func (r showRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]event.Show, error) {
+	return []event.Show{r.show}, nil
+}
+
+type ticketStockRepositoryStandIn struct{ *catalog }
+
+func (r ticketStockRepositoryStandIn) FindManyByShowID(ctx context.Context, showID string, tx *sql.Tx) ([]ticket.TicketStock, error) {
+	return []ticket.TicketStock{r.ticketStock}, nil
+}
+
+func (r ticketStockRepositoryStandIn) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (ticket.TicketStock, error) {
+	if ID != r.ticketStock.ID {
+		return ticket.TicketStock{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "ticket stock is not found")
+	}
+
+	return r.ticketStock, nil
+}
+
+func (r ticketStockRepositoryStandIn) Update(ctx context.Context, ID string, ts ticket.TicketStock, tx *sql.Tx) error {
+	r.ticketStock = ts
+
+	return nil
+}
+
+type acquiredTicketRepositoryStandIn struct{}
+
+func (acquiredTicketRepositoryStandIn) CountByEventIDAndCustomerID(ctx context.Context, eventID string, customerID int64, tx *sql.Tx) (int64, error) {
+	return 0, nil
+}
+
+func (acquiredTicketRepositoryStandIn) AnonymizeByCustomerID(ctx context.Context, customerID int64, name, email string, tx *sql.Tx) error {
+	return nil
+}
+
+type orderRuleRangeDateRepositoryStandIn struct{ *catalog }
+
+func (r orderRuleRangeDateRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]order.OrderRuleRangeDate, error) {
+	return []order.OrderRuleRangeDate{r.rangeDate}, nil
+}
+
+type orderRuleDayRepositoryStandIn struct{}
+
+func (orderRuleDayRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]order.OrderRuleDay, error) {
+	return nil, nil
+}
+
+type orderRuleRepositoryStandIn struct{}
+
+func (orderRuleRepositoryStandIn) FindManyByEventID(ctx context.Context, eventID string, tx *sql.Tx) ([]order.OrderRule, error) {
+	return nil, nil
+}
+
+// orderRepositoryStandIn does not run transactions, every write is applied immediately.
+type orderRepositoryStandIn struct {
+	mu     sync.Mutex
+	orders []order.Order
+	items  []order.Item
+}
+
+func (r *orderRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, nil
+}
+
+func (r *orderRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *orderRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *orderRepositoryStandIn) AnonymizeByCustomerID(ctx context.Context, customerID int64, name, email string, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for k, o := range r.orders {
+		if o.CustomerID == customerID {
+			r.orders[k].CustomerName = name
+			r.orders[k].CustomerEmail = email
+		}
+	}
+
+	return nil
+}
+
+func (r *orderRepositoryStandIn) Save(ctx context.Context, o order.Order, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.orders = append(r.orders, o)
+
+	return nil
+}
+
+func (r *orderRepositoryStandIn) FindByID(ctx context.Context, ID string, tx *sql.Tx) (order.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, o := range r.orders {
+		if o.ID == ID {
+			return o, nil
+		}
+	}
+
+	return order.Order{}, errors.New(http.StatusNotFound, status.NOT_FOUND, "order is not found")
+}
+
+func (r *orderRepositoryStandIn) FindByIDForUpdate(ctx context.Context, ID string, tx *sql.Tx) (order.Order, error) {
+	return r.FindByID(ctx, ID, tx)
+}
+
+func (r *orderRepositoryStandIn) FindMany(ctx context.Context, customerID int64, offset, limit int64, tx *sql.Tx) ([]order.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	orders := []order.Order{}
+	for _, o := range r.orders {
+		if o.CustomerID == customerID {
+			orders = append(orders, o)
+		}
+	}
+
+	return orders, nil
+}
+
+func (r *orderRepositoryStandIn) Count(ctx context.Context, customerID int64, tx *sql.Tx) (int64, error) {
+	orders, _ := r.FindMany(ctx, customerID, 0, 0, tx)
+
+	return int64(len(orders)), nil
+}
+
+func (r *orderRepositoryStandIn) Update(ctx context.Context, ID string, o order.Order, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.orders {
+		if r.orders[i].ID == ID {
+			r.orders[i] = o
+			return nil
+		}
+	}
+
+	return errors.New(http.StatusNotFound, status.NOT_FOUND, "order is not found")
+}
+
+func (r *orderRepositoryStandIn) CountActiveOrderByCustomerID(ctx context.Context, customerID int64, tx *sql.Tx) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, o := range r.orders {
+		if o.CustomerID == customerID && o.Status == "WAITING_FOR_PAYMENT" {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+type itemRepositoryStandIn struct{ *orderRepositoryStandIn }
+
+func (r itemRepositoryStandIn) FindManyByOrderID(ctx context.Context, orderID string, tx *sql.Tx) ([]order.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := []order.Item{}
+	for _, i := range r.items {
+		if i.OrderID == orderID {
+			items = append(items, i)
+		}
+	}
+
+	return items, nil
+}
+
+func (r itemRepositoryStandIn) Save(ctx context.Context, i order.Item, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items = append(r.items, i)
+
+	return nil
+}
+
+// outboxRepositoryStandIn keeps the outbox messages in memory, locking is not needed as the relay is called in turn.
+type outboxRepositoryStandIn struct {
+	mu       sync.Mutex
+	messages []outbox.Message
+}
+
+func (r *outboxRepositoryStandIn) BeginTx(ctx context.Context) (*sql.Tx, error) {
+	return nil, nil
+}
+
+func (r *outboxRepositoryStandIn) CommitTx(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *outboxRepositoryStandIn) Rollback(ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (r *outboxRepositoryStandIn) Save(ctx context.Context, m outbox.Message, tx *sql.Tx) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m.ID = int64(len(r.messages) + 1)
+	r.messages = append(r.messages, m)
+
+	return m.ID, nil
+}
+
+func (r *outboxRepositoryStandIn) FindManyUnpublishedForUpdate(ctx context.Context, IDs []int64, limit int64, tx *sql.Tx) ([]outbox.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[int64]bool, len(IDs))
+	for _, ID := range IDs {
+		wanted[ID] = true
+	}
+
+	messages := []outbox.Message{}
+	for _, m := range r.messages {
+		if m.PublishedAt == nil && (len(IDs) == 0 || wanted[m.ID]) && int64(len(messages)) < limit {
+			messages = append(messages, m)
+		}
+	}
+
+	return messages, nil
+}
+
+func (r *outboxRepositoryStandIn) FindFirstUnpublishedIDByKeys(ctx context.Context, keys []string, excludedIDs []int64, tx *sql.Tx) (map[string]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	excluded := make(map[int64]bool, len(excludedIDs))
+	for _, ID := range excludedIDs {
+		excluded[ID] = true
+	}
+
+	first := make(map[string]int64)
+	for _, key := range keys {
+		for _, m := range r.messages {
+			if m.Key == key && m.PublishedAt == nil && !excluded[m.ID] {
+				first[key] = m.ID
+				break
+			}
+		}
+	}
+
+	return first, nil
+}
+
+func (r *outboxRepositoryStandIn) MarkPublished(ctx context.Context, IDs []int64, publishedAt time.Time, tx *sql.Tx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ID := range IDs {
+		r.messages[ID-1].PublishedAt = &publishedAt
+	}
+
+	return nil
+}
+
+func (r *outboxRepositoryStandIn) pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pending int
+	for _, m := range r.messages {
+		if m.PublishedAt == nil {
+			pending++
+		}
+	}
+
+	return pending
+}
+
+// cloudTaskStandIn records the deferred tasks instead of sending them to google cloud tasks.
+type cloudTaskStandIn struct {
+	mu    sync.Mutex
+	tasks []gctasks.Request
+}
+
+func (c *cloudTaskStandIn) CreateQueue(id string) error {
+	return nil
+}
+
+func (c *cloudTaskStandIn) CreateTask(queueID string, request gctasks.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tasks = append(c.tasks, request)
+
+	return nil
+}
+
+func (c *cloudTaskStandIn) DeferCreateTaskInDuration(queueID string, request gctasks.Request, duration time.Duration) error {
+	return c.CreateTask(queueID, request)
+}
+
+func (c *cloudTaskStandIn) DeferCreateTaskInTime(queueID string, request gctasks.Request, schedule time.Time) error {
+	return c.CreateTask(queueID, request)
+}
+
+func (c *cloudTaskStandIn) Close() error {
+	return nil
+}
+
+// newMidtransStandIn answers every charge with a bank transfer that is waiting for payment.
+func newMidtransStandIn() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req midtrans.ChargeRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(midtrans.ChargeResponse{
+			StatusCode:        "201",
+			TransactionID:     "midtrans-transaction-1",
+			OrderID:           req.TransactionDetails.OrderID,
+			TransactionStatus: "pending",
+			VaNumbers: []midtrans.VANumber{
+				{Bank: req.BankTransfer.Bank, VaNumber: "80000000001"},
+			},
+		})
+	}))
+}